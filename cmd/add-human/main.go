@@ -9,6 +9,7 @@ import (
 	"cloud.google.com/go/firestore"
 	"github.com/raymonstah/asianamericanswiki/functions/api"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
 	"github.com/urfave/cli/v2"
 )
 
@@ -19,6 +20,7 @@ func main() {
 		DOB         string
 		Ethnicity   string
 		Description string
+		BirthPlace  string
 		Location    string
 		Instagram   string
 		Tags        string
@@ -32,10 +34,11 @@ func main() {
 		Flags: []cli.Flag{
 			&cli.StringFlag{Name: "name", Required: true, Destination: &opts.Name},
 			&cli.StringFlag{Name: "aliases", Usage: "Comma separated aliases", Destination: &opts.Aliases},
-			&cli.StringFlag{Name: "dob", Usage: "YYYY-MM-DD", Destination: &opts.DOB},
+			&cli.StringFlag{Name: "dob", Usage: "YYYY-MM-DD, YYYY-MM or YYYY", Destination: &opts.DOB},
 			&cli.StringFlag{Name: "ethnicity", Usage: "Comma separated ethnicities", Destination: &opts.Ethnicity},
 			&cli.StringFlag{Name: "description", Destination: &opts.Description},
-			&cli.StringFlag{Name: "location", Usage: "Comma separated locations", Destination: &opts.Location},
+			&cli.StringFlag{Name: "birth-place", Usage: "Where they were born, e.g. \"Honolulu, HI\"", Destination: &opts.BirthPlace},
+			&cli.StringFlag{Name: "location", Usage: "Semicolon separated places they are based in, e.g. \"Los Angeles, CA; Seoul\"", Destination: &opts.Location},
 			&cli.StringFlag{Name: "instagram", Destination: &opts.Instagram},
 			&cli.StringFlag{Name: "tags", Usage: "Comma separated tags", Destination: &opts.Tags},
 			&cli.StringFlag{Name: "gender", Value: "nonbinary", Destination: &opts.Gender},
@@ -64,10 +67,11 @@ func main() {
 				ethnicities[i] = strings.TrimSpace(e)
 			}
 
-			locations := strings.Split(opts.Location, ",")
-			for i, l := range locations {
-				locations[i] = strings.TrimSpace(l)
+			var birthPlace *place.Place
+			if p := place.Parse(opts.BirthPlace); !p.IsZero() {
+				birthPlace = &p
 			}
+			basedIn := place.ParseAll(strings.Split(opts.Location, ";"))
 
 			tags := strings.Split(opts.Tags, ",")
 			for i, t := range tags {
@@ -82,13 +86,19 @@ func main() {
 				}
 			}
 
+			dob, err := humandao.ParsePartialDate(opts.DOB)
+			if err != nil {
+				return err
+			}
+
 			input := humandao.AddHumanInput{
 				Name:        opts.Name,
 				Aliases:     aliases,
-				DOB:         opts.DOB,
+				DOB:         dob,
 				Ethnicity:   ethnicities,
 				Description: opts.Description,
-				Location:    locations,
+				BirthPlace:  birthPlace,
+				BasedIn:     basedIn,
 				Instagram:   opts.Instagram,
 				Tags:        tags,
 				Gender:      humandao.Gender(opts.Gender),
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
 	"strings"
 
 	"cloud.google.com/go/firestore"
@@ -49,6 +50,7 @@ func main() {
 }
 
 func action(ctx context.Context, cmd *cli.Command) error {
+	ctx = humandao.WithAuthor(ctx, "compute-similar")
 	fsClient, err := firestore.NewClient(ctx, api.ProjectID)
 	if err != nil {
 		return fmt.Errorf("unable to create firestore client: %w", err)
@@ -73,9 +75,7 @@ func action(ctx context.Context, cmd *cli.Command) error {
 		weaviateClient: weaviateClient,
 	}
 
-	humans, err := humanDAO.ListHumans(ctx, humandao.ListHumansInput{
-		Limit: 500,
-	})
+	humans, err := humandao.IterateHumans(ctx, humanDAO, humandao.ListHumansInput{}).GetAll()
 	if err != nil {
 		return fmt.Errorf("unable to list humans: %w", err)
 	}
@@ -97,11 +97,10 @@ func action(ctx context.Context, cmd *cli.Command) error {
 			fmt.Printf("\t%s\n", similarHuman.Name)
 		}
 
-		human.Similar = ids
 		if opts.Dry {
 			continue
 		}
-		if err := humanDAO.UpdateHuman(ctx, human); err != nil {
+		if _, err := humanDAO.PatchHuman(ctx, human.ID, humandao.HumanPatch{Similar: &ids}); err != nil {
 			return fmt.Errorf("unable to update human: %w", err)
 		}
 		fmt.Printf("Updated %s - %s\n", human.Name, human.ID)
@@ -151,7 +150,7 @@ func initWeaviate(ctx context.Context) (*weaviate.Client, error) {
 
 type Handler struct {
 	fsClient       *firestore.Client
-	humanDAO       *humandao.DAO
+	humanDAO       humandao.HumanStore
 	weaviateClient *weaviate.Client
 	genModel       *genai.GenerativeModel
 	embModel       *genai.EmbeddingModel
@@ -183,13 +182,17 @@ func (h *Handler) build(ctx context.Context, humans []humandao.Human) error {
 		// Use the batch embedding API to embed all documents at once.
 		batch := h.embModel.NewBatch()
 		for _, human := range humans {
+			locations := slices.Clone(human.Location)
+			for _, p := range human.BasedIn {
+				locations = append(locations, p.String())
+			}
 			batch.AddContent(
 				// Name suggests people with similar names -- not ideal.
 				// genai.Text(fmt.Sprintf("Name: %s", human.Name)),
 				genai.Text(fmt.Sprintf("Gender: %s", human.Gender)),
 				genai.Text(fmt.Sprintf("Ethnicity: %s", strings.Join(human.Ethnicity, ","))),
 				genai.Text(fmt.Sprintf("Tags: %s", strings.Join(human.Tags, ","))),
-				genai.Text(fmt.Sprintf("Location: %s", strings.Join(human.Location, ","))),
+				genai.Text(fmt.Sprintf("Location: %s", strings.Join(locations, "; "))),
 				genai.Text(human.Description),
 			)
 		}
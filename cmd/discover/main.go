@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strings"
 	"time"
 	"unicode"
@@ -19,6 +20,7 @@ import (
 	"github.com/raymonstah/asianamericanswiki/functions/api"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
 	"github.com/raymonstah/asianamericanswiki/internal/imageutil"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
 	"github.com/raymonstah/asianamericanswiki/internal/xai"
 	"github.com/urfave/cli/v2"
 )
@@ -69,8 +71,9 @@ func main() {
 }
 
 type Discoverer struct {
-	dao           *humandao.DAO
+	dao           humandao.HumanStore
 	existing      map[string]struct{}
+	tags          humandao.Taxonomy
 	xaiClient     *xai.Client
 	storageClient *storage.Client
 	uploader      *imageutil.Uploader
@@ -98,19 +101,18 @@ func newDiscoverer(ctx context.Context) (*Discoverer, error) {
 		return nil, err
 	}
 	dao := humandao.NewDAO(client)
-	humans, err := dao.ListHumans(ctx, humandao.ListHumansInput{
-		Limit:         10000,
-		IncludeDrafts: true,
-	})
+	humans, err := humandao.IterateHumans(ctx, dao, humandao.ListHumansInput{IncludeDrafts: true}).GetAll()
 	if err != nil {
 		return nil, err
 	}
 
 	existing := make(map[string]struct{})
 	for _, h := range humans {
-		existing[strings.ToLower(h.Name)] = struct{}{}
-		existing[strings.ToLower(h.Path)] = struct{}{}
-		existing[NormalizePath(h.Name)] = struct{}{}
+		addExisting(existing, h)
+	}
+	tags, err := dao.Tags(ctx)
+	if err != nil {
+		return nil, err
 	}
 
 	var xClient *xai.Client
@@ -132,6 +134,7 @@ func newDiscoverer(ctx context.Context) (*Discoverer, error) {
 	return &Discoverer{
 		dao:           dao,
 		existing:      existing,
+		tags:          tags,
 		xaiClient:     xClient,
 		storageClient: storageClient,
 		uploader:      uploader,
@@ -184,6 +187,37 @@ func (d *Discoverer) FindImageURL(ctx context.Context, name string) (string, err
 	return d.findGoogleImageURL(ctx, name)
 }
 
+// partialDate parses a date from xAI, dropping it if it can't be read rather than failing the whole draft.
+func partialDate(date string) humandao.PartialDate {
+	parsed, err := humandao.ParsePartialDate(date)
+	if err != nil {
+		fmt.Printf("Ignoring date from XAI: %v\n", err)
+		return ""
+	}
+	return parsed
+}
+
+// sources turns the sources cited by xAI into sources for the draft, dropping the ones that don't link
+// to a web page, and the fields a source claims to back up that aren't facts about a human.
+func sources(generated []xai.GeneratedSource) []humandao.Source {
+	var sources []humandao.Source
+	for _, g := range generated {
+		u, err := url.Parse(strings.TrimSpace(g.URL))
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			fmt.Printf("Ignoring source from XAI: %q is not a http(s) url\n", g.URL)
+			continue
+		}
+		source := humandao.Source{URL: u.String(), Title: g.Title, Publisher: g.Publisher}
+		for _, field := range g.Fields {
+			if slices.Contains(humandao.SourceFields, field) {
+				source.Fields = append(source.Fields, field)
+			}
+		}
+		sources = append(sources, source)
+	}
+	return sources
+}
+
 func NormalizePath(name string) string {
 	var b strings.Builder
 	for _, r := range strings.ToLower(name) {
@@ -201,6 +235,16 @@ func NormalizePath(name string) string {
 	return strings.Trim(res, "-")
 }
 
+// addExisting records every name the human is known by, including their aliases and names in
+// other scripts, so a candidate found under any of them is skipped.
+func addExisting(existing map[string]struct{}, h humandao.Human) {
+	existing[strings.ToLower(h.Path)] = struct{}{}
+	for _, name := range h.AllNames() {
+		existing[strings.ToLower(name)] = struct{}{}
+		existing[NormalizePath(name)] = struct{}{}
+	}
+}
+
 func (d *Discoverer) Exists(name string) bool {
 	if _, ok := d.existing[strings.ToLower(name)]; ok {
 		return true
@@ -309,8 +353,11 @@ func (d *Discoverer) GenerateAndUploadImage(ctx context.Context, human humandao.
 		return human, nil
 	}
 
-	human.AIGenerated = true
-	human, err = d.uploader.UploadHumanImages(ctx, human, raw)
+	image := humandao.Image{AIGenerated: true}
+	if strings.HasPrefix(sourceImageURL, "http") {
+		image.SourceURL = sourceImageURL
+	}
+	human, err = d.uploader.UploadHumanImage(ctx, imageutil.UploadHumanImageInput{Human: human, RawImage: raw, Image: image, Featured: true})
 	if err != nil {
 		return human, err
 	}
@@ -321,7 +368,7 @@ func (d *Discoverer) GenerateAndUploadImage(ctx context.Context, human humandao.
 }
 
 func discoverWikipedia(c *cli.Context) error {
-	ctx := c.Context
+	ctx := humandao.WithAuthor(c.Context, "discover")
 	d, err := newDiscoverer(ctx)
 	if err != nil {
 		return err
@@ -375,8 +422,8 @@ func discoverWikipedia(c *cli.Context) error {
 					fmt.Printf("Enriched data for %s: %+v\n", enriched.Name, enriched)
 					input.Name = enriched.Name // Use the full name from AI
 					input.Description = enriched.Description
-					input.DOB = enriched.DOB
-					input.DOD = enriched.DOD
+					input.DOB = partialDate(enriched.DOB)
+					input.DOD = partialDate(enriched.DOD)
 					input.Ethnicity = enriched.Ethnicity
 					input.Gender = humandao.Gender(enriched.Gender)
 					if input.Gender == "" {
@@ -384,13 +431,12 @@ func discoverWikipedia(c *cli.Context) error {
 					}
 					input.Website = enriched.Website
 					input.Twitter = enriched.Twitter
-					input.Location = enriched.Location
-					for _, tag := range enriched.Tags {
-						t := strings.ToLower(strings.TrimSpace(tag))
-						if humandao.IsValidTag(t) {
-							input.Tags = append(input.Tags, t)
-						}
+					if birthPlace := place.Parse(enriched.BirthPlace); !birthPlace.IsZero() {
+						input.BirthPlace = &birthPlace
 					}
+					input.BasedIn = place.ParseAll(enriched.Location)
+					input.Tags = d.tags.Known(enriched.Tags)
+					input.Sources = sources(enriched.Sources)
 					fullAsian = enriched.FullAsian
 
 					// Double check if name changed and if it's already in database
@@ -430,8 +476,7 @@ func discoverWikipedia(c *cli.Context) error {
 				}
 				fmt.Printf("Saved human %s with ID: %s\n", human.Name, human.ID)
 				// update existing map to prevent duplicates in the same run
-				d.existing[strings.ToLower(human.Name)] = struct{}{}
-				d.existing[strings.ToLower(human.Path)] = struct{}{}
+				addExisting(d.existing, human)
 			}
 
 			if opts.GenImage {
@@ -500,7 +545,7 @@ func (d *Discoverer) fetchWikipediaCategoryMembers(ctx context.Context, category
 }
 
 func brainstorm(c *cli.Context) error {
-	ctx := c.Context
+	ctx := humandao.WithAuthor(c.Context, "discover")
 	d, err := newDiscoverer(ctx)
 	if err != nil {
 		return err
@@ -550,8 +595,8 @@ func brainstorm(c *cli.Context) error {
 				fmt.Printf("Enriched data for %s: %+v\n", enriched.Name, enriched)
 				input.Name = enriched.Name // Use full name
 				input.Description = enriched.Description
-				input.DOB = enriched.DOB
-				input.DOD = enriched.DOD
+				input.DOB = partialDate(enriched.DOB)
+				input.DOD = partialDate(enriched.DOD)
 				input.Ethnicity = enriched.Ethnicity
 				input.Gender = humandao.Gender(enriched.Gender)
 				if input.Gender == "" {
@@ -559,13 +604,12 @@ func brainstorm(c *cli.Context) error {
 				}
 				input.Website = enriched.Website
 				input.Twitter = enriched.Twitter
-				input.Location = enriched.Location
-				for _, tag := range enriched.Tags {
-					t := strings.ToLower(strings.TrimSpace(tag))
-					if humandao.IsValidTag(t) {
-						input.Tags = append(input.Tags, t)
-					}
+				if birthPlace := place.Parse(enriched.BirthPlace); !birthPlace.IsZero() {
+					input.BirthPlace = &birthPlace
 				}
+				input.BasedIn = place.ParseAll(enriched.Location)
+				input.Tags = d.tags.Known(enriched.Tags)
+				input.Sources = sources(enriched.Sources)
 				fullAsian = enriched.FullAsian
 
 				// Check if renamed person already exists
@@ -11,6 +11,7 @@ import (
 	firebase "firebase.google.com/go/v4"
 	"github.com/raymonstah/asianamericanswiki/functions/api"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
 	"github.com/segmentio/ksuid"
 	"github.com/urfave/cli/v2"
 	"google.golang.org/api/iterator"
@@ -182,9 +183,8 @@ func (h *Handler) generateNew(ctx context.Context) error {
 				Website: "https://example.com",
 				X:       "https://twitter.com",
 			},
-			Location: []string{
-				"San Francisco",
-				"CA",
+			BasedIn: []place.Place{
+				place.Parse("San Francisco, CA"),
 			},
 			Tags: []string{
 				"tag1",
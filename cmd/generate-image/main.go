@@ -35,7 +35,7 @@ func main() {
 			&cli.StringFlag{Name: "source-url", Destination: &opts.SourceURL, Usage: "URL of a source image to base the generation on", Required: true},
 		},
 		Action: func(c *cli.Context) error {
-			ctx := c.Context
+			ctx := humandao.WithAuthor(c.Context, "generate-image")
 
 			if !opts.UseProd {
 				if err := os.Setenv("FIRESTORE_EMULATOR_HOST", "127.0.0.1:8080"); err != nil {
@@ -144,7 +144,12 @@ func main() {
 			}
 
 			log.Println("Uploading image to storage...")
-			human, err = uploader.UploadHumanImages(ctx, human, rawImage)
+			human, err = uploader.UploadHumanImage(ctx, imageutil.UploadHumanImageInput{
+				Human:    human,
+				RawImage: rawImage,
+				Image:    humandao.Image{AIGenerated: true},
+				Featured: true,
+			})
 			if err != nil {
 				return fmt.Errorf("unable to upload image: %w", err)
 			}
@@ -24,7 +24,7 @@ func main() {
 	defer func() { _ = client.Close() }()
 
 	dao := humandao.NewDAO(client)
-	humans, err := dao.ListHumans(ctx, humandao.ListHumansInput{
+	humans, _, err := dao.ListHumans(ctx, humandao.ListHumansInput{
 		Limit:         10,
 		IncludeDrafts: true,
 	})
@@ -7,7 +7,9 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"slices"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"cloud.google.com/go/storage"
@@ -21,28 +23,125 @@ import (
 )
 
 type Server struct {
-	dao       *humandao.DAO
+	dao       humandao.HumanStore
 	logger    zerolog.Logger
 	xaiClient *xai.Client
 	uploader  *imageutil.Uploader
 }
 
 type MCPHuman struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
-	Aliases     []string `json:"aliases,omitempty"`
-	Path        string   `json:"path"`
-	Draft       bool     `json:"draft"`
-	DOB         string   `json:"dob,omitempty"`
-	DOD         string   `json:"dod,omitempty"`
-	Tags        []string `json:"tags,omitempty"`
-	Ethnicity   []string `json:"ethnicity,omitempty"`
-	Description string   `json:"description,omitempty"`
-	Gender      string   `json:"gender,omitempty"`
-	Instagram   string   `json:"instagram,omitempty"`
-	Twitter     string   `json:"twitter,omitempty"`
-	Website     string   `json:"website,omitempty"`
-	IMDB        string   `json:"imdb,omitempty"`
+	ID          string               `json:"id"`
+	Name        string               `json:"name"`
+	Aliases     []string             `json:"aliases,omitempty"`
+	Names       []MCPName            `json:"names,omitempty"`
+	Path        string               `json:"path"`
+	Draft       bool                 `json:"draft"`
+	DOB         humandao.PartialDate `json:"dob,omitempty"`
+	DOD         humandao.PartialDate `json:"dod,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Ethnicity   []string             `json:"ethnicity,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Gender      string               `json:"gender,omitempty"`
+	Instagram   string               `json:"instagram,omitempty"`
+	Twitter     string               `json:"twitter,omitempty"`
+	Website     string               `json:"website,omitempty"`
+	IMDB        string               `json:"imdb,omitempty"`
+	Sources     []MCPSource          `json:"sources,omitempty"`
+	Works       []MCPWork            `json:"works,omitempty"`
+}
+
+type MCPSource struct {
+	URL        string   `json:"url" jsonschema:"Link to the source"`
+	Title      string   `json:"title,omitempty" jsonschema:"Title of the article or page"`
+	Publisher  string   `json:"publisher,omitempty" jsonschema:"Who published the source (e.g. Wikipedia, The New York Times)"`
+	AccessedAt string   `json:"accessed_at,omitempty" jsonschema:"When the source was read (YYYY-MM-DD)"`
+	Fields     []string `json:"fields,omitempty" jsonschema:"Fields the source supports (e.g. dob, description). Leave empty if it supports the whole entry"`
+}
+
+type MCPName struct {
+	Value string `json:"value" jsonschema:"The name as written (e.g. 楊紫瓊)"`
+	Lang  string `json:"lang,omitempty" jsonschema:"BCP 47 tag of the language and script of the name (e.g. zh-Hant, ko, ja-Latn)"`
+	Type  string `json:"type,omitempty" jsonschema:"One of birth, legal, stage or romanization"`
+}
+
+func toMCPNames(names []humandao.NameVariant) []MCPName {
+	out := make([]MCPName, 0, len(names))
+	for _, name := range names {
+		out = append(out, MCPName{Value: name.Value, Lang: name.Lang, Type: string(name.Type)})
+	}
+	return out
+}
+
+func fromMCPNames(names []MCPName) []humandao.NameVariant {
+	out := make([]humandao.NameVariant, 0, len(names))
+	for _, name := range names {
+		out = append(out, humandao.NameVariant{Value: name.Value, Lang: name.Lang, Type: humandao.NameType(name.Type)})
+	}
+	return out
+}
+
+type MCPWork struct {
+	Title  string `json:"title" jsonschema:"Title of the work"`
+	Type   string `json:"type" jsonschema:"One of film, tv, stage, album, song, book, game or other"`
+	Year   int    `json:"year,omitempty" jsonschema:"Year the work came out"`
+	Credit string `json:"credit,omitempty" jsonschema:"What the person did on the work (e.g. Director, Author, or the character they played)"`
+	URL    string `json:"url,omitempty" jsonschema:"Link to more about the work (e.g. its IMDB page)"`
+}
+
+func toMCPWorks(works []humandao.Work) []MCPWork {
+	out := make([]MCPWork, 0, len(works))
+	for _, work := range works {
+		out = append(out, MCPWork{Title: work.Title, Type: string(work.Type), Year: work.Year, Credit: work.Credit, URL: work.URL})
+	}
+	return out
+}
+
+func fromMCPWorks(works []MCPWork) []humandao.Work {
+	out := make([]humandao.Work, 0, len(works))
+	for _, work := range works {
+		out = append(out, humandao.Work{Title: work.Title, Type: humandao.WorkType(work.Type), Year: work.Year, Credit: work.Credit, URL: work.URL})
+	}
+	return out
+}
+
+func toMCPSources(sources []humandao.Source) []MCPSource {
+	out := make([]MCPSource, 0, len(sources))
+	for _, source := range sources {
+		accessedAt := ""
+		if !source.AccessedAt.IsZero() {
+			accessedAt = source.AccessedAt.Format("2006-01-02")
+		}
+		out = append(out, MCPSource{
+			URL:        source.URL,
+			Title:      source.Title,
+			Publisher:  source.Publisher,
+			AccessedAt: accessedAt,
+			Fields:     source.Fields,
+		})
+	}
+	return out
+}
+
+func fromMCPSources(sources []MCPSource) ([]humandao.Source, error) {
+	out := make([]humandao.Source, 0, len(sources))
+	for _, source := range sources {
+		var accessedAt time.Time
+		if source.AccessedAt != "" {
+			var err error
+			accessedAt, err = time.Parse("2006-01-02", source.AccessedAt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid accessed_at for source %v: %w", source.URL, err)
+			}
+		}
+		out = append(out, humandao.Source{
+			URL:        source.URL,
+			Title:      source.Title,
+			Publisher:  source.Publisher,
+			AccessedAt: accessedAt,
+			Fields:     source.Fields,
+		})
+	}
+	return out, nil
 }
 
 func toMCPHuman(h humandao.Human) MCPHuman {
@@ -50,6 +149,7 @@ func toMCPHuman(h humandao.Human) MCPHuman {
 		ID:          h.ID,
 		Name:        h.Name,
 		Aliases:     h.Aliases,
+		Names:       toMCPNames(h.Names),
 		Path:        h.Path,
 		Draft:       h.Draft,
 		DOB:         h.DOB,
@@ -62,11 +162,14 @@ func toMCPHuman(h humandao.Human) MCPHuman {
 		Twitter:     h.Socials.X,
 		Website:     h.Socials.Website,
 		IMDB:        h.Socials.IMDB,
+		Sources:     toMCPSources(h.Sources),
+		Works:       toMCPWorks(h.Works),
 	}
 }
 
 type HumansResponse struct {
-	Humans []MCPHuman `json:"humans"`
+	Humans        []MCPHuman `json:"humans"`
+	NextPageToken string     `json:"next_page_token,omitempty"`
 }
 
 type HumanResponse struct {
@@ -82,7 +185,7 @@ type SearchInput struct {
 }
 
 func (s *Server) searchAsianAmericans(ctx context.Context, req *mcp.CallToolRequest, input SearchInput) (*mcp.CallToolResult, HumansResponse, error) {
-	humans, err := s.dao.ListHumans(ctx, humandao.ListHumansInput{Limit: 1000})
+	humans, err := humandao.IterateHumans(ctx, s.dao, humandao.ListHumansInput{}).GetAll()
 	if err != nil {
 		return nil, HumansResponse{}, fmt.Errorf("failed to list humans: %w", err)
 	}
@@ -156,30 +259,39 @@ func (s *Server) getHuman(ctx context.Context, req *mcp.CallToolRequest, input G
 }
 
 type AddInput struct {
-	Name        string   `json:"name" jsonschema:"Full name of the person"`
-	Aliases     []string `json:"aliases,omitempty" jsonschema:"Alternative names or nicknames"`
-	DOB         string   `json:"dob,omitempty" jsonschema:"Date of birth (YYYY-MM-DD)"`
-	DOD         string   `json:"dod,omitempty" jsonschema:"Date of death (YYYY-MM-DD)"`
-	Ethnicity   []string `json:"ethnicity" jsonschema:"List of ethnicities"`
-	Description string   `json:"description" jsonschema:"Short biography or description"`
-	Tags        []string `json:"tags,omitempty" jsonschema:"Relevant tags (e.g. actor, musician)"`
-	Gender      string   `json:"gender" jsonschema:"male, female, or nonbinary"`
-	Instagram   string   `json:"instagram,omitempty" jsonschema:"Instagram profile URL"`
-	Twitter     string   `json:"twitter,omitempty" jsonschema:"Twitter profile URL"`
-	Website     string   `json:"website,omitempty" jsonschema:"Personal website"`
-	IMDB        string   `json:"imdb,omitempty" jsonschema:"IMDB profile URL"`
-	SourceImage string   `json:"source_image,omitempty" jsonschema:"Direct URL to a high-quality portrait image to be used as a source for xAI cinematic portrait generation"`
+	Name        string               `json:"name" jsonschema:"Full name of the person"`
+	Aliases     []string             `json:"aliases,omitempty" jsonschema:"Alternative names or nicknames"`
+	Names       []MCPName            `json:"names,omitempty" jsonschema:"The name in other scripts or romanized"`
+	DOB         humandao.PartialDate `json:"dob,omitempty" jsonschema:"Date of birth (YYYY-MM-DD). Use YYYY-MM or YYYY if only part of it is known"`
+	DOD         humandao.PartialDate `json:"dod,omitempty" jsonschema:"Date of death (YYYY-MM-DD). Use YYYY-MM or YYYY if only part of it is known"`
+	Ethnicity   []string             `json:"ethnicity" jsonschema:"List of ethnicities"`
+	Description string               `json:"description" jsonschema:"Short biography or description"`
+	Tags        []string             `json:"tags,omitempty" jsonschema:"Relevant tags (e.g. actor, musician), from list-tags"`
+	Gender      string               `json:"gender" jsonschema:"male, female, or nonbinary"`
+	Instagram   string               `json:"instagram,omitempty" jsonschema:"Instagram profile URL"`
+	Twitter     string               `json:"twitter,omitempty" jsonschema:"Twitter profile URL"`
+	Website     string               `json:"website,omitempty" jsonschema:"Personal website"`
+	IMDB        string               `json:"imdb,omitempty" jsonschema:"IMDB profile URL"`
+	SourceImage string               `json:"source_image,omitempty" jsonschema:"Direct URL to a high-quality portrait image to be used as a source for xAI cinematic portrait generation"`
+	Sources     []MCPSource          `json:"sources,omitempty" jsonschema:"Citations for the facts about this person"`
+	Works       []MCPWork            `json:"works,omitempty" jsonschema:"Films, shows, albums, books and other works of this person"`
 }
 
 func (s *Server) addHuman(ctx context.Context, req *mcp.CallToolRequest, input AddInput) (*mcp.CallToolResult, MessageResponse, error) {
+	ctx = humandao.WithAuthor(ctx, "mcp-agent")
 	humanID := ksuid.New().String()
 	if err := validateSocials(input.Instagram, input.Twitter, input.Website, input.IMDB); err != nil {
 		return nil, MessageResponse{}, err
 	}
+	sources, err := fromMCPSources(input.Sources)
+	if err != nil {
+		return nil, MessageResponse{}, err
+	}
 	human, err := s.dao.AddHuman(ctx, humandao.AddHumanInput{
 		HumanID:     humanID,
 		Name:        input.Name,
 		Aliases:     input.Aliases,
+		Names:       fromMCPNames(input.Names),
 		DOB:         input.DOB,
 		DOD:         input.DOD,
 		Ethnicity:   input.Ethnicity,
@@ -192,6 +304,8 @@ func (s *Server) addHuman(ctx context.Context, req *mcp.CallToolRequest, input A
 		IMDB:        input.IMDB,
 		Draft:       true, // Agents should probably contribute as drafts first
 		CreatedBy:   "mcp-agent",
+		Sources:     sources,
+		Works:       fromMCPWorks(input.Works),
 	})
 	if err != nil {
 		return nil, MessageResponse{}, fmt.Errorf("failed to add human: %w", err)
@@ -209,74 +323,120 @@ func (s *Server) addHuman(ctx context.Context, req *mcp.CallToolRequest, input A
 }
 
 type UpdateInput struct {
-	ID          string   `json:"id" jsonschema:"The ID of the human to update"`
-	Name        string   `json:"name,omitempty"`
-	Aliases     []string `json:"aliases,omitempty"`
-	Draft       *bool    `json:"draft,omitempty"`
-	DOB         string   `json:"dob,omitempty"`
-	DOD         string   `json:"dod,omitempty"`
-	Ethnicity   []string `json:"ethnicity,omitempty"`
-	Description string   `json:"description,omitempty"`
-	Tags        []string `json:"tags,omitempty"`
-	Gender      string   `json:"gender,omitempty"`
-	Instagram   string   `json:"instagram,omitempty" jsonschema:"Instagram profile URL"`
-	Twitter     string   `json:"twitter,omitempty" jsonschema:"Twitter profile URL"`
-	Website     string   `json:"website,omitempty"`
-	IMDB        string   `json:"imdb,omitempty"`
-	SourceImage string   `json:"source_image,omitempty" jsonschema:"Direct URL to a high-quality portrait image to be used as a source for xAI cinematic portrait generation"`
+	ID          string               `json:"id" jsonschema:"The ID of the human to update"`
+	Name        string               `json:"name,omitempty"`
+	Aliases     []string             `json:"aliases,omitempty"`
+	Names       []MCPName            `json:"names,omitempty" jsonschema:"Names in other scripts or romanized. Replaces the existing ones"`
+	Draft       *bool                `json:"draft,omitempty"`
+	DOB         humandao.PartialDate `json:"dob,omitempty"`
+	DOD         humandao.PartialDate `json:"dod,omitempty"`
+	Ethnicity   []string             `json:"ethnicity,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Gender      string               `json:"gender,omitempty"`
+	Instagram   string               `json:"instagram,omitempty" jsonschema:"Instagram profile URL"`
+	Twitter     string               `json:"twitter,omitempty" jsonschema:"Twitter profile URL"`
+	Website     string               `json:"website,omitempty"`
+	IMDB        string               `json:"imdb,omitempty"`
+	SourceImage string               `json:"source_image,omitempty" jsonschema:"Direct URL to a high-quality portrait image to be used as a source for xAI cinematic portrait generation"`
+	Sources     []MCPSource          `json:"sources,omitempty" jsonschema:"Citations to add. A source with the same URL as an existing one replaces it"`
+	Works       []MCPWork            `json:"works,omitempty" jsonschema:"Works to add. A work with the same title and type as an existing one replaces it"`
 }
 
 func (s *Server) updateHuman(ctx context.Context, req *mcp.CallToolRequest, input UpdateInput) (*mcp.CallToolResult, MessageResponse, error) {
-	human, err := s.dao.Human(ctx, humandao.HumanInput{HumanID: input.ID})
-	if err != nil {
-		return nil, MessageResponse{}, fmt.Errorf("failed to get human for update: %w", err)
-	}
-
+	ctx = humandao.WithAuthor(ctx, "mcp-agent")
 	if err := validateSocials(input.Instagram, input.Twitter, input.Website, input.IMDB); err != nil {
 		return nil, MessageResponse{}, err
 	}
 
+	// only send the fields that were given, so nothing else on the human is overwritten
+	patch := humandao.HumanPatch{Draft: input.Draft}
 	if input.Name != "" {
-		human.Name = input.Name
+		patch.Name = &input.Name
 	}
 	if len(input.Aliases) > 0 {
-		human.Aliases = input.Aliases
+		patch.Aliases = &input.Aliases
 	}
-	if input.Draft != nil {
-		human.Draft = *input.Draft
+	if len(input.Names) > 0 {
+		names := fromMCPNames(input.Names)
+		patch.Names = &names
 	}
 	if input.DOB != "" {
-		human.DOB = input.DOB
+		patch.DOB = &input.DOB
 	}
 	if input.DOD != "" {
-		human.DOD = input.DOD
+		patch.DOD = &input.DOD
 	}
 	if len(input.Ethnicity) > 0 {
-		human.Ethnicity = input.Ethnicity
+		patch.Ethnicity = &input.Ethnicity
 	}
 	if input.Description != "" {
-		human.Description = input.Description
+		patch.Description = &input.Description
 	}
 	if len(input.Tags) > 0 {
-		human.Tags = input.Tags
+		patch.Tags = &input.Tags
 	}
 	if input.Gender != "" {
-		human.Gender = humandao.Gender(input.Gender)
-	}
-	if input.Instagram != "" {
-		human.Socials.Instagram = input.Instagram
+		gender := humandao.Gender(input.Gender)
+		patch.Gender = &gender
 	}
-	if input.Twitter != "" {
-		human.Socials.X = input.Twitter
-	}
-	if input.Website != "" {
-		human.Socials.Website = input.Website
-	}
-	if input.IMDB != "" {
-		human.Socials.IMDB = input.IMDB
+	var added []humandao.Source
+	if len(input.Sources) > 0 {
+		var err error
+		added, err = fromMCPSources(input.Sources)
+		if err != nil {
+			return nil, MessageResponse{}, err
+		}
 	}
 
-	err = s.dao.UpdateHuman(ctx, human)
+	// the socials, sources and works given are merged into the ones stored, in the patch
+	// transaction, so ones added since the human was read aren't lost
+	human, err := s.dao.PatchHumanFunc(ctx, input.ID, func(current humandao.Human) (humandao.HumanPatch, error) {
+		patch := patch
+		if input.Instagram != "" || input.Twitter != "" || input.Website != "" || input.IMDB != "" {
+			socials := current.Socials
+			if input.Instagram != "" {
+				socials.Instagram = input.Instagram
+			}
+			if input.Twitter != "" {
+				socials.X = input.Twitter
+			}
+			if input.Website != "" {
+				socials.Website = input.Website
+			}
+			if input.IMDB != "" {
+				socials.IMDB = input.IMDB
+			}
+			patch.Socials = &socials
+		}
+		if len(added) > 0 {
+			sources := slices.Clone(current.Sources)
+			for _, source := range added {
+				i := slices.IndexFunc(sources, func(existing humandao.Source) bool { return existing.URL == source.URL })
+				if i >= 0 {
+					sources[i] = source
+				} else {
+					sources = append(sources, source)
+				}
+			}
+			patch.Sources = &sources
+		}
+		if len(input.Works) > 0 {
+			works := slices.Clone(current.Works)
+			for _, work := range fromMCPWorks(input.Works) {
+				i := slices.IndexFunc(works, func(existing humandao.Work) bool {
+					return strings.EqualFold(existing.Title, work.Title) && existing.Type == work.Type
+				})
+				if i >= 0 {
+					works[i] = work
+				} else {
+					works = append(works, work)
+				}
+			}
+			patch.Works = &works
+		}
+		return patch, nil
+	})
 	if err != nil {
 		return nil, MessageResponse{}, fmt.Errorf("failed to update human: %w", err)
 	}
@@ -305,7 +465,7 @@ func (s *Server) generateAndUploadImage(ctx context.Context, human humandao.Huma
 		return human, fmt.Errorf("unable to create request for source image: %w", err)
 	}
 	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/91.0.4472.124 Safari/537.36")
-	
+
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return human, fmt.Errorf("unable to download source image: %w", err)
@@ -318,7 +478,7 @@ func (s *Server) generateAndUploadImage(ctx context.Context, human humandao.Huma
 	if err != nil {
 		return human, fmt.Errorf("failed to read source image: %w", err)
 	}
-	
+
 	base64Data := base64.StdEncoding.EncodeToString(data)
 	mimeType := http.DetectContentType(data)
 	baseImage := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
@@ -349,7 +509,12 @@ func (s *Server) generateAndUploadImage(ctx context.Context, human humandao.Huma
 	}
 
 	s.logger.Info().Msg("Uploading image to storage")
-	return s.uploader.UploadHumanImages(ctx, human, rawImage)
+	return s.uploader.UploadHumanImage(ctx, imageutil.UploadHumanImageInput{
+		Human:    human,
+		RawImage: rawImage,
+		Image:    humandao.Image{AIGenerated: true, SourceURL: sourceURL},
+		Featured: true,
+	})
 }
 
 func validateSocials(instagram, twitter, website, imdb string) error {
@@ -372,8 +537,8 @@ func validateSocials(instagram, twitter, website, imdb string) error {
 }
 
 type ListInput struct {
-	Limit  int `json:"limit,omitempty" jsonschema:"Max number of results (default 50, max 100)"`
-	Offset int `json:"offset,omitempty" jsonschema:"Number of results to skip"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"Max number of results (default 50, max 100)"`
+	PageToken string `json:"page_token,omitempty" jsonschema:"The next_page_token of the previous page, to get the page after it"`
 }
 
 func (s *Server) listHumans(ctx context.Context, req *mcp.CallToolRequest, input ListInput) (*mcp.CallToolResult, HumansResponse, error) {
@@ -385,9 +550,9 @@ func (s *Server) listHumans(ctx context.Context, req *mcp.CallToolRequest, input
 		limit = 100
 	}
 
-	humans, err := s.dao.ListHumans(ctx, humandao.ListHumansInput{
-		Limit:  limit,
-		Offset: input.Offset,
+	humans, next, err := s.dao.ListHumans(ctx, humandao.ListHumansInput{
+		Limit:     limit,
+		PageToken: input.PageToken,
 	})
 	if err != nil {
 		return nil, HumansResponse{}, fmt.Errorf("failed to list humans: %w", err)
@@ -396,7 +561,32 @@ func (s *Server) listHumans(ctx context.Context, req *mcp.CallToolRequest, input
 	for _, h := range humans {
 		out = append(out, toMCPHuman(h))
 	}
-	return nil, HumansResponse{Humans: out}, nil
+	return nil, HumansResponse{Humans: out, NextPageToken: next}, nil
+}
+
+type MCPTag struct {
+	Name        string   `json:"name"`
+	Parent      string   `json:"parent,omitempty"`
+	Synonyms    []string `json:"synonyms,omitempty"`
+	Description string   `json:"description,omitempty"`
+}
+
+type ListTagsInput struct{}
+
+type TagsResponse struct {
+	Tags []MCPTag `json:"tags"`
+}
+
+func (s *Server) listTags(ctx context.Context, req *mcp.CallToolRequest, input ListTagsInput) (*mcp.CallToolResult, TagsResponse, error) {
+	taxonomy, err := s.dao.Tags(ctx)
+	if err != nil {
+		return nil, TagsResponse{}, fmt.Errorf("failed to list tags: %w", err)
+	}
+	out := make([]MCPTag, 0, len(taxonomy))
+	for _, tag := range taxonomy {
+		out = append(out, MCPTag{Name: tag.Name, Parent: tag.Parent, Synonyms: tag.Synonyms, Description: tag.Description})
+	}
+	return nil, TagsResponse{Tags: out}, nil
 }
 
 func main() {
@@ -410,7 +600,7 @@ func main() {
 	defer func() { _ = fsClient.Close() }()
 
 	dao := humandao.NewDAO(fsClient)
-	
+
 	var xClient *xai.Client
 	xaiToken := os.Getenv("XAI_API_KEY")
 	if xaiToken != "" {
@@ -421,7 +611,7 @@ func main() {
 	if err != nil {
 		logger.Fatal().Err(err).Msg("failed to create storage client")
 	}
-	
+
 	storageURL := "https://storage.googleapis.com"
 	if os.Getenv("STORAGE_EMULATOR_HOST") != "" {
 		storageURL = "http://" + os.Getenv("STORAGE_EMULATOR_HOST")
@@ -462,6 +652,11 @@ func main() {
 		Description: "Update an existing Asian American entry",
 	}, mcpServer.updateHuman)
 
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "list-tags",
+		Description: "List the tags people can be given. Synonyms are accepted and stored as the tag they belong to",
+	}, mcpServer.listTags)
+
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "list-humans",
 		Description: "List Asian Americans with pagination",
@@ -2,16 +2,19 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"slices"
 	"strings"
-	"unicode"
+	"time"
 
 	"cloud.google.com/go/firestore"
 	"github.com/raymonstah/asianamericanswiki/functions/api"
+	eth "github.com/raymonstah/asianamericanswiki/internal/ethnicity"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
 	"github.com/urfave/cli/v2"
 )
 
@@ -25,14 +28,39 @@ func main() {
 		Commands: []*cli.Command{
 			{
 				Name:   "ethnicity",
-				Usage:  "normalize ethnicity data",
+				Usage:  "map ethnicities onto the names used by the ethnicity taxonomy",
 				Action: ethnicity,
 			},
 			{
 				Name:   "tags",
-				Usage:  "normalize tags data",
+				Usage:  "map tags onto the names used by the tag taxonomy",
 				Action: tags,
 			},
+			{
+				Name:   "dates",
+				Usage:  "rewrite dates of birth and death as YYYY, YYYY-MM or YYYY-MM-DD",
+				Action: dates,
+			},
+			{
+				Name:   "relationships",
+				Usage:  "add an influenced-by relationship for every human listed in influenced_by",
+				Action: relationships,
+			},
+			{
+				Name:   "places",
+				Usage:  "fill in birth_place and based_in from birth_location and location using the gazetteer",
+				Action: places,
+			},
+			{
+				Name:  "merge",
+				Usage: "merge a duplicate human into another one",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "winner", Required: true, Usage: "ID of the human to keep"},
+					&cli.StringFlag{Name: "loser", Required: true, Usage: "ID of the duplicate to merge away"},
+					&cli.StringSliceFlag{Name: "from-loser", Usage: "fields to take from the duplicate instead (one of: " + strings.Join(humandao.MergeFields, ", ") + ")"},
+				},
+				Action: merge,
+			},
 		},
 		Flags: []cli.Flag{
 			&cli.BoolFlag{Name: "dry", Destination: &opts.Dry},
@@ -46,7 +74,7 @@ func main() {
 
 type Handler struct {
 	fsClient *firestore.Client
-	humanDAO *humandao.DAO
+	humanDAO humandao.HumanStore
 }
 
 func prepareHandler(ctx context.Context) (Handler, error) {
@@ -65,7 +93,7 @@ func prepareHandler(ctx context.Context) (Handler, error) {
 }
 
 func ethnicity(c *cli.Context) error {
-	ctx := c.Context
+	ctx := humandao.WithAuthor(c.Context, "normalize")
 	h, err := prepareHandler(ctx)
 	if err != nil {
 		return err
@@ -78,7 +106,7 @@ func ethnicity(c *cli.Context) error {
 }
 
 func tags(c *cli.Context) error {
-	ctx := c.Context
+	ctx := humandao.WithAuthor(c.Context, "normalize")
 	h, err := prepareHandler(ctx)
 	if err != nil {
 		return err
@@ -90,37 +118,114 @@ func tags(c *cli.Context) error {
 	return nil
 }
 
-func (h *Handler) Ethnicity(ctx context.Context) error {
-	// Get all humans
-	humans, err := h.humanDAO.ListHumans(ctx, humandao.ListHumansInput{
-		Limit: 1000,
-	})
+func dates(c *cli.Context) error {
+	ctx := humandao.WithAuthor(c.Context, "normalize")
+	h, err := prepareHandler(ctx)
 	if err != nil {
-		return fmt.Errorf("unable to list humans: %w", err)
+		return err
+	}
+	if err := h.Dates(ctx); err != nil {
+		return err
 	}
 
-	allEthnicities := make(map[string]struct{})
-	for _, human := range humans {
-		needUpdate := false
-		for i, ethnicity := range human.Ethnicity {
-			allEthnicities[ethnicity] = struct{}{}
-			// check if string starts with upper
-
-			if unicode.IsUpper(rune(ethnicity[0])) {
-				needUpdate = true
-				human.Ethnicity[i] = strings.ToLower(ethnicity)
-			}
+	return nil
+}
 
-		}
+func relationships(c *cli.Context) error {
+	ctx := humandao.WithAuthor(c.Context, "normalize")
+	h, err := prepareHandler(ctx)
+	if err != nil {
+		return err
+	}
+	if err := h.Relationships(ctx); err != nil {
+		return err
+	}
 
-		if needUpdate {
-			log.Println("would update human", human.Name, human.Ethnicity)
-		}
+	return nil
+}
 
-		if !opts.Dry {
-			err := h.humanDAO.UpdateHuman(ctx, human)
+func places(c *cli.Context) error {
+	ctx := humandao.WithAuthor(c.Context, "normalize")
+	h, err := prepareHandler(ctx)
+	if err != nil {
+		return err
+	}
+	if err := h.Places(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func merge(c *cli.Context) error {
+	ctx := c.Context
+	h, err := prepareHandler(ctx)
+	if err != nil {
+		return err
+	}
+
+	input := humandao.MergeInput{
+		WinnerID:  c.String("winner"),
+		LoserID:   c.String("loser"),
+		FromLoser: c.StringSlice("from-loser"),
+	}
+	if err := h.Merge(ctx, input); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (h *Handler) Merge(ctx context.Context, input humandao.MergeInput) error {
+	humans, err := h.humanDAO.HumansByID(ctx, humandao.HumansByIDInput{HumanIDs: []string{input.WinnerID, input.LoserID}})
+	if err != nil {
+		return fmt.Errorf("unable to get humans: %w", err)
+	}
+	winner, loser := humans[0], humans[1]
+
+	merged := humandao.MergeHumans(winner, loser, input.FromLoser)
+	log.Printf("would merge %v (%v) into %v (%v)", loser.Name, loser.ID, winner.Name, winner.ID)
+	for _, change := range humandao.DiffHumans(winner, merged) {
+		log.Printf("\t%v: %q -> %q", change.Field, change.Before, change.After)
+	}
+	if opts.Dry {
+		return nil
+	}
+
+	ctx = humandao.WithAuthor(ctx, "normalize")
+	if _, err := h.humanDAO.Merge(ctx, input); err != nil {
+		return err
+	}
+	log.Println("successfully merged", loser.Name, "into", winner.Name)
+
+	return nil
+}
+
+// Relationships turns the influenced_by list of every human into relationships, which are what
+// the connections of a human are shown from. Running it again skips the ones that already exist.
+func (h *Handler) Relationships(ctx context.Context) error {
+	humans, err := humandao.IterateHumans(ctx, h.humanDAO, humandao.ListHumansInput{IncludeDrafts: true}).GetAll()
+	if err != nil {
+		return fmt.Errorf("unable to list humans: %w", err)
+	}
+
+	for _, human := range humans {
+		for _, influence := range human.InfluencedBy {
+			log.Printf("would add that %v (%v) was influenced by %v", human.Name, human.ID, influence)
+			if opts.Dry {
+				continue
+			}
+			_, err := h.humanDAO.AddRelationship(ctx, humandao.AddRelationshipInput{
+				Type: humandao.RelationshipInfluencedBy,
+				From: human.ID,
+				To:   influence,
+			})
+			if errors.Is(err, humandao.ErrRelationshipExists) {
+				continue
+			}
+			// a stale or misspelled ID shouldn't stop the others
 			if err != nil {
-				return err
+				log.Printf("unable to add influence %v of %v (%v): %v", influence, human.Name, human.ID, err)
 			}
 		}
 	}
@@ -128,92 +233,202 @@ func (h *Handler) Ethnicity(ctx context.Context) error {
 	return nil
 }
 
-func (h *Handler) Tags(ctx context.Context) error {
-	// Get all humans
-	humans, err := h.humanDAO.ListHumans(ctx, humandao.ListHumansInput{
-		Limit: 1000,
-	})
+// Places parses the free text birth_location and location of every human that doesn't have
+// structured places yet. The free text is left as it is.
+func (h *Handler) Places(ctx context.Context) error {
+	humans, err := humandao.IterateHumans(ctx, h.humanDAO, humandao.ListHumansInput{IncludeDrafts: true}).GetAll()
 	if err != nil {
 		return fmt.Errorf("unable to list humans: %w", err)
 	}
 
-	// Lowercase all Tags
 	for _, human := range humans {
-		needUpdate := false
-		previousTags := slices.Clone(human.Tags)
-		for i, tag := range human.Tags {
-			// ensure tags are lowercase
-			if strings.ToLower(tag) != tag {
-				needUpdate = true
-				human.Tags[i] = strings.ToLower(tag)
+		var patch humandao.HumanPatch
+		var parsed []place.Place
+		if human.BirthPlace == nil && human.BirthLocation != "" {
+			birthPlace := place.Parse(human.BirthLocation)
+			log.Printf("would set birth place of %v (%v): %q -> %v", human.Name, human.ID, human.BirthLocation, birthPlace)
+			patch.BirthPlace = &birthPlace
+			parsed = append(parsed, birthPlace)
+		}
+		if len(human.BasedIn) == 0 && len(human.Location) > 0 {
+			basedIn := place.ParseAll(human.Location)
+			log.Printf("would set based in of %v (%v): %q -> %v", human.Name, human.ID, human.Location, basedIn)
+			patch.BasedIn = &basedIn
+			parsed = append(parsed, basedIn...)
+		}
+		for _, p := range parsed {
+			if !p.IsZero() && !p.HasCoordinates() {
+				log.Printf("%v of %v (%v) isn't in the gazetteer, add its coordinates by hand", p, human.Name, human.ID)
 			}
+		}
 
-			// ensure tag doesn't contain multiple values
-			parts := strings.Split(tag, ",")
-			if len(parts) > 1 {
-				needUpdate = true
-				human.Tags[i] = parts[0]
-				human.Tags = append(human.Tags, parts[1:]...)
-			}
+		if opts.Dry || (patch.BirthPlace == nil && patch.BasedIn == nil) {
+			continue
+		}
+		if _, err := h.humanDAO.PatchHuman(ctx, human.ID, patch); err != nil {
+			log.Printf("unable to update places of %v (%v): %v", human.Name, human.ID, err)
+		}
+	}
 
-			normalizeValues := map[string]string{
-				"technologist":      "technology",
-				"tech":              "technology",
-				"software engineer": "technology",
-				"youTuber":          "content creator",
-				"youtube":           "content creator",
-				"youtuber":          "content creator",
-				"podcaster":         "content creator",
-				"olympics":          "olympian",
-				"photography":       "photographer",
-				"music":             "musician",
-				"activism":          "activist",
-				"actress":           "actor",
-				"voice actor":       "actor",
-				"comedy":            "comedian",
-				"entertainment":     "entertainer",
-				"lgbt":              "lgbtq",
-				"physician":         "doctor",
-				"author":            "writer",
-				"social media":      "influencer",
-				"illustrator":       "artist",
-				"animator":          "artist",
-				"stylist":           "designer",
-				"business":          "executive",
-			}
+	return nil
+}
 
-			normalizedTag, ok := normalizeValues[human.Tags[i]]
-			if ok {
-				needUpdate = true
-				human.Tags[i] = normalizedTag
-			}
+// legacyDateFormats are the ways dates were written before they had to be partial dates.
+var legacyDateFormats = []struct {
+	layout    string
+	precision humandao.DatePrecision
+}{
+	{"January 2, 2006", humandao.PrecisionDay},
+	{"2 January 2006", humandao.PrecisionDay},
+	{"Jan 2, 2006", humandao.PrecisionDay},
+	{"2006/01/02", humandao.PrecisionDay},
+	{"01/02/2006", humandao.PrecisionDay},
+	{"2006-1-2", humandao.PrecisionDay},
+	{"January 2006", humandao.PrecisionMonth},
+	{"Jan 2006", humandao.PrecisionMonth},
+	{"2006-1", humandao.PrecisionMonth},
+}
+
+// fixDate returns date as a valid partial date, and whether it could be read at all.
+func fixDate(date humandao.PartialDate) (humandao.PartialDate, bool) {
+	if parsed, err := humandao.ParsePartialDate(string(date)); err == nil {
+		return parsed, true
+	}
+	for _, format := range legacyDateFormats {
+		t, err := time.Parse(format.layout, strings.TrimSpace(string(date)))
+		if err != nil {
+			continue
+		}
+		if format.precision == humandao.PrecisionMonth {
+			return humandao.NewPartialDate(t.Year(), t.Month(), 0), true
 		}
+		return humandao.NewPartialDate(t.Date()), true
+	}
+	return date, false
+}
 
-		if needUpdate {
-			// deduplicate tags after normalization
-			uniqueTags := make(map[string]struct{})
-			var deduplicated []string
-			for _, t := range human.Tags {
-				if _, ok := uniqueTags[t]; !ok {
-					uniqueTags[t] = struct{}{}
-					deduplicated = append(deduplicated, t)
-				}
+// Dates goes over every human, including drafts and the trash, since a date that doesn't parse
+// keeps a human from being saved or restored.
+func (h *Handler) Dates(ctx context.Context) error {
+	humans, err := h.everyHuman(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, human := range humans {
+		var patch humandao.HumanPatch
+		for _, field := range []struct {
+			name  string
+			date  humandao.PartialDate
+			patch **humandao.PartialDate
+		}{
+			{"dob", human.DOB, &patch.DOB},
+			{"dod", human.DOD, &patch.DOD},
+		} {
+			if field.date.Validate() == nil {
+				continue
 			}
-			if len(deduplicated) != len(human.Tags) {
-				human.Tags = deduplicated
+			fixed, ok := fixDate(field.date)
+			if !ok {
+				log.Printf("unable to read %v of %v (%v): %q, fix it by hand", field.name, human.Name, human.ID, field.date)
+				continue
+			}
+			log.Printf("would update %v of %v: %q -> %q", field.name, human.Name, field.date, fixed)
+			*field.patch = &fixed
+		}
+
+		if opts.Dry || (patch.DOB == nil && patch.DOD == nil) {
+			continue
+		}
+		// a date that's still unreadable makes the patch fail, which shouldn't stop the others
+		if _, err := h.humanDAO.PatchDeletedHuman(ctx, human.ID, patch); err != nil {
+			log.Printf("unable to update dates of %v (%v): %v", human.Name, human.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// everyHuman returns every human, including drafts and the trash, for the migrations that have to
+// get to all of them. A human that isn't migrated can't be saved or restored once it no longer
+// follows the rules the migration brings it in line with.
+func (h *Handler) everyHuman(ctx context.Context) ([]humandao.Human, error) {
+	humans, err := humandao.IterateHumans(ctx, h.humanDAO, humandao.ListHumansInput{IncludeDrafts: true}).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list humans: %w", err)
+	}
+	trashed, _, err := h.humanDAO.Trash(ctx, humandao.TrashInput{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list deleted humans: %w", err)
+	}
+	return append(humans, trashed...), nil
+}
+
+// Ethnicity rewrites the ethnicities of every human, including drafts and the trash, as they are
+// named in the taxonomy, e.g. "Chinese" as "chinese" and "bengalis" as "bangladeshi". Humans with
+// an ethnicity the taxonomy doesn't know are left alone and logged, so they can be fixed by hand.
+func (h *Handler) Ethnicity(ctx context.Context) error {
+	humans, err := h.everyHuman(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, human := range humans {
+		normalized, err := eth.Normalize(human.Ethnicity)
+		if err != nil {
+			log.Printf("unable to normalize ethnicity of %v (%v): %v", human.Name, human.ID, err)
+			continue
+		}
+		if slices.Equal(normalized, human.Ethnicity) {
+			continue
+		}
+
+		log.Println("would update human", human.Name, human.Ethnicity, "to", normalized)
+		if !opts.Dry {
+			_, err := h.humanDAO.PatchDeletedHuman(ctx, human.ID, humandao.HumanPatch{Ethnicity: &normalized})
+			if err != nil {
+				log.Printf("unable to update ethnicity of %v (%v): %v", human.Name, human.ID, err)
 			}
+		}
+	}
 
-			log.Printf("would update %v's tags", human.Name)
-			log.Printf("\tbefore: %v", previousTags)
-			log.Printf("\tafter: %v", human.Tags)
+	return nil
+}
 
-			if !opts.Dry {
-				err := h.humanDAO.UpdateHuman(ctx, human)
-				if err != nil {
-					return err
-				}
-				log.Println("successfully updated", human.Name)
+// Tags rewrites the tags of every human, including drafts and the trash, as they are named in the
+// tag taxonomy: lowercased, split on commas, and with synonyms like "actress" replaced by the tag
+// they are of. Tags that aren't in the taxonomy are logged, to be added to it or merged into
+// another tag from the admin page.
+func (h *Handler) Tags(ctx context.Context) error {
+	taxonomy, err := h.humanDAO.Tags(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to get tags: %w", err)
+	}
+	humans, err := h.everyHuman(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, human := range humans {
+		cleaned, unknown := taxonomy.Clean(human.Tags)
+		if len(unknown) > 0 {
+			log.Printf("%v (%v) has tags that aren't in the taxonomy: %v", human.Name, human.ID, unknown)
+		}
+		if slices.Equal(cleaned, human.Tags) {
+			continue
+		}
+
+		log.Printf("would update %v's tags", human.Name)
+		log.Printf("\tbefore: %v", human.Tags)
+		log.Printf("\tafter: %v", cleaned)
+
+		if !opts.Dry {
+			_, err := h.humanDAO.PatchDeletedHuman(ctx, human.ID, humandao.HumanPatch{Tags: &cleaned})
+			if err != nil {
+				log.Printf("unable to update tags of %v (%v): %v", human.Name, human.ID, err)
+				continue
 			}
+			log.Println("successfully updated", human.Name)
 		}
 	}
 
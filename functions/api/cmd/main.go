@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/go-chi/httplog"
 	"github.com/rs/zerolog"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/raymonstah/asianamericanswiki/functions/api"
 	"github.com/raymonstah/asianamericanswiki/functions/api/server"
@@ -26,8 +28,10 @@ func main() {
 		Name: "api server for AsianAmericans.wiki",
 		Flags: []cli.Flag{
 			&cli.IntFlag{Name: "port", EnvVars: []string{"PORT"}, Value: 3000},
+			&cli.IntFlag{Name: "grpc-port", EnvVars: []string{"GRPC_PORT"}, Usage: "also serve grpc on its own port, for streams that outlive the http timeouts"},
 			&cli.BoolFlag{Name: "local"},
 			&cli.BoolFlag{Name: "no-auth"},
+			&cli.BoolFlag{Name: "in-memory", Usage: "keep humans in memory instead of firestore"},
 			&cli.StringFlag{Name: "git-hash", EnvVars: []string{"GIT_HASH"}, Value: "latest"},
 			&cli.StringFlag{Name: "xai-api-key", EnvVars: []string{"XAI_API_KEY"}},
 			&cli.StringFlag{Name: "firebase-api-key", EnvVars: []string{"FIREBASE_API_KEY"}},
@@ -89,7 +93,11 @@ func run(c *cli.Context) error {
 		return fmt.Errorf("unable to create firestore client: %w", err)
 	}
 
-	humansDAO := humandao.NewDAO(fsClient)
+	var humansDAO humandao.HumanStore = humandao.NewDAO(fsClient)
+	if c.Bool("in-memory") {
+		logger.Info().Msg("using in-memory human store")
+		humansDAO = humandao.NewMemoryDAO()
+	}
 	userDAO := userdao.NewDAO(fsClient)
 	xaiClient := xai.New(c.String("xai-api-key"))
 
@@ -115,6 +123,10 @@ func run(c *cli.Context) error {
 
 	mux := server.NewServer(config)
 
+	// grpc clients connect over http/2 without tls, which is how cloud run forwards requests
+	var protocols http.Protocols
+	protocols.SetHTTP1(true)
+	protocols.SetUnencryptedHTTP2(true)
 	address := fmt.Sprintf(":%v", c.Int("port"))
 	s := http.Server{
 		Addr:              address,
@@ -122,10 +134,31 @@ func run(c *cli.Context) error {
 		ReadTimeout:       5 * time.Second,
 		ReadHeaderTimeout: 5 * time.Second,
 		WriteTimeout:      90 * time.Second,
+		Protocols:         &protocols,
 	}
 
-	logger.Info().Str("port", c.String("port")).Msg("starting server")
-	return s.ListenAndServe()
+	g, ctx := errgroup.WithContext(ctx)
+	if c.Int("grpc-port") != 0 {
+		listener, err := net.Listen("tcp", fmt.Sprintf(":%v", c.Int("grpc-port")))
+		if err != nil {
+			return fmt.Errorf("unable to listen for grpc: %w", err)
+		}
+		g.Go(func() error {
+			logger.Info().Str("port", c.String("grpc-port")).Msg("starting grpc server")
+			return mux.GRPCServer().Serve(listener)
+		})
+	}
+	g.Go(func() error {
+		logger.Info().Str("port", c.String("port")).Msg("starting server")
+		return s.ListenAndServe()
+	})
+	// when either server stops, so does the other
+	g.Go(func() error {
+		<-ctx.Done()
+		mux.GRPCServer().Stop()
+		return s.Close()
+	})
+	return g.Wait()
 }
 
 func setupEmulatorEnvironmentVariables(logger zerolog.Logger) error {
@@ -10,6 +10,9 @@ import (
 	_ "google.golang.org/genproto/googleapis/api/annotations"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,25 +25,96 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ChangeType int32
+
+const (
+	ChangeType_CHANGE_TYPE_UNSPECIFIED ChangeType = 0
+	// ADDED is a human that was published, or restored from the trash.
+	ChangeType_ADDED    ChangeType = 1
+	ChangeType_MODIFIED ChangeType = 2
+	// REMOVED is a human that was moved to the trash, purged or unpublished.
+	ChangeType_REMOVED ChangeType = 3
+	// CURRENT is sent once the watch has caught up, after the humans it started with or the events
+	// it resumed with.
+	ChangeType_CURRENT ChangeType = 4
+	// RESYNC is sent when the watch can't send the events the client missed, because its resume
+	// token can't be resumed from or it fell behind. It is followed by every published human as
+	// ADDED and a CURRENT event, so the client should drop the humans it has and start over.
+	ChangeType_RESYNC ChangeType = 5
+)
+
+// Enum value maps for ChangeType.
+var (
+	ChangeType_name = map[int32]string{
+		0: "CHANGE_TYPE_UNSPECIFIED",
+		1: "ADDED",
+		2: "MODIFIED",
+		3: "REMOVED",
+		4: "CURRENT",
+		5: "RESYNC",
+	}
+	ChangeType_value = map[string]int32{
+		"CHANGE_TYPE_UNSPECIFIED": 0,
+		"ADDED":                   1,
+		"MODIFIED":                2,
+		"REMOVED":                 3,
+		"CURRENT":                 4,
+		"RESYNC":                  5,
+	}
+)
+
+func (x ChangeType) Enum() *ChangeType {
+	p := new(ChangeType)
+	*p = x
+	return p
+}
+
+func (x ChangeType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ChangeType) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_proto_enumTypes[0].Descriptor()
+}
+
+func (ChangeType) Type() protoreflect.EnumType {
+	return &file_api_proto_enumTypes[0]
+}
+
+func (x ChangeType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ChangeType.Descriptor instead.
+func (ChangeType) EnumDescriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{0}
+}
+
+// Gender keeps the numbers FEMALE and NONBINARY had before there was GENDER_UNSPECIFIED, so
+// clients built before it still read them the same. MALE was 0, which older clients read as MALE
+// when a human has no gender.
 type Gender int32
 
 const (
-	Gender_MALE      Gender = 0
-	Gender_FEMALE    Gender = 1
-	Gender_NONBINARY Gender = 2
+	Gender_GENDER_UNSPECIFIED Gender = 0
+	Gender_FEMALE             Gender = 1
+	Gender_NONBINARY          Gender = 2
+	Gender_MALE               Gender = 3
 )
 
 // Enum value maps for Gender.
 var (
 	Gender_name = map[int32]string{
-		0: "MALE",
+		0: "GENDER_UNSPECIFIED",
 		1: "FEMALE",
 		2: "NONBINARY",
+		3: "MALE",
 	}
 	Gender_value = map[string]int32{
-		"MALE":      0,
-		"FEMALE":    1,
-		"NONBINARY": 2,
+		"GENDER_UNSPECIFIED": 0,
+		"FEMALE":             1,
+		"NONBINARY":          2,
+		"MALE":               3,
 	}
 )
 
@@ -55,11 +129,11 @@ func (x Gender) String() string {
 }
 
 func (Gender) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_proto_enumTypes[0].Descriptor()
+	return file_api_proto_enumTypes[1].Descriptor()
 }
 
 func (Gender) Type() protoreflect.EnumType {
-	return &file_api_proto_enumTypes[0]
+	return &file_api_proto_enumTypes[1]
 }
 
 func (x Gender) Number() protoreflect.EnumNumber {
@@ -68,7 +142,7 @@ func (x Gender) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Gender.Descriptor instead.
 func (Gender) EnumDescriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{0}
+	return file_api_proto_rawDescGZIP(), []int{1}
 }
 
 type VersionRequest struct {
@@ -239,37 +313,28 @@ func (x *HumansResponse) GetHumans() []*Human {
 	return nil
 }
 
-type Human struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Path          string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
-	Dob           string                 `protobuf:"bytes,4,opt,name=dob,proto3" json:"dob,omitempty"`
-	Dod           string                 `protobuf:"bytes,5,opt,name=dod,proto3" json:"dod,omitempty"`
-	Tags          []string               `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
-	Ethnicity     []string               `protobuf:"bytes,7,rep,name=ethnicity,proto3" json:"ethnicity,omitempty"`
-	Image         string                 `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
-	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
-	Socials       *HumanSocial           `protobuf:"bytes,10,opt,name=socials,proto3" json:"socials,omitempty"`
-	Gender        Gender                 `protobuf:"varint,11,opt,name=gender,proto3,enum=main.Gender" json:"gender,omitempty"`
+type GetHumanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the ID or the path of the human.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Human) Reset() {
-	*x = Human{}
+func (x *GetHumanRequest) Reset() {
+	*x = GetHumanRequest{}
 	mi := &file_api_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Human) String() string {
+func (x *GetHumanRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Human) ProtoMessage() {}
+func (*GetHumanRequest) ProtoMessage() {}
 
-func (x *Human) ProtoReflect() protoreflect.Message {
+func (x *GetHumanRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -281,113 +346,174 @@ func (x *Human) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Human.ProtoReflect.Descriptor instead.
-func (*Human) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetHumanRequest.ProtoReflect.Descriptor instead.
+func (*GetHumanRequest) Descriptor() ([]byte, []int) {
 	return file_api_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *Human) GetId() string {
+func (x *GetHumanRequest) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *Human) GetName() string {
+type ListHumansRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// page_size is 500 when it isn't set, which is how many humans GET /v1/humans returned before it
+	// was paged, and at most 500. Clients that don't page get the first 500 humans as they did
+	// before, and should follow next_page_token to get the rest.
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous response, which must have been for the
+	// same filters and order.
+	PageToken string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	// order_by is one of name, created_at, views or dob, optionally followed by " desc". It is
+	// "created_at desc" when it isn't set.
+	OrderBy string `protobuf:"bytes,3,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// tags keeps the humans with any of the tags, or with a kind of one of them.
+	Tags []string `protobuf:"bytes,4,rep,name=tags,proto3" json:"tags,omitempty"`
+	// ethnicity keeps the humans of every ethnicity, or of one within it.
+	Ethnicity []string `protobuf:"bytes,5,rep,name=ethnicity,proto3" json:"ethnicity,omitempty"`
+	// gender is male, female or nonbinary.
+	Gender string `protobuf:"bytes,6,opt,name=gender,proto3" json:"gender,omitempty"`
+	// dob_after and dob_before keep living humans born after or before a date, which is YYYY,
+	// YYYY-MM or YYYY-MM-DD.
+	DobAfter  string `protobuf:"bytes,7,opt,name=dob_after,json=dobAfter,proto3" json:"dob_after,omitempty"`
+	DobBefore string `protobuf:"bytes,8,opt,name=dob_before,json=dobBefore,proto3" json:"dob_before,omitempty"`
+	// name keeps the humans whose name contains it, ignoring case. With include_aliases, their
+	// aliases and other names count too.
+	Name           string `protobuf:"bytes,9,opt,name=name,proto3" json:"name,omitempty"`
+	IncludeAliases bool   `protobuf:"varint,10,opt,name=include_aliases,json=includeAliases,proto3" json:"include_aliases,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListHumansRequest) Reset() {
+	*x = ListHumansRequest{}
+	mi := &file_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHumansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHumansRequest) ProtoMessage() {}
+
+func (x *ListHumansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[5]
 	if x != nil {
-		return x.Name
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *Human) GetPath() string {
+// Deprecated: Use ListHumansRequest.ProtoReflect.Descriptor instead.
+func (*ListHumansRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListHumansRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Path
+		return x.PageSize
 	}
-	return ""
+	return 0
 }
 
-func (x *Human) GetDob() string {
+func (x *ListHumansRequest) GetPageToken() string {
 	if x != nil {
-		return x.Dob
+		return x.PageToken
 	}
 	return ""
 }
 
-func (x *Human) GetDod() string {
+func (x *ListHumansRequest) GetOrderBy() string {
 	if x != nil {
-		return x.Dod
+		return x.OrderBy
 	}
 	return ""
 }
 
-func (x *Human) GetTags() []string {
+func (x *ListHumansRequest) GetTags() []string {
 	if x != nil {
 		return x.Tags
 	}
 	return nil
 }
 
-func (x *Human) GetEthnicity() []string {
+func (x *ListHumansRequest) GetEthnicity() []string {
 	if x != nil {
 		return x.Ethnicity
 	}
 	return nil
 }
 
-func (x *Human) GetImage() string {
+func (x *ListHumansRequest) GetGender() string {
 	if x != nil {
-		return x.Image
+		return x.Gender
 	}
 	return ""
 }
 
-func (x *Human) GetDescription() string {
+func (x *ListHumansRequest) GetDobAfter() string {
 	if x != nil {
-		return x.Description
+		return x.DobAfter
 	}
 	return ""
 }
 
-func (x *Human) GetSocials() *HumanSocial {
+func (x *ListHumansRequest) GetDobBefore() string {
 	if x != nil {
-		return x.Socials
+		return x.DobBefore
 	}
-	return nil
+	return ""
 }
 
-func (x *Human) GetGender() Gender {
+func (x *ListHumansRequest) GetName() string {
 	if x != nil {
-		return x.Gender
+		return x.Name
 	}
-	return Gender_MALE
+	return ""
 }
 
-type HumanSocial struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Instagram     string                 `protobuf:"bytes,1,opt,name=instagram,proto3" json:"instagram,omitempty"`
-	X             string                 `protobuf:"bytes,2,opt,name=x,proto3" json:"x,omitempty"`
-	Website       string                 `protobuf:"bytes,3,opt,name=website,proto3" json:"website,omitempty"`
-	Imdb          string                 `protobuf:"bytes,4,opt,name=imdb,proto3" json:"imdb,omitempty"`
+func (x *ListHumansRequest) GetIncludeAliases() bool {
+	if x != nil {
+		return x.IncludeAliases
+	}
+	return false
+}
+
+type ListHumansResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Humans []*Human               `protobuf:"bytes,1,rep,name=humans,proto3" json:"humans,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	// total_size is how many humans match the filters, across every page.
+	TotalSize     int32 `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HumanSocial) Reset() {
-	*x = HumanSocial{}
-	mi := &file_api_proto_msgTypes[5]
+func (x *ListHumansResponse) Reset() {
+	*x = ListHumansResponse{}
+	mi := &file_api_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HumanSocial) String() string {
+func (x *ListHumansResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HumanSocial) ProtoMessage() {}
+func (*ListHumansResponse) ProtoMessage() {}
 
-func (x *HumanSocial) ProtoReflect() protoreflect.Message {
-	mi := &file_api_proto_msgTypes[5]
+func (x *ListHumansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -398,78 +524,1728 @@ func (x *HumanSocial) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HumanSocial.ProtoReflect.Descriptor instead.
-func (*HumanSocial) Descriptor() ([]byte, []int) {
-	return file_api_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListHumansResponse.ProtoReflect.Descriptor instead.
+func (*ListHumansResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *HumanSocial) GetInstagram() string {
+func (x *ListHumansResponse) GetHumans() []*Human {
 	if x != nil {
-		return x.Instagram
+		return x.Humans
+	}
+	return nil
+}
+
+func (x *ListHumansResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-func (x *HumanSocial) GetX() string {
+func (x *ListHumansResponse) GetTotalSize() int32 {
 	if x != nil {
-		return x.X
+		return x.TotalSize
+	}
+	return 0
+}
+
+type SearchHumansRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Query string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// page_size is 50 when it isn't set, and at most 500.
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// page_token is the next_page_token of the previous response, which must have been for the
+	// same query.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHumansRequest) Reset() {
+	*x = SearchHumansRequest{}
+	mi := &file_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHumansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHumansRequest) ProtoMessage() {}
+
+func (x *SearchHumansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHumansRequest.ProtoReflect.Descriptor instead.
+func (*SearchHumansRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *SearchHumansRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
 	}
 	return ""
 }
 
-func (x *HumanSocial) GetWebsite() string {
+func (x *SearchHumansRequest) GetPageSize() int32 {
 	if x != nil {
-		return x.Website
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchHumansRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
 	}
 	return ""
 }
 
-func (x *HumanSocial) GetImdb() string {
+type SearchHumansResponse struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Humans []*Human               `protobuf:"bytes,1,rep,name=humans,proto3" json:"humans,omitempty"`
+	// next_page_token is empty on the last page.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalSize     int32  `protobuf:"varint,3,opt,name=total_size,json=totalSize,proto3" json:"total_size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHumansResponse) Reset() {
+	*x = SearchHumansResponse{}
+	mi := &file_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHumansResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHumansResponse) ProtoMessage() {}
+
+func (x *SearchHumansResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[8]
 	if x != nil {
-		return x.Imdb
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHumansResponse.ProtoReflect.Descriptor instead.
+func (*SearchHumansResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *SearchHumansResponse) GetHumans() []*Human {
+	if x != nil {
+		return x.Humans
+	}
+	return nil
+}
+
+func (x *SearchHumansResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
 	}
 	return ""
 }
 
-var File_api_proto protoreflect.FileDescriptor
+func (x *SearchHumansResponse) GetTotalSize() int32 {
+	if x != nil {
+		return x.TotalSize
+	}
+	return 0
+}
 
-const file_api_proto_rawDesc = "" +
-	"\n" +
-	"\tapi.proto\x12\x04main\x1a\x1cgoogle/api/annotations.proto\"\x10\n" +
-	"\x0eVersionRequest\"=\n" +
-	"\x0fVersionResponse\x12\x18\n" +
-	"\aversion\x18\x01 \x01(\tR\aversion\x12\x10\n" +
-	"\x03now\x18\x02 \x01(\tR\x03now\"\x0f\n" +
-	"\rHumansRequest\"5\n" +
-	"\x0eHumansResponse\x12#\n" +
-	"\x06humans\x18\x01 \x03(\v2\v.main.HumanR\x06humans\"\xa0\x02\n" +
-	"\x05Human\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04path\x18\x03 \x01(\tR\x04path\x12\x10\n" +
-	"\x03dob\x18\x04 \x01(\tR\x03dob\x12\x10\n" +
-	"\x03dod\x18\x05 \x01(\tR\x03dod\x12\x12\n" +
-	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1c\n" +
-	"\tethnicity\x18\a \x03(\tR\tethnicity\x12\x14\n" +
-	"\x05image\x18\b \x01(\tR\x05image\x12 \n" +
-	"\vdescription\x18\t \x01(\tR\vdescription\x12+\n" +
-	"\asocials\x18\n" +
-	" \x01(\v2\x11.main.HumanSocialR\asocials\x12$\n" +
-	"\x06gender\x18\v \x01(\x0e2\f.main.GenderR\x06gender\"g\n" +
-	"\vHumanSocial\x12\x1c\n" +
-	"\tinstagram\x18\x01 \x01(\tR\tinstagram\x12\f\n" +
-	"\x01x\x18\x02 \x01(\tR\x01x\x12\x18\n" +
-	"\awebsite\x18\x03 \x01(\tR\awebsite\x12\x12\n" +
-	"\x04imdb\x18\x04 \x01(\tR\x04imdb*-\n" +
-	"\x06Gender\x12\b\n" +
-	"\x04MALE\x10\x00\x12\n" +
-	"\n" +
-	"\x06FEMALE\x10\x01\x12\r\n" +
-	"\tNONBINARY\x10\x022\xa4\x01\n" +
-	"\fHumanService\x12K\n" +
-	"\aVersion\x12\x14.main.VersionRequest\x1a\x15.main.VersionResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/version\x12G\n" +
-	"\x06Humans\x12\x13.main.HumansRequest\x1a\x14.main.HumansResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
-	"/v1/humansB\n" +
+type CreateHumanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// human is the human to add. Only the fields UpdateHuman can update are used, and the rest are
+	// set by the server.
+	Human         *Human `protobuf:"bytes,1,opt,name=human,proto3" json:"human,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateHumanRequest) Reset() {
+	*x = CreateHumanRequest{}
+	mi := &file_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateHumanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateHumanRequest) ProtoMessage() {}
+
+func (x *CreateHumanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateHumanRequest.ProtoReflect.Descriptor instead.
+func (*CreateHumanRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CreateHumanRequest) GetHuman() *Human {
+	if x != nil {
+		return x.Human
+	}
+	return nil
+}
+
+type UpdateHumanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// human.id is the ID or the path of the human to update.
+	Human *Human `protobuf:"bytes,1,opt,name=human,proto3" json:"human,omitempty"`
+	// update_mask is the fields to update: name, aliases, names, dob, dod, tags, ethnicity,
+	// birth_place, based_in, description, gender, ai_generated, works, awards, sources or socials.
+	// socials can be updated as a whole, or one at a time, e.g. socials.instagram. The images are
+	// changed on the website, and the rest are set by the server.
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateHumanRequest) Reset() {
+	*x = UpdateHumanRequest{}
+	mi := &file_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateHumanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateHumanRequest) ProtoMessage() {}
+
+func (x *UpdateHumanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateHumanRequest.ProtoReflect.Descriptor instead.
+func (*UpdateHumanRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *UpdateHumanRequest) GetHuman() *Human {
+	if x != nil {
+		return x.Human
+	}
+	return nil
+}
+
+func (x *UpdateHumanRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type PublishHumanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the ID or the path of the human.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishHumanRequest) Reset() {
+	*x = PublishHumanRequest{}
+	mi := &file_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishHumanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishHumanRequest) ProtoMessage() {}
+
+func (x *PublishHumanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishHumanRequest.ProtoReflect.Descriptor instead.
+func (*PublishHumanRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PublishHumanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteHumanRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id is the ID or the path of the human.
+	Id            string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteHumanRequest) Reset() {
+	*x = DeleteHumanRequest{}
+	mi := &file_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteHumanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteHumanRequest) ProtoMessage() {}
+
+func (x *DeleteHumanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteHumanRequest.ProtoReflect.Descriptor instead.
+func (*DeleteHumanRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DeleteHumanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type WatchHumansRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// resume_token is the resume_token of the last event the client got, to continue right after
+	// it. Without one, the watch starts with every published human as ADDED. Each server only keeps
+	// the latest events, in memory, so a token from another server, from before a restart or
+	// deploy, or for an event that is no longer kept starts the watch over with a RESYNC event.
+	ResumeToken string `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	// skip_existing starts a watch without a resume token at the changes from now on.
+	SkipExisting  bool `protobuf:"varint,2,opt,name=skip_existing,json=skipExisting,proto3" json:"skip_existing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchHumansRequest) Reset() {
+	*x = WatchHumansRequest{}
+	mi := &file_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchHumansRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchHumansRequest) ProtoMessage() {}
+
+func (x *WatchHumansRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchHumansRequest.ProtoReflect.Descriptor instead.
+func (*WatchHumansRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WatchHumansRequest) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *WatchHumansRequest) GetSkipExisting() bool {
+	if x != nil {
+		return x.SkipExisting
+	}
+	return false
+}
+
+type HumanEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  ChangeType             `protobuf:"varint,1,opt,name=type,proto3,enum=main.ChangeType" json:"type,omitempty"`
+	// human is the human as it is now, or as it was before it was removed. CURRENT events have
+	// none.
+	Human *Human `protobuf:"bytes,2,opt,name=human,proto3" json:"human,omitempty"`
+	// resume_token resumes the watch after this event. The ADDED events a watch starts with have
+	// none, since the CURRENT event after them resumes after all of them.
+	ResumeToken   string `protobuf:"bytes,3,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HumanEvent) Reset() {
+	*x = HumanEvent{}
+	mi := &file_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HumanEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HumanEvent) ProtoMessage() {}
+
+func (x *HumanEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HumanEvent.ProtoReflect.Descriptor instead.
+func (*HumanEvent) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *HumanEvent) GetType() ChangeType {
+	if x != nil {
+		return x.Type
+	}
+	return ChangeType_CHANGE_TYPE_UNSPECIFIED
+}
+
+func (x *HumanEvent) GetHuman() *Human {
+	if x != nil {
+		return x.Human
+	}
+	return nil
+}
+
+func (x *HumanEvent) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+type Human struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Path  string                 `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`
+	// dob and dod are YYYY, YYYY-MM or YYYY-MM-DD. dob_date and dod_date hold the same dates by part.
+	Dob       string   `protobuf:"bytes,4,opt,name=dob,proto3" json:"dob,omitempty"`
+	Dod       string   `protobuf:"bytes,5,opt,name=dod,proto3" json:"dod,omitempty"`
+	Tags      []string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty"`
+	Ethnicity []string `protobuf:"bytes,7,rep,name=ethnicity,proto3" json:"ethnicity,omitempty"`
+	// image is the URL of the featured image, which images has along with its details.
+	//
+	// Deprecated: Marked as deprecated in api.proto.
+	Image       string       `protobuf:"bytes,8,opt,name=image,proto3" json:"image,omitempty"`
+	Description string       `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	Socials     *HumanSocial `protobuf:"bytes,10,opt,name=socials,proto3" json:"socials,omitempty"`
+	Gender      Gender       `protobuf:"varint,11,opt,name=gender,proto3,enum=main.Gender" json:"gender,omitempty"`
+	DobDate     *PartialDate `protobuf:"bytes,12,opt,name=dob_date,json=dobDate,proto3" json:"dob_date,omitempty"`
+	DodDate     *PartialDate `protobuf:"bytes,13,opt,name=dod_date,json=dodDate,proto3" json:"dod_date,omitempty"`
+	Aliases     []string     `protobuf:"bytes,14,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// names are the name in other scripts, or romanized.
+	Names []*NameVariant `protobuf:"bytes,15,rep,name=names,proto3" json:"names,omitempty"`
+	// previous_paths are old paths of the human, which GetHuman still finds it by.
+	PreviousPaths []string `protobuf:"bytes,16,rep,name=previous_paths,json=previousPaths,proto3" json:"previous_paths,omitempty"`
+	BirthPlace    *Place   `protobuf:"bytes,17,opt,name=birth_place,json=birthPlace,proto3" json:"birth_place,omitempty"`
+	// based_in is where the human lives or works.
+	BasedIn []*Place `protobuf:"bytes,18,rep,name=based_in,json=basedIn,proto3" json:"based_in,omitempty"`
+	// birth_location and locations are where the human was born and is based, from before places
+	// had parts.
+	BirthLocation string                 `protobuf:"bytes,19,opt,name=birth_location,json=birthLocation,proto3" json:"birth_location,omitempty"`
+	Locations     []string               `protobuf:"bytes,20,rep,name=locations,proto3" json:"locations,omitempty"`
+	InfluencedBy  []string               `protobuf:"bytes,21,rep,name=influenced_by,json=influencedBy,proto3" json:"influenced_by,omitempty"`
+	Images        *HumanImages           `protobuf:"bytes,22,opt,name=images,proto3" json:"images,omitempty"`
+	Draft         bool                   `protobuf:"varint,23,opt,name=draft,proto3" json:"draft,omitempty"`
+	AiGenerated   bool                   `protobuf:"varint,24,opt,name=ai_generated,json=aiGenerated,proto3" json:"ai_generated,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// created_by, version and published_by are only set for admins.
+	CreatedBy string                 `protobuf:"bytes,26,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,27,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// version is bumped on every change to the human.
+	Version     int64                  `protobuf:"varint,28,opt,name=version,proto3" json:"version,omitempty"`
+	PublishedAt *timestamppb.Timestamp `protobuf:"bytes,29,opt,name=published_at,json=publishedAt,proto3" json:"published_at,omitempty"`
+	PublishedBy string                 `protobuf:"bytes,30,opt,name=published_by,json=publishedBy,proto3" json:"published_by,omitempty"`
+	Views       int64                  `protobuf:"varint,31,opt,name=views,proto3" json:"views,omitempty"`
+	// similar is the IDs of the humans most like this one.
+	Similar       []string  `protobuf:"bytes,32,rep,name=similar,proto3" json:"similar,omitempty"`
+	Works         []*Work   `protobuf:"bytes,33,rep,name=works,proto3" json:"works,omitempty"`
+	Awards        []*Award  `protobuf:"bytes,34,rep,name=awards,proto3" json:"awards,omitempty"`
+	Sources       []*Source `protobuf:"bytes,35,rep,name=sources,proto3" json:"sources,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Human) Reset() {
+	*x = Human{}
+	mi := &file_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Human) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Human) ProtoMessage() {}
+
+func (x *Human) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Human.ProtoReflect.Descriptor instead.
+func (*Human) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Human) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Human) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Human) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *Human) GetDob() string {
+	if x != nil {
+		return x.Dob
+	}
+	return ""
+}
+
+func (x *Human) GetDod() string {
+	if x != nil {
+		return x.Dod
+	}
+	return ""
+}
+
+func (x *Human) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *Human) GetEthnicity() []string {
+	if x != nil {
+		return x.Ethnicity
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in api.proto.
+func (x *Human) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *Human) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Human) GetSocials() *HumanSocial {
+	if x != nil {
+		return x.Socials
+	}
+	return nil
+}
+
+func (x *Human) GetGender() Gender {
+	if x != nil {
+		return x.Gender
+	}
+	return Gender_GENDER_UNSPECIFIED
+}
+
+func (x *Human) GetDobDate() *PartialDate {
+	if x != nil {
+		return x.DobDate
+	}
+	return nil
+}
+
+func (x *Human) GetDodDate() *PartialDate {
+	if x != nil {
+		return x.DodDate
+	}
+	return nil
+}
+
+func (x *Human) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *Human) GetNames() []*NameVariant {
+	if x != nil {
+		return x.Names
+	}
+	return nil
+}
+
+func (x *Human) GetPreviousPaths() []string {
+	if x != nil {
+		return x.PreviousPaths
+	}
+	return nil
+}
+
+func (x *Human) GetBirthPlace() *Place {
+	if x != nil {
+		return x.BirthPlace
+	}
+	return nil
+}
+
+func (x *Human) GetBasedIn() []*Place {
+	if x != nil {
+		return x.BasedIn
+	}
+	return nil
+}
+
+func (x *Human) GetBirthLocation() string {
+	if x != nil {
+		return x.BirthLocation
+	}
+	return ""
+}
+
+func (x *Human) GetLocations() []string {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
+func (x *Human) GetInfluencedBy() []string {
+	if x != nil {
+		return x.InfluencedBy
+	}
+	return nil
+}
+
+func (x *Human) GetImages() *HumanImages {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *Human) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *Human) GetAiGenerated() bool {
+	if x != nil {
+		return x.AiGenerated
+	}
+	return false
+}
+
+func (x *Human) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Human) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Human) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Human) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Human) GetPublishedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishedAt
+	}
+	return nil
+}
+
+func (x *Human) GetPublishedBy() string {
+	if x != nil {
+		return x.PublishedBy
+	}
+	return ""
+}
+
+func (x *Human) GetViews() int64 {
+	if x != nil {
+		return x.Views
+	}
+	return 0
+}
+
+func (x *Human) GetSimilar() []string {
+	if x != nil {
+		return x.Similar
+	}
+	return nil
+}
+
+func (x *Human) GetWorks() []*Work {
+	if x != nil {
+		return x.Works
+	}
+	return nil
+}
+
+func (x *Human) GetAwards() []*Award {
+	if x != nil {
+		return x.Awards
+	}
+	return nil
+}
+
+func (x *Human) GetSources() []*Source {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// PartialDate is a date that may only be known to the year or the month.
+type PartialDate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Year  int32                  `protobuf:"varint,1,opt,name=year,proto3" json:"year,omitempty"`
+	// 1-12, or 0 if only the year is known.
+	Month int32 `protobuf:"varint,2,opt,name=month,proto3" json:"month,omitempty"`
+	// 1-31, or 0 if only the year and month are known.
+	Day           int32 `protobuf:"varint,3,opt,name=day,proto3" json:"day,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PartialDate) Reset() {
+	*x = PartialDate{}
+	mi := &file_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PartialDate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PartialDate) ProtoMessage() {}
+
+func (x *PartialDate) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PartialDate.ProtoReflect.Descriptor instead.
+func (*PartialDate) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *PartialDate) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *PartialDate) GetMonth() int32 {
+	if x != nil {
+		return x.Month
+	}
+	return 0
+}
+
+func (x *PartialDate) GetDay() int32 {
+	if x != nil {
+		return x.Day
+	}
+	return 0
+}
+
+type HumanSocial struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Instagram     string                 `protobuf:"bytes,1,opt,name=instagram,proto3" json:"instagram,omitempty"`
+	X             string                 `protobuf:"bytes,2,opt,name=x,proto3" json:"x,omitempty"`
+	Website       string                 `protobuf:"bytes,3,opt,name=website,proto3" json:"website,omitempty"`
+	Imdb          string                 `protobuf:"bytes,4,opt,name=imdb,proto3" json:"imdb,omitempty"`
+	Youtube       string                 `protobuf:"bytes,5,opt,name=youtube,proto3" json:"youtube,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HumanSocial) Reset() {
+	*x = HumanSocial{}
+	mi := &file_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HumanSocial) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HumanSocial) ProtoMessage() {}
+
+func (x *HumanSocial) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HumanSocial.ProtoReflect.Descriptor instead.
+func (*HumanSocial) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *HumanSocial) GetInstagram() string {
+	if x != nil {
+		return x.Instagram
+	}
+	return ""
+}
+
+func (x *HumanSocial) GetX() string {
+	if x != nil {
+		return x.X
+	}
+	return ""
+}
+
+func (x *HumanSocial) GetWebsite() string {
+	if x != nil {
+		return x.Website
+	}
+	return ""
+}
+
+func (x *HumanSocial) GetImdb() string {
+	if x != nil {
+		return x.Imdb
+	}
+	return ""
+}
+
+func (x *HumanSocial) GetYoutube() string {
+	if x != nil {
+		return x.Youtube
+	}
+	return ""
+}
+
+type NameVariant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Value string                 `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	// lang is the BCP 47 tag of the language and script of the name, e.g. zh-Hant.
+	Lang string `protobuf:"bytes,2,opt,name=lang,proto3" json:"lang,omitempty"`
+	// type is birth, legal, stage or romanization.
+	Type          string `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NameVariant) Reset() {
+	*x = NameVariant{}
+	mi := &file_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NameVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NameVariant) ProtoMessage() {}
+
+func (x *NameVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NameVariant.ProtoReflect.Descriptor instead.
+func (*NameVariant) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *NameVariant) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *NameVariant) GetLang() string {
+	if x != nil {
+		return x.Lang
+	}
+	return ""
+}
+
+func (x *NameVariant) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+type Place struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	City  string                 `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	// region is the state, province or prefecture.
+	Region  string `protobuf:"bytes,2,opt,name=region,proto3" json:"region,omitempty"`
+	Country string `protobuf:"bytes,3,opt,name=country,proto3" json:"country,omitempty"`
+	// lat and lng are both 0 when where the place is isn't known.
+	Lat           float64 `protobuf:"fixed64,4,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lng           float64 `protobuf:"fixed64,5,opt,name=lng,proto3" json:"lng,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Place) Reset() {
+	*x = Place{}
+	mi := &file_api_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Place) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Place) ProtoMessage() {}
+
+func (x *Place) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Place.ProtoReflect.Descriptor instead.
+func (*Place) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Place) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Place) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Place) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Place) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *Place) GetLng() float64 {
+	if x != nil {
+		return x.Lng
+	}
+	return 0
+}
+
+type HumanImages struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// featured and thumbnail are the URLs of the featured image and its thumbnail.
+	Featured  string `protobuf:"bytes,1,opt,name=featured,proto3" json:"featured,omitempty"`
+	Thumbnail string `protobuf:"bytes,2,opt,name=thumbnail,proto3" json:"thumbnail,omitempty"`
+	// featured_id is the image of the gallery that is featured, if any.
+	FeaturedId    string   `protobuf:"bytes,3,opt,name=featured_id,json=featuredId,proto3" json:"featured_id,omitempty"`
+	Gallery       []*Image `protobuf:"bytes,4,rep,name=gallery,proto3" json:"gallery,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HumanImages) Reset() {
+	*x = HumanImages{}
+	mi := &file_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HumanImages) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HumanImages) ProtoMessage() {}
+
+func (x *HumanImages) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HumanImages.ProtoReflect.Descriptor instead.
+func (*HumanImages) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *HumanImages) GetFeatured() string {
+	if x != nil {
+		return x.Featured
+	}
+	return ""
+}
+
+func (x *HumanImages) GetThumbnail() string {
+	if x != nil {
+		return x.Thumbnail
+	}
+	return ""
+}
+
+func (x *HumanImages) GetFeaturedId() string {
+	if x != nil {
+		return x.FeaturedId
+	}
+	return ""
+}
+
+func (x *HumanImages) GetGallery() []*Image {
+	if x != nil {
+		return x.Gallery
+	}
+	return nil
+}
+
+type Image struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// object and thumbnail_object are where the image and its thumbnail are stored. They are only
+	// set for admins, like uploaded_by.
+	Object          string `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	ThumbnailObject string `protobuf:"bytes,3,opt,name=thumbnail_object,json=thumbnailObject,proto3" json:"thumbnail_object,omitempty"`
+	Url             string `protobuf:"bytes,4,opt,name=url,proto3" json:"url,omitempty"`
+	ThumbnailUrl    string `protobuf:"bytes,5,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	Width           int32  `protobuf:"varint,6,opt,name=width,proto3" json:"width,omitempty"`
+	Height          int32  `protobuf:"varint,7,opt,name=height,proto3" json:"height,omitempty"`
+	// source_url is where the image was found.
+	SourceUrl     string                 `protobuf:"bytes,8,opt,name=source_url,json=sourceUrl,proto3" json:"source_url,omitempty"`
+	Author        string                 `protobuf:"bytes,9,opt,name=author,proto3" json:"author,omitempty"`
+	License       string                 `protobuf:"bytes,10,opt,name=license,proto3" json:"license,omitempty"`
+	AiGenerated   bool                   `protobuf:"varint,11,opt,name=ai_generated,json=aiGenerated,proto3" json:"ai_generated,omitempty"`
+	Caption       string                 `protobuf:"bytes,12,opt,name=caption,proto3" json:"caption,omitempty"`
+	UploadedAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=uploaded_at,json=uploadedAt,proto3" json:"uploaded_at,omitempty"`
+	UploadedBy    string                 `protobuf:"bytes,14,opt,name=uploaded_by,json=uploadedBy,proto3" json:"uploaded_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Image) Reset() {
+	*x = Image{}
+	mi := &file_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Image) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Image) ProtoMessage() {}
+
+func (x *Image) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Image.ProtoReflect.Descriptor instead.
+func (*Image) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Image) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Image) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *Image) GetThumbnailObject() string {
+	if x != nil {
+		return x.ThumbnailObject
+	}
+	return ""
+}
+
+func (x *Image) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Image) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
+
+func (x *Image) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *Image) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *Image) GetSourceUrl() string {
+	if x != nil {
+		return x.SourceUrl
+	}
+	return ""
+}
+
+func (x *Image) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *Image) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+func (x *Image) GetAiGenerated() bool {
+	if x != nil {
+		return x.AiGenerated
+	}
+	return false
+}
+
+func (x *Image) GetCaption() string {
+	if x != nil {
+		return x.Caption
+	}
+	return ""
+}
+
+func (x *Image) GetUploadedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UploadedAt
+	}
+	return nil
+}
+
+func (x *Image) GetUploadedBy() string {
+	if x != nil {
+		return x.UploadedBy
+	}
+	return ""
+}
+
+type Work struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Title string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	// type is film, tv, stage, album, song, book, game or other.
+	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	// year is 0 when it isn't known.
+	Year int32 `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+	// credit is what the human did on the work, e.g. Director.
+	Credit        string `protobuf:"bytes,4,opt,name=credit,proto3" json:"credit,omitempty"`
+	Url           string `protobuf:"bytes,5,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Work) Reset() {
+	*x = Work{}
+	mi := &file_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Work) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Work) ProtoMessage() {}
+
+func (x *Work) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Work.ProtoReflect.Descriptor instead.
+func (*Work) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Work) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Work) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Work) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Work) GetCredit() string {
+	if x != nil {
+		return x.Credit
+	}
+	return ""
+}
+
+func (x *Work) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type Award struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Name     string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Category string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	Year     int32                  `protobuf:"varint,3,opt,name=year,proto3" json:"year,omitempty"`
+	// result is won or nominated.
+	Result        string `protobuf:"bytes,4,opt,name=result,proto3" json:"result,omitempty"`
+	Source        string `protobuf:"bytes,5,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Award) Reset() {
+	*x = Award{}
+	mi := &file_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Award) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Award) ProtoMessage() {}
+
+func (x *Award) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Award.ProtoReflect.Descriptor instead.
+func (*Award) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Award) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Award) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Award) GetYear() int32 {
+	if x != nil {
+		return x.Year
+	}
+	return 0
+}
+
+func (x *Award) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *Award) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+type Source struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Url        string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Title      string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Publisher  string                 `protobuf:"bytes,3,opt,name=publisher,proto3" json:"publisher,omitempty"`
+	AccessedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=accessed_at,json=accessedAt,proto3" json:"accessed_at,omitempty"`
+	// fields are the fields the source supports, or empty when it supports the human as a whole.
+	Fields        []string `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Source) Reset() {
+	*x = Source{}
+	mi := &file_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Source) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Source) ProtoMessage() {}
+
+func (x *Source) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Source.ProtoReflect.Descriptor instead.
+func (*Source) Descriptor() ([]byte, []int) {
+	return file_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Source) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Source) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Source) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *Source) GetAccessedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.AccessedAt
+	}
+	return nil
+}
+
+func (x *Source) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+var File_api_proto protoreflect.FileDescriptor
+
+const file_api_proto_rawDesc = "" +
+	"\n" +
+	"\tapi.proto\x12\x04main\x1a\x1cgoogle/api/annotations.proto\x1a\x1bgoogle/protobuf/empty.proto\x1a google/protobuf/field_mask.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x10\n" +
+	"\x0eVersionRequest\"=\n" +
+	"\x0fVersionResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x10\n" +
+	"\x03now\x18\x02 \x01(\tR\x03now\"\x0f\n" +
+	"\rHumansRequest\"5\n" +
+	"\x0eHumansResponse\x12#\n" +
+	"\x06humans\x18\x01 \x03(\v2\v.main.HumanR\x06humans\"!\n" +
+	"\x0fGetHumanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xad\x02\n" +
+	"\x11ListHumansRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12\x19\n" +
+	"\border_by\x18\x03 \x01(\tR\aorderBy\x12\x12\n" +
+	"\x04tags\x18\x04 \x03(\tR\x04tags\x12\x1c\n" +
+	"\tethnicity\x18\x05 \x03(\tR\tethnicity\x12\x16\n" +
+	"\x06gender\x18\x06 \x01(\tR\x06gender\x12\x1b\n" +
+	"\tdob_after\x18\a \x01(\tR\bdobAfter\x12\x1d\n" +
+	"\n" +
+	"dob_before\x18\b \x01(\tR\tdobBefore\x12\x12\n" +
+	"\x04name\x18\t \x01(\tR\x04name\x12'\n" +
+	"\x0finclude_aliases\x18\n" +
+	" \x01(\bR\x0eincludeAliases\"\x80\x01\n" +
+	"\x12ListHumansResponse\x12#\n" +
+	"\x06humans\x18\x01 \x03(\v2\v.main.HumanR\x06humans\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"g\n" +
+	"\x13SearchHumansRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"\x82\x01\n" +
+	"\x14SearchHumansResponse\x12#\n" +
+	"\x06humans\x18\x01 \x03(\v2\v.main.HumanR\x06humans\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\x12\x1d\n" +
+	"\n" +
+	"total_size\x18\x03 \x01(\x05R\ttotalSize\"7\n" +
+	"\x12CreateHumanRequest\x12!\n" +
+	"\x05human\x18\x01 \x01(\v2\v.main.HumanR\x05human\"t\n" +
+	"\x12UpdateHumanRequest\x12!\n" +
+	"\x05human\x18\x01 \x01(\v2\v.main.HumanR\x05human\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"%\n" +
+	"\x13PublishHumanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"$\n" +
+	"\x12DeleteHumanRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\\\n" +
+	"\x12WatchHumansRequest\x12!\n" +
+	"\fresume_token\x18\x01 \x01(\tR\vresumeToken\x12#\n" +
+	"\rskip_existing\x18\x02 \x01(\bR\fskipExisting\"x\n" +
+	"\n" +
+	"HumanEvent\x12$\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x10.main.ChangeTypeR\x04type\x12!\n" +
+	"\x05human\x18\x02 \x01(\v2\v.main.HumanR\x05human\x12!\n" +
+	"\fresume_token\x18\x03 \x01(\tR\vresumeToken\"\xbe\t\n" +
+	"\x05Human\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04path\x18\x03 \x01(\tR\x04path\x12\x10\n" +
+	"\x03dob\x18\x04 \x01(\tR\x03dob\x12\x10\n" +
+	"\x03dod\x18\x05 \x01(\tR\x03dod\x12\x12\n" +
+	"\x04tags\x18\x06 \x03(\tR\x04tags\x12\x1c\n" +
+	"\tethnicity\x18\a \x03(\tR\tethnicity\x12\x18\n" +
+	"\x05image\x18\b \x01(\tB\x02\x18\x01R\x05image\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12+\n" +
+	"\asocials\x18\n" +
+	" \x01(\v2\x11.main.HumanSocialR\asocials\x12$\n" +
+	"\x06gender\x18\v \x01(\x0e2\f.main.GenderR\x06gender\x12,\n" +
+	"\bdob_date\x18\f \x01(\v2\x11.main.PartialDateR\adobDate\x12,\n" +
+	"\bdod_date\x18\r \x01(\v2\x11.main.PartialDateR\adodDate\x12\x18\n" +
+	"\aaliases\x18\x0e \x03(\tR\aaliases\x12'\n" +
+	"\x05names\x18\x0f \x03(\v2\x11.main.NameVariantR\x05names\x12%\n" +
+	"\x0eprevious_paths\x18\x10 \x03(\tR\rpreviousPaths\x12,\n" +
+	"\vbirth_place\x18\x11 \x01(\v2\v.main.PlaceR\n" +
+	"birthPlace\x12&\n" +
+	"\bbased_in\x18\x12 \x03(\v2\v.main.PlaceR\abasedIn\x12%\n" +
+	"\x0ebirth_location\x18\x13 \x01(\tR\rbirthLocation\x12\x1c\n" +
+	"\tlocations\x18\x14 \x03(\tR\tlocations\x12#\n" +
+	"\rinfluenced_by\x18\x15 \x03(\tR\finfluencedBy\x12)\n" +
+	"\x06images\x18\x16 \x01(\v2\x11.main.HumanImagesR\x06images\x12\x14\n" +
+	"\x05draft\x18\x17 \x01(\bR\x05draft\x12!\n" +
+	"\fai_generated\x18\x18 \x01(\bR\vaiGenerated\x129\n" +
+	"\n" +
+	"created_at\x18\x19 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x1a \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"updated_at\x18\x1b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\x1c \x01(\x03R\aversion\x12=\n" +
+	"\fpublished_at\x18\x1d \x01(\v2\x1a.google.protobuf.TimestampR\vpublishedAt\x12!\n" +
+	"\fpublished_by\x18\x1e \x01(\tR\vpublishedBy\x12\x14\n" +
+	"\x05views\x18\x1f \x01(\x03R\x05views\x12\x18\n" +
+	"\asimilar\x18  \x03(\tR\asimilar\x12 \n" +
+	"\x05works\x18! \x03(\v2\n" +
+	".main.WorkR\x05works\x12#\n" +
+	"\x06awards\x18\" \x03(\v2\v.main.AwardR\x06awards\x12&\n" +
+	"\asources\x18# \x03(\v2\f.main.SourceR\asources\"I\n" +
+	"\vPartialDate\x12\x12\n" +
+	"\x04year\x18\x01 \x01(\x05R\x04year\x12\x14\n" +
+	"\x05month\x18\x02 \x01(\x05R\x05month\x12\x10\n" +
+	"\x03day\x18\x03 \x01(\x05R\x03day\"\x81\x01\n" +
+	"\vHumanSocial\x12\x1c\n" +
+	"\tinstagram\x18\x01 \x01(\tR\tinstagram\x12\f\n" +
+	"\x01x\x18\x02 \x01(\tR\x01x\x12\x18\n" +
+	"\awebsite\x18\x03 \x01(\tR\awebsite\x12\x12\n" +
+	"\x04imdb\x18\x04 \x01(\tR\x04imdb\x12\x18\n" +
+	"\ayoutube\x18\x05 \x01(\tR\ayoutube\"K\n" +
+	"\vNameVariant\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\tR\x05value\x12\x12\n" +
+	"\x04lang\x18\x02 \x01(\tR\x04lang\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"q\n" +
+	"\x05Place\x12\x12\n" +
+	"\x04city\x18\x01 \x01(\tR\x04city\x12\x16\n" +
+	"\x06region\x18\x02 \x01(\tR\x06region\x12\x18\n" +
+	"\acountry\x18\x03 \x01(\tR\acountry\x12\x10\n" +
+	"\x03lat\x18\x04 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lng\x18\x05 \x01(\x01R\x03lng\"\x8f\x01\n" +
+	"\vHumanImages\x12\x1a\n" +
+	"\bfeatured\x18\x01 \x01(\tR\bfeatured\x12\x1c\n" +
+	"\tthumbnail\x18\x02 \x01(\tR\tthumbnail\x12\x1f\n" +
+	"\vfeatured_id\x18\x03 \x01(\tR\n" +
+	"featuredId\x12%\n" +
+	"\agallery\x18\x04 \x03(\v2\v.main.ImageR\agallery\"\xab\x03\n" +
+	"\x05Image\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06object\x18\x02 \x01(\tR\x06object\x12)\n" +
+	"\x10thumbnail_object\x18\x03 \x01(\tR\x0fthumbnailObject\x12\x10\n" +
+	"\x03url\x18\x04 \x01(\tR\x03url\x12#\n" +
+	"\rthumbnail_url\x18\x05 \x01(\tR\fthumbnailUrl\x12\x14\n" +
+	"\x05width\x18\x06 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\a \x01(\x05R\x06height\x12\x1d\n" +
+	"\n" +
+	"source_url\x18\b \x01(\tR\tsourceUrl\x12\x16\n" +
+	"\x06author\x18\t \x01(\tR\x06author\x12\x18\n" +
+	"\alicense\x18\n" +
+	" \x01(\tR\alicense\x12!\n" +
+	"\fai_generated\x18\v \x01(\bR\vaiGenerated\x12\x18\n" +
+	"\acaption\x18\f \x01(\tR\acaption\x12;\n" +
+	"\vuploaded_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"uploadedAt\x12\x1f\n" +
+	"\vuploaded_by\x18\x0e \x01(\tR\n" +
+	"uploadedBy\"n\n" +
+	"\x04Work\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04year\x18\x03 \x01(\x05R\x04year\x12\x16\n" +
+	"\x06credit\x18\x04 \x01(\tR\x06credit\x12\x10\n" +
+	"\x03url\x18\x05 \x01(\tR\x03url\"{\n" +
+	"\x05Award\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x12\n" +
+	"\x04year\x18\x03 \x01(\x05R\x04year\x12\x16\n" +
+	"\x06result\x18\x04 \x01(\tR\x06result\x12\x16\n" +
+	"\x06source\x18\x05 \x01(\tR\x06source\"\xa3\x01\n" +
+	"\x06Source\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1c\n" +
+	"\tpublisher\x18\x03 \x01(\tR\tpublisher\x12;\n" +
+	"\vaccessed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"accessedAt\x12\x16\n" +
+	"\x06fields\x18\x05 \x03(\tR\x06fields*h\n" +
+	"\n" +
+	"ChangeType\x12\x1b\n" +
+	"\x17CHANGE_TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05ADDED\x10\x01\x12\f\n" +
+	"\bMODIFIED\x10\x02\x12\v\n" +
+	"\aREMOVED\x10\x03\x12\v\n" +
+	"\aCURRENT\x10\x04\x12\n" +
+	"\n" +
+	"\x06RESYNC\x10\x05*E\n" +
+	"\x06Gender\x12\x16\n" +
+	"\x12GENDER_UNSPECIFIED\x10\x00\x12\n" +
+	"\n" +
+	"\x06FEMALE\x10\x01\x12\r\n" +
+	"\tNONBINARY\x10\x02\x12\b\n" +
+	"\x04MALE\x10\x032\xb5\x06\n" +
+	"\fHumanService\x12K\n" +
+	"\aVersion\x12\x14.main.VersionRequest\x1a\x15.main.VersionResponse\"\x13\x82\xd3\xe4\x93\x02\r\x12\v/v1/version\x128\n" +
+	"\x06Humans\x12\x13.main.HumansRequest\x1a\x14.main.HumansResponse\"\x03\x88\x02\x01\x12G\n" +
+	"\bGetHuman\x12\x15.main.GetHumanRequest\x1a\v.main.Human\"\x17\x82\xd3\xe4\x93\x02\x11\x12\x0f/v1/humans/{id}\x12S\n" +
+	"\n" +
+	"ListHumans\x12\x17.main.ListHumansRequest\x1a\x18.main.ListHumansResponse\"\x12\x82\xd3\xe4\x93\x02\f\x12\n" +
+	"/v1/humans\x12`\n" +
+	"\fSearchHumans\x12\x19.main.SearchHumansRequest\x1a\x1a.main.SearchHumansResponse\"\x19\x82\xd3\xe4\x93\x02\x13\x12\x11/v1/humans:search\x12O\n" +
+	"\vCreateHuman\x12\x18.main.CreateHumanRequest\x1a\v.main.Human\"\x19\x82\xd3\xe4\x93\x02\x13:\x05human\"\n" +
+	"/v1/humans\x12Z\n" +
+	"\vUpdateHuman\x12\x18.main.UpdateHumanRequest\x1a\v.main.Human\"$\x82\xd3\xe4\x93\x02\x1e:\x05human2\x15/v1/humans/{human.id}\x12Z\n" +
+	"\fPublishHuman\x12\x19.main.PublishHumanRequest\x1a\v.main.Human\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/v1/humans/{id}:publish\x12;\n" +
+	"\vWatchHumans\x12\x18.main.WatchHumansRequest\x1a\x10.main.HumanEvent0\x01\x12X\n" +
+	"\vDeleteHuman\x12\x18.main.DeleteHumanRequest\x1a\x16.google.protobuf.Empty\"\x17\x82\xd3\xe4\x93\x02\x11*\x0f/v1/humans/{id}B\n" +
 	"Z\b./serverb\x06proto3"
 
 var (
@@ -484,30 +2260,91 @@ func file_api_proto_rawDescGZIP() []byte {
 	return file_api_proto_rawDescData
 }
 
-var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_api_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_api_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_api_proto_goTypes = []any{
-	(Gender)(0),             // 0: main.Gender
-	(*VersionRequest)(nil),  // 1: main.VersionRequest
-	(*VersionResponse)(nil), // 2: main.VersionResponse
-	(*HumansRequest)(nil),   // 3: main.HumansRequest
-	(*HumansResponse)(nil),  // 4: main.HumansResponse
-	(*Human)(nil),           // 5: main.Human
-	(*HumanSocial)(nil),     // 6: main.HumanSocial
+	(ChangeType)(0),               // 0: main.ChangeType
+	(Gender)(0),                   // 1: main.Gender
+	(*VersionRequest)(nil),        // 2: main.VersionRequest
+	(*VersionResponse)(nil),       // 3: main.VersionResponse
+	(*HumansRequest)(nil),         // 4: main.HumansRequest
+	(*HumansResponse)(nil),        // 5: main.HumansResponse
+	(*GetHumanRequest)(nil),       // 6: main.GetHumanRequest
+	(*ListHumansRequest)(nil),     // 7: main.ListHumansRequest
+	(*ListHumansResponse)(nil),    // 8: main.ListHumansResponse
+	(*SearchHumansRequest)(nil),   // 9: main.SearchHumansRequest
+	(*SearchHumansResponse)(nil),  // 10: main.SearchHumansResponse
+	(*CreateHumanRequest)(nil),    // 11: main.CreateHumanRequest
+	(*UpdateHumanRequest)(nil),    // 12: main.UpdateHumanRequest
+	(*PublishHumanRequest)(nil),   // 13: main.PublishHumanRequest
+	(*DeleteHumanRequest)(nil),    // 14: main.DeleteHumanRequest
+	(*WatchHumansRequest)(nil),    // 15: main.WatchHumansRequest
+	(*HumanEvent)(nil),            // 16: main.HumanEvent
+	(*Human)(nil),                 // 17: main.Human
+	(*PartialDate)(nil),           // 18: main.PartialDate
+	(*HumanSocial)(nil),           // 19: main.HumanSocial
+	(*NameVariant)(nil),           // 20: main.NameVariant
+	(*Place)(nil),                 // 21: main.Place
+	(*HumanImages)(nil),           // 22: main.HumanImages
+	(*Image)(nil),                 // 23: main.Image
+	(*Work)(nil),                  // 24: main.Work
+	(*Award)(nil),                 // 25: main.Award
+	(*Source)(nil),                // 26: main.Source
+	(*fieldmaskpb.FieldMask)(nil), // 27: google.protobuf.FieldMask
+	(*timestamppb.Timestamp)(nil), // 28: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 29: google.protobuf.Empty
 }
 var file_api_proto_depIdxs = []int32{
-	5, // 0: main.HumansResponse.humans:type_name -> main.Human
-	6, // 1: main.Human.socials:type_name -> main.HumanSocial
-	0, // 2: main.Human.gender:type_name -> main.Gender
-	1, // 3: main.HumanService.Version:input_type -> main.VersionRequest
-	3, // 4: main.HumanService.Humans:input_type -> main.HumansRequest
-	2, // 5: main.HumanService.Version:output_type -> main.VersionResponse
-	4, // 6: main.HumanService.Humans:output_type -> main.HumansResponse
-	5, // [5:7] is the sub-list for method output_type
-	3, // [3:5] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	17, // 0: main.HumansResponse.humans:type_name -> main.Human
+	17, // 1: main.ListHumansResponse.humans:type_name -> main.Human
+	17, // 2: main.SearchHumansResponse.humans:type_name -> main.Human
+	17, // 3: main.CreateHumanRequest.human:type_name -> main.Human
+	17, // 4: main.UpdateHumanRequest.human:type_name -> main.Human
+	27, // 5: main.UpdateHumanRequest.update_mask:type_name -> google.protobuf.FieldMask
+	0,  // 6: main.HumanEvent.type:type_name -> main.ChangeType
+	17, // 7: main.HumanEvent.human:type_name -> main.Human
+	19, // 8: main.Human.socials:type_name -> main.HumanSocial
+	1,  // 9: main.Human.gender:type_name -> main.Gender
+	18, // 10: main.Human.dob_date:type_name -> main.PartialDate
+	18, // 11: main.Human.dod_date:type_name -> main.PartialDate
+	20, // 12: main.Human.names:type_name -> main.NameVariant
+	21, // 13: main.Human.birth_place:type_name -> main.Place
+	21, // 14: main.Human.based_in:type_name -> main.Place
+	22, // 15: main.Human.images:type_name -> main.HumanImages
+	28, // 16: main.Human.created_at:type_name -> google.protobuf.Timestamp
+	28, // 17: main.Human.updated_at:type_name -> google.protobuf.Timestamp
+	28, // 18: main.Human.published_at:type_name -> google.protobuf.Timestamp
+	24, // 19: main.Human.works:type_name -> main.Work
+	25, // 20: main.Human.awards:type_name -> main.Award
+	26, // 21: main.Human.sources:type_name -> main.Source
+	23, // 22: main.HumanImages.gallery:type_name -> main.Image
+	28, // 23: main.Image.uploaded_at:type_name -> google.protobuf.Timestamp
+	28, // 24: main.Source.accessed_at:type_name -> google.protobuf.Timestamp
+	2,  // 25: main.HumanService.Version:input_type -> main.VersionRequest
+	4,  // 26: main.HumanService.Humans:input_type -> main.HumansRequest
+	6,  // 27: main.HumanService.GetHuman:input_type -> main.GetHumanRequest
+	7,  // 28: main.HumanService.ListHumans:input_type -> main.ListHumansRequest
+	9,  // 29: main.HumanService.SearchHumans:input_type -> main.SearchHumansRequest
+	11, // 30: main.HumanService.CreateHuman:input_type -> main.CreateHumanRequest
+	12, // 31: main.HumanService.UpdateHuman:input_type -> main.UpdateHumanRequest
+	13, // 32: main.HumanService.PublishHuman:input_type -> main.PublishHumanRequest
+	15, // 33: main.HumanService.WatchHumans:input_type -> main.WatchHumansRequest
+	14, // 34: main.HumanService.DeleteHuman:input_type -> main.DeleteHumanRequest
+	3,  // 35: main.HumanService.Version:output_type -> main.VersionResponse
+	5,  // 36: main.HumanService.Humans:output_type -> main.HumansResponse
+	17, // 37: main.HumanService.GetHuman:output_type -> main.Human
+	8,  // 38: main.HumanService.ListHumans:output_type -> main.ListHumansResponse
+	10, // 39: main.HumanService.SearchHumans:output_type -> main.SearchHumansResponse
+	17, // 40: main.HumanService.CreateHuman:output_type -> main.Human
+	17, // 41: main.HumanService.UpdateHuman:output_type -> main.Human
+	17, // 42: main.HumanService.PublishHuman:output_type -> main.Human
+	16, // 43: main.HumanService.WatchHumans:output_type -> main.HumanEvent
+	29, // 44: main.HumanService.DeleteHuman:output_type -> google.protobuf.Empty
+	35, // [35:45] is the sub-list for method output_type
+	25, // [25:35] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_init() }
@@ -520,8 +2357,8 @@ func file_api_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_rawDesc), len(file_api_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
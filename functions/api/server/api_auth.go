@@ -0,0 +1,80 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"firebase.google.com/go/v4/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+// adminMethods are the methods of HumanService only admins can call.
+var adminMethods = map[string]bool{
+	HumanService_CreateHuman_FullMethodName:  true,
+	HumanService_UpdateHuman_FullMethodName:  true,
+	HumanService_PublishHuman_FullMethodName: true,
+	HumanService_DeleteHuman_FullMethodName:  true,
+}
+
+type adminKey struct{}
+
+// authInterceptor checks that calls to adminMethods are made with the Firebase ID token of an
+// admin, and attributes the changes they make to the admin. Anyone can call the other methods, but
+// admins that call them with their token get the humans as admins see them.
+func authInterceptor(authClient Authorizer) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		token, err := verifyMetadataToken(ctx, authClient)
+		if !adminMethods[info.FullMethod] {
+			if err == nil && IsAdmin(token) {
+				ctx = context.WithValue(ctx, adminKey{}, true)
+			}
+			return handler(ctx, req)
+		}
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		if !IsAdmin(token) {
+			return nil, status.Error(codes.PermissionDenied, "user is not an admin")
+		}
+		ctx = context.WithValue(ctx, adminKey{}, true)
+		return handler(humandao.WithAuthor(ctx, token.UID), req)
+	}
+}
+
+// calledByAdmin reports whether authInterceptor let the call through as an admin's.
+func calledByAdmin(ctx context.Context) bool {
+	admin, _ := ctx.Value(adminKey{}).(bool)
+	return admin
+}
+
+// responseHuman is the human as the caller gets to see it: all of it for admins, and the public
+// part of it for everyone else.
+func responseHuman(ctx context.Context, human humandao.Human) *Human {
+	if calledByAdmin(ctx) {
+		return convertHuman(human)
+	}
+	return publicHuman(human)
+}
+
+// verifyMetadataToken verifies the bearer token in the authorization metadata of a call, which
+// the gateway forwards from the Authorization header.
+func verifyMetadataToken(ctx context.Context, authClient Authorizer) (*auth.Token, error) {
+	const bearerPrefix = "Bearer "
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if authClient == nil || len(values) == 0 || !strings.HasPrefix(values[0], bearerPrefix) {
+		return nil, ErrNoAuthorization
+	}
+	token, err := authClient.VerifyIDToken(ctx, strings.TrimPrefix(values[0], bearerPrefix))
+	if err != nil {
+		return nil, fmt.Errorf("unable to verify token: %w", err)
+	}
+	return token, nil
+}
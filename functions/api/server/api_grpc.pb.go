@@ -11,6 +11,7 @@ import (
 	grpc "google.golang.org/grpc"
 	codes "google.golang.org/grpc/codes"
 	status "google.golang.org/grpc/status"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
 )
 
 // This is a compile-time assertion to ensure that this generated file
@@ -19,8 +20,16 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	HumanService_Version_FullMethodName = "/main.HumanService/Version"
-	HumanService_Humans_FullMethodName  = "/main.HumanService/Humans"
+	HumanService_Version_FullMethodName      = "/main.HumanService/Version"
+	HumanService_Humans_FullMethodName       = "/main.HumanService/Humans"
+	HumanService_GetHuman_FullMethodName     = "/main.HumanService/GetHuman"
+	HumanService_ListHumans_FullMethodName   = "/main.HumanService/ListHumans"
+	HumanService_SearchHumans_FullMethodName = "/main.HumanService/SearchHumans"
+	HumanService_CreateHuman_FullMethodName  = "/main.HumanService/CreateHuman"
+	HumanService_UpdateHuman_FullMethodName  = "/main.HumanService/UpdateHuman"
+	HumanService_PublishHuman_FullMethodName = "/main.HumanService/PublishHuman"
+	HumanService_WatchHumans_FullMethodName  = "/main.HumanService/WatchHumans"
+	HumanService_DeleteHuman_FullMethodName  = "/main.HumanService/DeleteHuman"
 )
 
 // HumanServiceClient is the client API for HumanService service.
@@ -28,7 +37,33 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type HumanServiceClient interface {
 	Version(ctx context.Context, in *VersionRequest, opts ...grpc.CallOption) (*VersionResponse, error)
+	// Deprecated: Do not use.
+	// Humans returns every published human. It is deprecated for ListHumans, which GET /v1/humans
+	// has served since it was paged, and which it calls until there are no more pages.
 	Humans(ctx context.Context, in *HumansRequest, opts ...grpc.CallOption) (*HumansResponse, error)
+	// GetHuman returns a published human by ID or by path, including a path the human had before
+	// being renamed.
+	GetHuman(ctx context.Context, in *GetHumanRequest, opts ...grpc.CallOption) (*Human, error)
+	// ListHumans returns a page of the published humans that match every filter of the request.
+	// GET /v1/humans was served by Humans, and returned every published human, before it was paged.
+	ListHumans(ctx context.Context, in *ListHumansRequest, opts ...grpc.CallOption) (*ListHumansResponse, error)
+	// SearchHumans returns a page of the published humans that match a query the same way the
+	// search on the website does, the best matches first.
+	SearchHumans(ctx context.Context, in *SearchHumansRequest, opts ...grpc.CallOption) (*SearchHumansResponse, error)
+	// CreateHuman adds a draft of a human, which isn't listed until it is published. Only admins can
+	// call it, and the methods below.
+	CreateHuman(ctx context.Context, in *CreateHumanRequest, opts ...grpc.CallOption) (*Human, error)
+	// UpdateHuman sets the fields of the update mask to those of the human. Over HTTP, the mask is
+	// the fields of the body when it isn't set.
+	UpdateHuman(ctx context.Context, in *UpdateHumanRequest, opts ...grpc.CallOption) (*Human, error)
+	// PublishHuman publishes a draft.
+	PublishHuman(ctx context.Context, in *PublishHumanRequest, opts ...grpc.CallOption) (*Human, error)
+	// WatchHumans sends the changes to the published humans as they happen. Over HTTP, they are
+	// server-sent events at /v1/humans:watch. The humans are as anyone sees them, without the
+	// fields only admins get.
+	WatchHumans(ctx context.Context, in *WatchHumansRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HumanEvent], error)
+	// DeleteHuman moves a human to the trash, where it can be restored from.
+	DeleteHuman(ctx context.Context, in *DeleteHumanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type humanServiceClient struct {
@@ -49,6 +84,7 @@ func (c *humanServiceClient) Version(ctx context.Context, in *VersionRequest, op
 	return out, nil
 }
 
+// Deprecated: Do not use.
 func (c *humanServiceClient) Humans(ctx context.Context, in *HumansRequest, opts ...grpc.CallOption) (*HumansResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(HumansResponse)
@@ -59,12 +95,127 @@ func (c *humanServiceClient) Humans(ctx context.Context, in *HumansRequest, opts
 	return out, nil
 }
 
+func (c *humanServiceClient) GetHuman(ctx context.Context, in *GetHumanRequest, opts ...grpc.CallOption) (*Human, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Human)
+	err := c.cc.Invoke(ctx, HumanService_GetHuman_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *humanServiceClient) ListHumans(ctx context.Context, in *ListHumansRequest, opts ...grpc.CallOption) (*ListHumansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHumansResponse)
+	err := c.cc.Invoke(ctx, HumanService_ListHumans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *humanServiceClient) SearchHumans(ctx context.Context, in *SearchHumansRequest, opts ...grpc.CallOption) (*SearchHumansResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchHumansResponse)
+	err := c.cc.Invoke(ctx, HumanService_SearchHumans_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *humanServiceClient) CreateHuman(ctx context.Context, in *CreateHumanRequest, opts ...grpc.CallOption) (*Human, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Human)
+	err := c.cc.Invoke(ctx, HumanService_CreateHuman_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *humanServiceClient) UpdateHuman(ctx context.Context, in *UpdateHumanRequest, opts ...grpc.CallOption) (*Human, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Human)
+	err := c.cc.Invoke(ctx, HumanService_UpdateHuman_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *humanServiceClient) PublishHuman(ctx context.Context, in *PublishHumanRequest, opts ...grpc.CallOption) (*Human, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Human)
+	err := c.cc.Invoke(ctx, HumanService_PublishHuman_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *humanServiceClient) WatchHumans(ctx context.Context, in *WatchHumansRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[HumanEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &HumanService_ServiceDesc.Streams[0], HumanService_WatchHumans_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchHumansRequest, HumanEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HumanService_WatchHumansClient = grpc.ServerStreamingClient[HumanEvent]
+
+func (c *humanServiceClient) DeleteHuman(ctx context.Context, in *DeleteHumanRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, HumanService_DeleteHuman_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // HumanServiceServer is the server API for HumanService service.
 // All implementations must embed UnimplementedHumanServiceServer
 // for forward compatibility.
 type HumanServiceServer interface {
 	Version(context.Context, *VersionRequest) (*VersionResponse, error)
+	// Deprecated: Do not use.
+	// Humans returns every published human. It is deprecated for ListHumans, which GET /v1/humans
+	// has served since it was paged, and which it calls until there are no more pages.
 	Humans(context.Context, *HumansRequest) (*HumansResponse, error)
+	// GetHuman returns a published human by ID or by path, including a path the human had before
+	// being renamed.
+	GetHuman(context.Context, *GetHumanRequest) (*Human, error)
+	// ListHumans returns a page of the published humans that match every filter of the request.
+	// GET /v1/humans was served by Humans, and returned every published human, before it was paged.
+	ListHumans(context.Context, *ListHumansRequest) (*ListHumansResponse, error)
+	// SearchHumans returns a page of the published humans that match a query the same way the
+	// search on the website does, the best matches first.
+	SearchHumans(context.Context, *SearchHumansRequest) (*SearchHumansResponse, error)
+	// CreateHuman adds a draft of a human, which isn't listed until it is published. Only admins can
+	// call it, and the methods below.
+	CreateHuman(context.Context, *CreateHumanRequest) (*Human, error)
+	// UpdateHuman sets the fields of the update mask to those of the human. Over HTTP, the mask is
+	// the fields of the body when it isn't set.
+	UpdateHuman(context.Context, *UpdateHumanRequest) (*Human, error)
+	// PublishHuman publishes a draft.
+	PublishHuman(context.Context, *PublishHumanRequest) (*Human, error)
+	// WatchHumans sends the changes to the published humans as they happen. Over HTTP, they are
+	// server-sent events at /v1/humans:watch. The humans are as anyone sees them, without the
+	// fields only admins get.
+	WatchHumans(*WatchHumansRequest, grpc.ServerStreamingServer[HumanEvent]) error
+	// DeleteHuman moves a human to the trash, where it can be restored from.
+	DeleteHuman(context.Context, *DeleteHumanRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedHumanServiceServer()
 }
 
@@ -81,6 +232,30 @@ func (UnimplementedHumanServiceServer) Version(context.Context, *VersionRequest)
 func (UnimplementedHumanServiceServer) Humans(context.Context, *HumansRequest) (*HumansResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Humans not implemented")
 }
+func (UnimplementedHumanServiceServer) GetHuman(context.Context, *GetHumanRequest) (*Human, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHuman not implemented")
+}
+func (UnimplementedHumanServiceServer) ListHumans(context.Context, *ListHumansRequest) (*ListHumansResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListHumans not implemented")
+}
+func (UnimplementedHumanServiceServer) SearchHumans(context.Context, *SearchHumansRequest) (*SearchHumansResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchHumans not implemented")
+}
+func (UnimplementedHumanServiceServer) CreateHuman(context.Context, *CreateHumanRequest) (*Human, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateHuman not implemented")
+}
+func (UnimplementedHumanServiceServer) UpdateHuman(context.Context, *UpdateHumanRequest) (*Human, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateHuman not implemented")
+}
+func (UnimplementedHumanServiceServer) PublishHuman(context.Context, *PublishHumanRequest) (*Human, error) {
+	return nil, status.Error(codes.Unimplemented, "method PublishHuman not implemented")
+}
+func (UnimplementedHumanServiceServer) WatchHumans(*WatchHumansRequest, grpc.ServerStreamingServer[HumanEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchHumans not implemented")
+}
+func (UnimplementedHumanServiceServer) DeleteHuman(context.Context, *DeleteHumanRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteHuman not implemented")
+}
 func (UnimplementedHumanServiceServer) mustEmbedUnimplementedHumanServiceServer() {}
 func (UnimplementedHumanServiceServer) testEmbeddedByValue()                      {}
 
@@ -138,6 +313,143 @@ func _HumanService_Humans_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _HumanService_GetHuman_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHumanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HumanServiceServer).GetHuman(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HumanService_GetHuman_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HumanServiceServer).GetHuman(ctx, req.(*GetHumanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HumanService_ListHumans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHumansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HumanServiceServer).ListHumans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HumanService_ListHumans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HumanServiceServer).ListHumans(ctx, req.(*ListHumansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HumanService_SearchHumans_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchHumansRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HumanServiceServer).SearchHumans(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HumanService_SearchHumans_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HumanServiceServer).SearchHumans(ctx, req.(*SearchHumansRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HumanService_CreateHuman_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateHumanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HumanServiceServer).CreateHuman(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HumanService_CreateHuman_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HumanServiceServer).CreateHuman(ctx, req.(*CreateHumanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HumanService_UpdateHuman_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateHumanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HumanServiceServer).UpdateHuman(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HumanService_UpdateHuman_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HumanServiceServer).UpdateHuman(ctx, req.(*UpdateHumanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HumanService_PublishHuman_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublishHumanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HumanServiceServer).PublishHuman(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HumanService_PublishHuman_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HumanServiceServer).PublishHuman(ctx, req.(*PublishHumanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _HumanService_WatchHumans_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchHumansRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(HumanServiceServer).WatchHumans(m, &grpc.GenericServerStream[WatchHumansRequest, HumanEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type HumanService_WatchHumansServer = grpc.ServerStreamingServer[HumanEvent]
+
+func _HumanService_DeleteHuman_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteHumanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(HumanServiceServer).DeleteHuman(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: HumanService_DeleteHuman_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(HumanServiceServer).DeleteHuman(ctx, req.(*DeleteHumanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // HumanService_ServiceDesc is the grpc.ServiceDesc for HumanService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,7 +465,41 @@ var HumanService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Humans",
 			Handler:    _HumanService_Humans_Handler,
 		},
+		{
+			MethodName: "GetHuman",
+			Handler:    _HumanService_GetHuman_Handler,
+		},
+		{
+			MethodName: "ListHumans",
+			Handler:    _HumanService_ListHumans_Handler,
+		},
+		{
+			MethodName: "SearchHumans",
+			Handler:    _HumanService_SearchHumans_Handler,
+		},
+		{
+			MethodName: "CreateHuman",
+			Handler:    _HumanService_CreateHuman_Handler,
+		},
+		{
+			MethodName: "UpdateHuman",
+			Handler:    _HumanService_UpdateHuman_Handler,
+		},
+		{
+			MethodName: "PublishHuman",
+			Handler:    _HumanService_PublishHuman_Handler,
+		},
+		{
+			MethodName: "DeleteHuman",
+			Handler:    _HumanService_DeleteHuman_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchHumans",
+			Handler:       _HumanService_WatchHumans_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api.proto",
 }
@@ -0,0 +1,302 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+const (
+	// listPageSize is how many humans GET /v1/humans returned before it was paged, so the clients
+	// that don't page yet still get as many as they used to.
+	listPageSize   = 500
+	searchPageSize = 50
+	maxPageSize    = 500
+)
+
+// Humans returns every published human, for the clients of the RPC GET /v1/humans was served by
+// before ListHumans paged it.
+//
+// Deprecated: use ListHumans.
+func (s *HumanServer) Humans(ctx context.Context, req *HumansRequest) (*HumansResponse, error) {
+	var humans []*Human
+	list := &ListHumansRequest{PageSize: maxPageSize}
+	for {
+		resp, err := s.ListHumans(ctx, list)
+		if err != nil {
+			return nil, err
+		}
+		humans = append(humans, resp.GetHumans()...)
+		if resp.GetNextPageToken() == "" {
+			return &HumansResponse{Humans: humans}, nil
+		}
+		list.PageToken = resp.GetNextPageToken()
+	}
+}
+
+// GetHuman returns a published human by ID, or by its path.
+func (s *HumanServer) GetHuman(ctx context.Context, req *GetHumanRequest) (*Human, error) {
+	human, err := s.findHuman(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if human.Draft {
+		return nil, status.Errorf(codes.NotFound, "%v: %v", humandao.ErrHumanNotFound, req.GetId())
+	}
+	return responseHuman(ctx, human), nil
+}
+
+// findHuman finds a human by ID or by path, drafts included.
+func (s *HumanServer) findHuman(ctx context.Context, id string) (humandao.Human, error) {
+	if id == "" {
+		return humandao.Human{}, status.Error(codes.InvalidArgument, "id must be provided")
+	}
+	human, err := s.humanDAO.Human(ctx, humandao.HumanInput{HumanID: id})
+	if errors.Is(err, humandao.ErrHumanNotFound) {
+		human, err = s.humanDAO.Human(ctx, humandao.HumanInput{Path: id})
+	}
+	if errors.Is(err, humandao.ErrHumanNotFound) {
+		return humandao.Human{}, status.Errorf(codes.NotFound, "%v: %v", humandao.ErrHumanNotFound, id)
+	}
+	if err != nil {
+		s.logger.Error().Err(err).Str("id", id).Msg("unable to get human")
+		return humandao.Human{}, status.Error(codes.Internal, "unable to get human")
+	}
+	return human, nil
+}
+
+// ListHumans returns a page of the published humans that match every filter of the request.
+func (s *HumanServer) ListHumans(ctx context.Context, req *ListHumansRequest) (*ListHumansResponse, error) {
+	order, err := parseOrderBy(req.GetOrderBy())
+	if err != nil {
+		return nil, err
+	}
+	filters, err := s.listFilters(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	humans := humandao.ApplyFilters(s.html.publishedHumans(), filters...)
+	page, next, err := paginate(req, humans, humanCursor, order, cmp.Or(req.GetPageSize(), listPageSize), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &ListHumansResponse{
+		Humans: convertSlice(page, func(human humandao.Human) *Human {
+			return responseHuman(ctx, human)
+		}),
+		NextPageToken: next,
+		TotalSize:     int32(len(humans)),
+	}, nil
+}
+
+// listFilters maps the filters of the request to the filters of humandao.
+func (s *HumanServer) listFilters(ctx context.Context, req *ListHumansRequest) ([]humandao.FilterOpt, error) {
+	var filters []humandao.FilterOpt
+	if len(req.GetTags()) > 0 {
+		taxonomy, err := s.html.getTaxonomy(ctx)
+		if err != nil {
+			s.logger.Error().Err(err).Msg("unable to get tags")
+			return nil, status.Error(codes.Internal, "unable to get tags")
+		}
+		filters = append(filters, taxonomy.ByTags(req.GetTags()...))
+	}
+	for _, e := range req.GetEthnicity() {
+		filters = append(filters, humandao.ByEthnicity(e))
+	}
+	if req.GetGender() != "" {
+		gender := humandao.Gender(strings.ToLower(req.GetGender()))
+		if _, ok := humandao.ValidGenders[gender]; !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "%v: %v", humandao.ErrInvalidGender, req.GetGender())
+		}
+		filters = append(filters, humandao.ByGender(gender))
+	}
+	// a partial date covers all of it, the same way it does for /humans
+	if req.GetDobBefore() != "" {
+		date, err := humandao.ParsePartialDate(req.GetDobBefore())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid dob_before: %v", err)
+		}
+		filters = append(filters, humandao.ByAgeOlderThan(date.Earliest()))
+	}
+	if req.GetDobAfter() != "" {
+		date, err := humandao.ParsePartialDate(req.GetDobAfter())
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid dob_after: %v", err)
+		}
+		filters = append(filters, humandao.ByAgeYoungerThan(date.Latest()))
+	}
+	if req.GetName() != "" {
+		filters = append(filters, humandao.ByName(req.GetName(), req.GetIncludeAliases()))
+	}
+	return filters, nil
+}
+
+// parseOrderBy returns how to sort humans for an order_by, breaking ties by ID so pages are stable.
+func parseOrderBy(orderBy string) (func(a, b pageCursor) int, error) {
+	field, direction, _ := strings.Cut(strings.TrimSpace(orderBy), " ")
+	if field == "" {
+		field, direction = "created_at", "desc"
+	}
+	var (
+		compare func(a, b pageCursor) int
+		// unknown sorts the humans the field isn't known for last, in either direction
+		unknown = func(a, b pageCursor) int { return 0 }
+	)
+	switch field {
+	case "name":
+		compare = func(a, b pageCursor) int { return cmp.Compare(a.Name, b.Name) }
+	case "created_at":
+		compare = func(a, b pageCursor) int { return a.CreatedAt.Compare(b.CreatedAt) }
+	case "views":
+		compare = func(a, b pageCursor) int { return cmp.Compare(a.Views, b.Views) }
+	case "dob":
+		// partial dates sort as strings
+		compare = func(a, b pageCursor) int { return cmp.Compare(a.DOB, b.DOB) }
+		unknown = func(a, b pageCursor) int {
+			switch {
+			case a.DOB == "" && b.DOB != "":
+				return 1
+			case a.DOB != "" && b.DOB == "":
+				return -1
+			}
+			return 0
+		}
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "order_by must be one of name, created_at, views or dob, not %q", field)
+	}
+	switch strings.TrimSpace(direction) {
+	case "", "asc":
+	case "desc":
+		ascending := compare
+		compare = func(a, b pageCursor) int { return ascending(b, a) }
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "order_by can only be followed by asc or desc, not %q", direction)
+	}
+	return func(a, b pageCursor) int {
+		return cmp.Or(unknown(a, b), compare(a, b), cmp.Compare(a.ID, b.ID))
+	}, nil
+}
+
+// SearchHumans returns a page of the published humans that match the query, the best matches first.
+func (s *HumanServer) SearchHumans(ctx context.Context, req *SearchHumansRequest) (*SearchHumansResponse, error) {
+	query := strings.TrimSpace(req.GetQuery())
+	if query == "" {
+		return nil, status.Error(codes.InvalidArgument, "query must be provided")
+	}
+
+	scores, err := s.html.searchScores(query)
+	if err != nil {
+		s.logger.Error().Err(err).Str("query", query).Msg("unable to search humans")
+		return nil, status.Error(codes.Internal, "unable to search humans")
+	}
+	humans := humandao.ApplyFilters(s.html.publishedHumans(), humandao.ByIDs(slices.Collect(maps.Keys(scores))...))
+
+	cursor := func(human humandao.Human) pageCursor {
+		return pageCursor{Score: scores[human.ID], ID: human.ID}
+	}
+	// the best matches first
+	order := func(a, b pageCursor) int { return cmp.Or(cmp.Compare(b.Score, a.Score), cmp.Compare(a.ID, b.ID)) }
+	page, next, err := paginate(req, humans, cursor, order, cmp.Or(req.GetPageSize(), searchPageSize), req.GetPageToken())
+	if err != nil {
+		return nil, err
+	}
+	return &SearchHumansResponse{
+		Humans: convertSlice(page, func(human humandao.Human) *Human {
+			return responseHuman(ctx, human)
+		}),
+		NextPageToken: next,
+		TotalSize:     int32(len(humans)),
+	}, nil
+}
+
+// pageToken is what a next_page_token points at: the last human of the previous page, and a hash
+// of the request it was handed out for, so it can't be used with other filters.
+type pageToken struct {
+	Request uint64     `json:"r"`
+	Last    pageCursor `json:"l"`
+}
+
+// pageCursor is a human as far as the order of a page goes: the fields it can be ordered by, how
+// well it matched a search, and its ID to break ties. The next page starts right after the human
+// of the cursor, so humans added or removed since don't shift it.
+type pageCursor struct {
+	Name      string               `json:"n,omitempty"`
+	CreatedAt time.Time            `json:"c,omitzero"`
+	Views     int64                `json:"v,omitempty"`
+	DOB       humandao.PartialDate `json:"d,omitempty"`
+	Score     float64              `json:"s,omitempty"`
+	ID        string               `json:"id"`
+}
+
+func humanCursor(human humandao.Human) pageCursor {
+	return pageCursor{Name: human.Name, CreatedAt: human.CreatedAt, Views: human.Views, DOB: human.DOB, ID: human.ID}
+}
+
+// requestHash hashes the request without its page size and token, which can change from page to
+// page.
+func requestHash(req proto.Message) uint64 {
+	req = proto.Clone(req)
+	fields := req.ProtoReflect().Descriptor().Fields()
+	for _, name := range []protoreflect.Name{"page_size", "page_token"} {
+		if field := fields.ByName(name); field != nil {
+			req.ProtoReflect().Clear(field)
+		}
+	}
+	// a message of strings and numbers always marshals
+	raw, _ := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	hash := fnv.New64a()
+	_, _ = hash.Write(raw)
+	return hash.Sum64()
+}
+
+// paginate sorts the humans by order and returns the page the token points at, along with the
+// token of the page after it, which is empty on the last page. size is the page_size, or the default
+// when it isn't set.
+func paginate(req proto.Message, humans []humandao.Human, cursor func(humandao.Human) pageCursor, order func(a, b pageCursor) int, size int32, token string) ([]humandao.Human, string, error) {
+	switch {
+	case size < 0:
+		return nil, "", status.Error(codes.InvalidArgument, "page_size can't be negative")
+	case size > maxPageSize:
+		size = maxPageSize
+	}
+
+	slices.SortStableFunc(humans, func(a, b humandao.Human) int { return order(cursor(a), cursor(b)) })
+	hash := requestHash(req)
+	start := 0
+	if token != "" {
+		var previous pageToken
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		if err == nil {
+			err = json.Unmarshal(raw, &previous)
+		}
+		if err != nil || previous.Request != hash || previous.Last.ID == "" {
+			return nil, "", status.Errorf(codes.InvalidArgument, "%v: not a token for this request", humandao.ErrInvalidPageToken)
+		}
+		// the page starts after the last human of the previous one, wherever that is now
+		start = sort.Search(len(humans), func(i int) bool { return order(previous.Last, cursor(humans[i])) < 0 })
+	}
+
+	end := min(start+int(size), len(humans))
+	if end == len(humans) {
+		return humans[start:end], "", nil
+	}
+	// a token is a number and a few strings, which always marshal
+	raw, _ := json.Marshal(pageToken{Request: hash, Last: cursor(humans[end-1])})
+	return humans[start:end], base64.RawURLEncoding.EncodeToString(raw), nil
+}
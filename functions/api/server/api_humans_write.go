@@ -0,0 +1,226 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
+)
+
+// CreateHuman adds a draft of a human, attributed to the admin authInterceptor let through.
+func (s *HumanServer) CreateHuman(ctx context.Context, req *CreateHumanRequest) (*Human, error) {
+	human, err := humanFromProto(req.GetHuman())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	created, err := s.humanDAO.AddHuman(ctx, humandao.AddHumanInput{
+		Name:        strings.TrimSpace(human.Name),
+		Aliases:     human.Aliases,
+		Names:       human.Names,
+		DOB:         human.DOB,
+		DOD:         human.DOD,
+		Ethnicity:   human.Ethnicity,
+		Description: strings.TrimSpace(human.Description),
+		BirthPlace:  human.BirthPlace,
+		BasedIn:     human.BasedIn,
+		Website:     strings.TrimSpace(human.Socials.Website),
+		Twitter:     strings.TrimSpace(human.Socials.X),
+		Instagram:   strings.TrimSpace(human.Socials.Instagram),
+		IMDB:        strings.TrimSpace(human.Socials.IMDB),
+		YouTube:     strings.TrimSpace(human.Socials.YouTube),
+		Tags:        human.Tags,
+		Draft:       true,
+		AIGenerated: human.AIGenerated,
+		CreatedBy:   humandao.AuthorFromContext(ctx),
+		Gender:      human.Gender,
+		Sources:     human.Sources,
+		Works:       human.Works,
+		Awards:      human.Awards,
+	})
+	if err != nil {
+		return nil, s.writeError(err, human.Name, "unable to create human")
+	}
+	if err := s.html.updateIndex(created); err != nil {
+		s.logger.Error().Err(err).Str("id", created.ID).Msg("unable to update index")
+	}
+	return convertHuman(created), nil
+}
+
+// UpdateHuman sets the fields of the update mask, leaving the others as they are.
+func (s *HumanServer) UpdateHuman(ctx context.Context, req *UpdateHumanRequest) (*Human, error) {
+	current, err := s.findHuman(ctx, req.GetHuman().GetId())
+	if err != nil {
+		return nil, err
+	}
+	patch, err := humanPatch(current, req.GetHuman(), req.GetUpdateMask())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.humanDAO.PatchHuman(ctx, current.ID, patch)
+	if err != nil {
+		return nil, s.writeError(err, current.ID, "unable to update human")
+	}
+	if err := s.html.updateIndex(updated); err != nil {
+		s.logger.Error().Err(err).Str("id", updated.ID).Msg("unable to update index")
+	}
+	return convertHuman(updated), nil
+}
+
+// PublishHuman publishes a draft, which publishing again leaves published.
+func (s *HumanServer) PublishHuman(ctx context.Context, req *PublishHumanRequest) (*Human, error) {
+	human, err := s.findHuman(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.humanDAO.Publish(ctx, humandao.PublishInput{HumanID: human.ID, UserID: humandao.AuthorFromContext(ctx)}); err != nil {
+		return nil, s.writeError(err, human.ID, "unable to publish human")
+	}
+
+	// the index needs the human as it was published
+	human, err = s.humanDAO.Human(ctx, humandao.HumanInput{HumanID: human.ID})
+	if err != nil {
+		return nil, s.writeError(err, req.GetId(), "unable to get human")
+	}
+	if err := s.html.updateIndex(human); err != nil {
+		s.logger.Error().Err(err).Str("id", human.ID).Msg("unable to update index")
+	}
+	return convertHuman(human), nil
+}
+
+// DeleteHuman moves a human to the trash.
+func (s *HumanServer) DeleteHuman(ctx context.Context, req *DeleteHumanRequest) (*emptypb.Empty, error) {
+	human, err := s.findHuman(ctx, req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.humanDAO.Delete(ctx, humandao.DeleteInput{HumanID: human.ID, UserID: humandao.AuthorFromContext(ctx)}); err != nil {
+		return nil, s.writeError(err, human.ID, "unable to delete human")
+	}
+	if err := s.html.deleteFromIndex(human.ID); err != nil {
+		s.logger.Error().Err(err).Str("id", human.ID).Msg("unable to delete from index")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// writeError maps the errors of changing a human to gRPC errors, with the validation errors the
+// HTML forms turn into bad requests being invalid arguments.
+func (s *HumanServer) writeError(err error, id string, msg string) error {
+	var conflict *humandao.ConflictError
+	switch {
+	case humandao.IsInvalidHuman(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, humandao.ErrHumanAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, humandao.ErrHumanNotFound):
+		return status.Errorf(codes.NotFound, "%v: %v", humandao.ErrHumanNotFound, id)
+	case errors.As(err, &conflict):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	s.logger.Error().Err(err).Str("id", id).Msg(msg)
+	return status.Error(codes.Internal, msg)
+}
+
+// humanPatch returns the patch that sets the fields of the mask to those of human. The socials
+// can be set one at a time, on top of the current ones. The other fields are set by the server,
+// or by the other methods.
+func humanPatch(current humandao.Human, human *Human, mask *fieldmaskpb.FieldMask) (humandao.HumanPatch, error) {
+	if len(mask.GetPaths()) == 0 {
+		return humandao.HumanPatch{}, status.Error(codes.InvalidArgument, "update_mask must list the fields to update")
+	}
+	from, err := humanFromProto(human)
+	if err != nil {
+		return humandao.HumanPatch{}, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	var (
+		patch   humandao.HumanPatch
+		socials = current.Socials
+	)
+	for _, path := range mask.GetPaths() {
+		// the gateway masks the parts of the places and dates that are in the body, which are set
+		// together
+		field, _, _ := strings.Cut(path, ".")
+		if field != "socials" {
+			path = field
+		}
+
+		switch path {
+		case "name":
+			name := strings.TrimSpace(from.Name)
+			patch.Name = &name
+		case "aliases":
+			patch.Aliases = &from.Aliases
+		case "names":
+			patch.Names = &from.Names
+		case "dob", "dob_date":
+			patch.DOB = &from.DOB
+		case "dod", "dod_date":
+			patch.DOD = &from.DOD
+		case "tags":
+			patch.Tags = &from.Tags
+		case "ethnicity":
+			patch.Ethnicity = &from.Ethnicity
+		case "birth_place":
+			// an empty place clears it
+			birthPlace := place.Place{}
+			if from.BirthPlace != nil {
+				birthPlace = *from.BirthPlace
+			}
+			patch.BirthPlace = &birthPlace
+		case "based_in":
+			patch.BasedIn = &from.BasedIn
+		case "description":
+			description := strings.TrimSpace(from.Description)
+			patch.Description = &description
+		case "gender":
+			patch.Gender = &from.Gender
+		case "ai_generated":
+			patch.AIGenerated = &from.AIGenerated
+		case "works":
+			patch.Works = &from.Works
+		case "awards":
+			patch.Awards = &from.Awards
+		case "sources":
+			patch.Sources = &from.Sources
+		case "socials":
+			socials = from.Socials
+			patch.Socials = &socials
+		case "socials.instagram":
+			socials.Instagram = from.Socials.Instagram
+			patch.Socials = &socials
+		case "socials.x":
+			socials.X = from.Socials.X
+			patch.Socials = &socials
+		case "socials.website":
+			socials.Website = from.Socials.Website
+			patch.Socials = &socials
+		case "socials.imdb":
+			socials.IMDB = from.Socials.IMDB
+			patch.Socials = &socials
+		case "socials.youtube":
+			socials.YouTube = from.Socials.YouTube
+			patch.Socials = &socials
+		default:
+			return humandao.HumanPatch{}, status.Errorf(codes.InvalidArgument, "%q can't be updated", path)
+		}
+	}
+	if patch.Socials != nil {
+		*patch.Socials = humandao.Socials{
+			IMDB:      strings.TrimSpace(socials.IMDB),
+			Website:   strings.TrimSpace(socials.Website),
+			X:         strings.TrimSpace(socials.X),
+			YouTube:   strings.TrimSpace(socials.YouTube),
+			Instagram: strings.TrimSpace(socials.Instagram),
+		}
+	}
+	return patch, nil
+}
@@ -0,0 +1,134 @@
+package server
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// watchKeepAlive is how often a watch over HTTP sends a comment while there are no events, so
+// proxies don't close it for being idle.
+const watchKeepAlive = 15 * time.Second
+
+// WatchHumans sends the changes to the published humans as they happen.
+func (s *HumanServer) WatchHumans(req *WatchHumansRequest, stream grpc.ServerStreamingServer[HumanEvent]) error {
+	return s.html.feed.watch(stream.Context(), req.GetResumeToken(), req.GetSkipExisting(), stream.Send)
+}
+
+// HandlerWatchHumans serves WatchHumans as server-sent events. Each has the type of the event in
+// lower case as its event, its resume token as its id, and the event as JSON as its data.
+// Browsers resume with the Last-Event-ID header they send when reconnecting, and other clients
+// with resume_token.
+func (s *HumanServer) HandlerWatchHumans(w http.ResponseWriter, r *http.Request) {
+	var (
+		ctx          = r.Context()
+		query        = r.URL.Query()
+		token        = cmp.Or(query.Get("resume_token"), r.Header.Get("Last-Event-ID"))
+		skipExisting bool
+		err          error
+	)
+	if query.Has("skip_existing") {
+		skipExisting, err = strconv.ParseBool(query.Get("skip_existing"))
+		if err != nil {
+			s.watchError(w, status.Errorf(codes.InvalidArgument, "invalid skip_existing: %v", err))
+			return
+		}
+	}
+
+	var (
+		rc      = http.NewResponseController(w)
+		lock    sync.Mutex
+		started bool
+		done    = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+	write := func(message string) error {
+		lock.Lock()
+		defer lock.Unlock()
+		if _, err := fmt.Fprint(w, message); err != nil {
+			return err
+		}
+		return rc.Flush()
+	}
+	keepAlive := func() {
+		defer wg.Done()
+		ticker := time.NewTicker(watchKeepAlive)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := write(": keep-alive\n\n"); err != nil {
+					return
+				}
+			case <-done:
+				return
+			}
+		}
+	}
+	defer func() {
+		close(done)
+		wg.Wait()
+	}()
+
+	err = s.html.feed.watch(ctx, token, skipExisting, func(event *HumanEvent) error {
+		// the response only starts with the first event, so a watch that can't start is an error
+		if !started {
+			started = true
+			// the watch outlives the write timeout of the server
+			if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+				s.logger.Error().Err(err).Msg("unable to clear write deadline")
+			}
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+			w.WriteHeader(http.StatusOK)
+			wg.Add(1)
+			go keepAlive()
+		}
+		data, err := protojson.Marshal(event)
+		if err != nil {
+			return status.Errorf(codes.Internal, "unable to marshal event: %v", err)
+		}
+		message := fmt.Sprintf("event: %v\ndata: %s\n\n", strings.ToLower(event.GetType().String()), data)
+		if event.GetResumeToken() != "" {
+			message = fmt.Sprintf("id: %v\n", event.GetResumeToken()) + message
+		}
+		return write(message)
+	})
+	switch {
+	case ctx.Err() != nil:
+		// the client went away
+	case !started:
+		s.watchError(w, err)
+	default:
+		// a status is a code, a message and no details, which always marshal
+		data, _ := protojson.Marshal(status.Convert(err).Proto())
+		if err := write(fmt.Sprintf("event: error\ndata: %s\n\n", data)); err != nil {
+			s.logger.Error().Err(err).Msg("unable to send watch error")
+		}
+	}
+}
+
+// watchError responds with an error the way the gateway does.
+func (s *HumanServer) watchError(w http.ResponseWriter, err error) {
+	data, marshalErr := protojson.Marshal(status.Convert(err).Proto())
+	if marshalErr != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(runtime.HTTPStatusFromCode(status.Code(err)))
+	if _, err := w.Write(data); err != nil {
+		s.logger.Error().Err(err).Msg("unable to write watch error")
+	}
+}
@@ -0,0 +1,296 @@
+package server
+
+import (
+	"cmp"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
+)
+
+// publicHuman is convertHuman without who created, published and uploaded to the human, where its
+// images are stored, or its version, which only admins get to see.
+func publicHuman(human humandao.Human) *Human {
+	public := convertHuman(human)
+	public.CreatedBy = ""
+	public.PublishedBy = ""
+	public.Version = 0
+	for _, image := range public.GetImages().GetGallery() {
+		image.Object = ""
+		image.ThumbnailObject = ""
+		image.UploadedBy = ""
+	}
+	return public
+}
+
+// convertHuman is the proto of a human. It has every field of the human except the deprecated
+// FeaturedImage, which is image unless the human has images, and those of humans in the trash,
+// which the API never returns.
+func convertHuman(human humandao.Human) *Human {
+	return &Human{
+		Id:          human.ID,
+		Name:        human.Name,
+		Path:        human.Path,
+		Dob:         human.DOB.String(),
+		Dod:         human.DOD.String(),
+		Tags:        human.Tags,
+		Ethnicity:   human.Ethnicity,
+		Image:       cmp.Or(human.Images.Featured, human.FeaturedImage),
+		Description: human.Description,
+		Socials: &HumanSocial{
+			Instagram: human.Socials.Instagram,
+			X:         human.Socials.X,
+			Website:   human.Socials.Website,
+			Imdb:      human.Socials.IMDB,
+			Youtube:   human.Socials.YouTube,
+		},
+		Gender:        convertGender(human.Gender),
+		DobDate:       convertPartialDate(human.DOB),
+		DodDate:       convertPartialDate(human.DOD),
+		Aliases:       human.Aliases,
+		Names:         convertSlice(human.Names, convertNameVariant),
+		PreviousPaths: human.PreviousPaths,
+		BirthPlace:    convertPlace(human.BirthPlace),
+		BasedIn: convertSlice(human.BasedIn, func(p place.Place) *Place {
+			return convertPlace(&p)
+		}),
+		BirthLocation: human.BirthLocation,
+		Locations:     human.Location,
+		InfluencedBy:  human.InfluencedBy,
+		Images:        convertImages(human.Images),
+		Draft:         human.Draft,
+		AiGenerated:   human.AIGenerated,
+		CreatedAt:     convertTime(human.CreatedAt),
+		CreatedBy:     human.CreatedBy,
+		UpdatedAt:     convertTime(human.UpdatedAt),
+		Version:       human.Version,
+		PublishedAt:   convertTime(human.PublishedAt),
+		PublishedBy:   human.PublishedBy,
+		Views:         human.Views,
+		Similar:       human.Similar,
+		Works:         convertSlice(human.Works, convertWork),
+		Awards:        convertSlice(human.Awards, convertAward),
+		Sources:       convertSlice(human.Sources, convertSource),
+	}
+}
+
+// humanFromProto is the human of a proto, the reverse of convertHuman. Its dates are dob and dod,
+// or dob_date and dod_date when those aren't set.
+func humanFromProto(human *Human) (humandao.Human, error) {
+	dob, err := humanDate(human.GetDob(), human.GetDobDate())
+	if err != nil {
+		return humandao.Human{}, err
+	}
+	dod, err := humanDate(human.GetDod(), human.GetDodDate())
+	if err != nil {
+		return humandao.Human{}, err
+	}
+
+	var birthPlace *place.Place
+	if human.GetBirthPlace() != nil {
+		p := placeFromProto(human.GetBirthPlace())
+		birthPlace = &p
+	}
+	return humandao.Human{
+		ID:            human.GetId(),
+		Name:          human.GetName(),
+		Aliases:       human.GetAliases(),
+		Names:         convertSlice(human.GetNames(), nameVariantFromProto),
+		Path:          human.GetPath(),
+		PreviousPaths: human.GetPreviousPaths(),
+		DOB:           dob,
+		DOD:           dod,
+		Tags:          human.GetTags(),
+		Ethnicity:     human.GetEthnicity(),
+		BirthPlace:    birthPlace,
+		BasedIn:       convertSlice(human.GetBasedIn(), placeFromProto),
+		BirthLocation: human.GetBirthLocation(),
+		Location:      human.GetLocations(),
+		InfluencedBy:  human.GetInfluencedBy(),
+		Draft:         human.GetDraft(),
+		AIGenerated:   human.GetAiGenerated(),
+		Description:   human.GetDescription(),
+		CreatedAt:     timeFromProto(human.GetCreatedAt()),
+		CreatedBy:     human.GetCreatedBy(),
+		UpdatedAt:     timeFromProto(human.GetUpdatedAt()),
+		Version:       human.GetVersion(),
+		PublishedBy:   human.GetPublishedBy(),
+		PublishedAt:   timeFromProto(human.GetPublishedAt()),
+		Socials: humandao.Socials{
+			IMDB:      human.GetSocials().GetImdb(),
+			Website:   human.GetSocials().GetWebsite(),
+			X:         human.GetSocials().GetX(),
+			YouTube:   human.GetSocials().GetYoutube(),
+			Instagram: human.GetSocials().GetInstagram(),
+		},
+		Views:   human.GetViews(),
+		Gender:  genderFromProto(human.GetGender()),
+		Similar: human.GetSimilar(),
+		Images:  imagesFromProto(human.GetImages()),
+		Works:   convertSlice(human.GetWorks(), workFromProto),
+		Awards:  convertSlice(human.GetAwards(), awardFromProto),
+		Sources: convertSlice(human.GetSources(), sourceFromProto),
+	}, nil
+}
+
+// convertSlice converts every element of a slice, which is nil when there are none.
+func convertSlice[In, Out any](in []In, convert func(In) Out) []Out {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make([]Out, 0, len(in))
+	for _, element := range in {
+		out = append(out, convert(element))
+	}
+	return out
+}
+
+func convertPartialDate(date humandao.PartialDate) *PartialDate {
+	if date.Precision() == humandao.PrecisionNone {
+		return nil
+	}
+	year, month, day := date.Parts()
+	return &PartialDate{
+		Year:  int32(year),
+		Month: int32(month),
+		Day:   int32(day),
+	}
+}
+
+// humanDate parses a date that is either YYYY, YYYY-MM or YYYY-MM-DD, or by part when that isn't
+// set.
+func humanDate(date string, parts *PartialDate) (humandao.PartialDate, error) {
+	if date != "" || parts == nil {
+		return humandao.ParsePartialDate(date)
+	}
+	return humandao.ParsePartialDate(string(humandao.NewPartialDate(int(parts.GetYear()), time.Month(parts.GetMonth()), int(parts.GetDay()))))
+}
+
+// convertGender is GENDER_UNSPECIFIED for humans without a gender, or with one the proto doesn't
+// have.
+func convertGender(gender humandao.Gender) Gender {
+	return Gender(Gender_value[strings.ToUpper(string(gender))])
+}
+
+func genderFromProto(gender Gender) humandao.Gender {
+	if gender == Gender_GENDER_UNSPECIFIED {
+		return ""
+	}
+	return humandao.Gender(strings.ToLower(gender.String()))
+}
+
+func convertTime(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func timeFromProto(t *timestamppb.Timestamp) time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.AsTime()
+}
+
+func convertNameVariant(name humandao.NameVariant) *NameVariant {
+	return &NameVariant{Value: name.Value, Lang: name.Lang, Type: string(name.Type)}
+}
+
+func nameVariantFromProto(name *NameVariant) humandao.NameVariant {
+	return humandao.NameVariant{Value: name.GetValue(), Lang: name.GetLang(), Type: humandao.NameType(name.GetType())}
+}
+
+func convertPlace(p *place.Place) *Place {
+	if p == nil {
+		return nil
+	}
+	return &Place{City: p.City, Region: p.Region, Country: p.Country, Lat: p.Lat, Lng: p.Lng}
+}
+
+func placeFromProto(p *Place) place.Place {
+	return place.Place{City: p.GetCity(), Region: p.GetRegion(), Country: p.GetCountry(), Lat: p.GetLat(), Lng: p.GetLng()}
+}
+
+func convertImages(images humandao.Images) *HumanImages {
+	return &HumanImages{
+		Featured:   images.Featured,
+		Thumbnail:  images.Thumbnail,
+		FeaturedId: images.FeaturedID,
+		Gallery:    convertSlice(images.Gallery, convertImage),
+	}
+}
+
+func imagesFromProto(images *HumanImages) humandao.Images {
+	return humandao.Images{
+		Featured:   images.GetFeatured(),
+		Thumbnail:  images.GetThumbnail(),
+		FeaturedID: images.GetFeaturedId(),
+		Gallery:    convertSlice(images.GetGallery(), imageFromProto),
+	}
+}
+
+func convertImage(image humandao.Image) *Image {
+	return &Image{
+		Id:              image.ID,
+		Object:          image.Object,
+		ThumbnailObject: image.ThumbnailObject,
+		Url:             image.URL,
+		ThumbnailUrl:    image.ThumbnailURL,
+		Width:           int32(image.Width),
+		Height:          int32(image.Height),
+		SourceUrl:       image.SourceURL,
+		Author:          image.Author,
+		License:         image.License,
+		AiGenerated:     image.AIGenerated,
+		Caption:         image.Caption,
+		UploadedAt:      convertTime(image.UploadedAt),
+		UploadedBy:      image.UploadedBy,
+	}
+}
+
+func imageFromProto(image *Image) humandao.Image {
+	return humandao.Image{
+		ID:              image.GetId(),
+		Object:          image.GetObject(),
+		ThumbnailObject: image.GetThumbnailObject(),
+		URL:             image.GetUrl(),
+		ThumbnailURL:    image.GetThumbnailUrl(),
+		Width:           int(image.GetWidth()),
+		Height:          int(image.GetHeight()),
+		SourceURL:       image.GetSourceUrl(),
+		Author:          image.GetAuthor(),
+		License:         image.GetLicense(),
+		AIGenerated:     image.GetAiGenerated(),
+		Caption:         image.GetCaption(),
+		UploadedAt:      timeFromProto(image.GetUploadedAt()),
+		UploadedBy:      image.GetUploadedBy(),
+	}
+}
+
+func convertWork(work humandao.Work) *Work {
+	return &Work{Title: work.Title, Type: string(work.Type), Year: int32(work.Year), Credit: work.Credit, Url: work.URL}
+}
+
+func workFromProto(work *Work) humandao.Work {
+	return humandao.Work{Title: work.GetTitle(), Type: humandao.WorkType(work.GetType()), Year: int(work.GetYear()), Credit: work.GetCredit(), URL: work.GetUrl()}
+}
+
+func convertAward(award humandao.Award) *Award {
+	return &Award{Name: award.Name, Category: award.Category, Year: int32(award.Year), Result: string(award.Result), Source: award.Source}
+}
+
+func awardFromProto(award *Award) humandao.Award {
+	return humandao.Award{Name: award.GetName(), Category: award.GetCategory(), Year: int(award.GetYear()), Result: humandao.AwardResult(award.GetResult()), Source: award.GetSource()}
+}
+
+func convertSource(source humandao.Source) *Source {
+	return &Source{Url: source.URL, Title: source.Title, Publisher: source.Publisher, AccessedAt: convertTime(source.AccessedAt), Fields: source.Fields}
+}
+
+func sourceFromProto(source *Source) humandao.Source {
+	return humandao.Source{URL: source.GetUrl(), Title: source.GetTitle(), Publisher: source.GetPublisher(), AccessedAt: timeFromProto(source.GetAccessedAt()), Fields: source.GetFields()}
+}
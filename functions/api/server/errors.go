@@ -52,3 +52,10 @@ func NewNotFoundError(err error) ErrorResponse {
 		Err:    err,
 	}
 }
+
+func NewConflictError(err error) ErrorResponse {
+	return ErrorResponse{
+		Status: http.StatusConflict,
+		Err:    err,
+	}
+}
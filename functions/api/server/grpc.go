@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// newGRPCServer serves the human service along with server reflection and health checks, with
+// the logging and recovery the chi middleware gives the rest of the site.
+func newGRPCServer(logger zerolog.Logger, authClient Authorizer, humanServer HumanServiceServer) *grpc.Server {
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(loggingInterceptor(logger), recoveryInterceptor(logger), authInterceptor(authClient)),
+		grpc.ChainStreamInterceptor(loggingStreamInterceptor(logger), recoveryStreamInterceptor(logger)),
+	)
+	RegisterHumanServiceServer(grpcServer, humanServer)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(HumanService_ServiceDesc.ServiceName, grpc_health_v1.HealthCheckResponse_SERVING)
+	grpc_health_v1.RegisterHealthServer(grpcServer, healthServer)
+	reflection.Register(grpcServer)
+	return grpcServer
+}
+
+// inProcess reports whether a call was made by the gateway, whose requests httplog logs already.
+func inProcess(ctx context.Context) bool {
+	p, ok := peer.FromContext(ctx)
+	return ok && p.Addr != nil && p.Addr.Network() == "bufconn"
+}
+
+// logCall logs a call the way httplog logs a request, as an error if the server failed it.
+func logCall(logger zerolog.Logger, method string, start time.Time, err error) {
+	event := logger.Info()
+	switch status.Code(err) {
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		event = logger.Error().Err(err)
+	}
+	event.Str("method", method).Str("code", status.Code(err).String()).Dur("elapsed", time.Since(start)).Msg("grpc call")
+}
+
+func loggingInterceptor(logger zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if inProcess(ctx) {
+			return handler(ctx, req)
+		}
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(logger, info.FullMethod, start, err)
+		return resp, err
+	}
+}
+
+func loggingStreamInterceptor(logger zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if inProcess(ss.Context()) {
+			return handler(srv, ss)
+		}
+		start := time.Now()
+		err := handler(srv, ss)
+		logCall(logger, info.FullMethod, start, err)
+		return err
+	}
+}
+
+// recovered turns a panic into an internal error, logging it along with the stack the way
+// middleware.Recoverer does.
+func recovered(logger zerolog.Logger, method string, r any) error {
+	logger.Error().Str("method", method).Str("panic", fmt.Sprint(r)).Bytes("stack", debug.Stack()).Msg("grpc call panicked")
+	return status.Error(codes.Internal, "internal error")
+}
+
+func recoveryInterceptor(logger zerolog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				resp, err = nil, recovered(logger, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+func recoveryStreamInterceptor(logger zerolog.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = recovered(logger, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
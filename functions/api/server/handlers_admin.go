@@ -77,7 +77,7 @@ type HTMLResponseAdmin struct {
 // HumanFormFields holds helper data to populate the form to add a new human.
 type HumanFormFields struct {
 	Source      string
-	Ethnicities []ethnicity.Ethnicity
+	Ethnicities []ethnicity.Group
 	Tags        []string
 }
 
@@ -93,14 +93,16 @@ func (s *ServerHTML) HandlerAdmin(w http.ResponseWriter, r *http.Request) error
 		return NewForbiddenError(fmt.Errorf("user is not an admin"))
 	}
 
-	humans, err := s.humanDAO.ListHumans(r.Context(), humandao.ListHumansInput{
-		Limit:         1000,
-		IncludeDrafts: true,
-	})
+	humans, err := humandao.IterateHumans(r.Context(), s.humanDAO, humandao.ListHumansInput{IncludeDrafts: true}).GetAll()
 	if err != nil {
 		return NewInternalServerError(fmt.Errorf("unable to list humans: %w", err))
 	}
 
+	taxonomy, err := s.getTaxonomy(r.Context())
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+
 	var drafts []humandao.Human
 	for _, human := range humans {
 		if human.Draft {
@@ -112,8 +114,8 @@ func (s *ServerHTML) HandlerAdmin(w http.ResponseWriter, r *http.Request) error
 		Base:      getBase(s, false),
 		AdminName: token.Claims["name"].(string),
 		HumanFormFields: HumanFormFields{
-			Ethnicities: ethnicity.All,
-			Tags:        getTags(humans),
+			Ethnicities: ethnicity.Groups(),
+			Tags:        getTags(taxonomy),
 		},
 		Drafts: drafts,
 	}
@@ -151,27 +153,25 @@ func (s *ServerHTML) HandlerGenerate(w http.ResponseWriter, r *http.Request) err
 	logger.Info().Str("source", source).Any("addHumanRequest", addHumanRequest).Msg("generated response from xAI")
 
 	human := humandao.Human{
-		Name:        addHumanRequest.Name,
-		Gender:      humandao.Gender(addHumanRequest.Gender),
-		Ethnicity:   addHumanRequest.Ethnicity,
-		DOB:         addHumanRequest.DOB,
-		DOD:         addHumanRequest.DOD,
+		Name:      addHumanRequest.Name,
+		Gender:    humandao.Gender(addHumanRequest.Gender),
+		Ethnicity: addHumanRequest.Ethnicity,
+		// only prefills the form, so a date xAI got wrong is left for the admin to fix
+		DOB:         humandao.PartialDate(addHumanRequest.DOB),
+		DOD:         humandao.PartialDate(addHumanRequest.DOD),
 		Description: addHumanRequest.Description,
 	}
 
-	humans, err := s.humanDAO.ListHumans(ctx, humandao.ListHumansInput{
-		Limit:         1000,
-		IncludeDrafts: true,
-	})
+	taxonomy, err := s.getTaxonomy(ctx)
 	if err != nil {
-		return NewInternalServerError(fmt.Errorf("unable to list humans: %w", err))
+		return NewInternalServerError(err)
 	}
 
 	response := HTMLResponseAdmin{
 		HumanFormFields: HumanFormFields{
 			Source:      source,
-			Ethnicities: ethnicity.All,
-			Tags:        getTags(humans),
+			Ethnicities: ethnicity.Groups(),
+			Tags:        getTags(taxonomy),
 		},
 		Human: human,
 	}
@@ -240,6 +240,8 @@ func (s *ServerHTML) HandlerRefreshHumanIndex(w http.ResponseWriter, r *http.Req
 	if !found {
 		s.humans = append(s.humans, human)
 	}
+	s.ethnicityStats = nil
+	s.tagStats = nil
 
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte("Human index refreshed successfully"))
@@ -0,0 +1,85 @@
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+// AwardFacet is an award that can be picked to filter humans by.
+type AwardFacet struct {
+	Name string
+	Slug string
+}
+
+// getAwards returns the awards of the humans, once per slug, by the first name they are spelled with.
+func getAwards(humans []humandao.Human) []AwardFacet {
+	bySlug := make(map[string]AwardFacet)
+	for _, human := range humans {
+		for _, award := range human.Awards {
+			if _, ok := bySlug[award.Slug()]; !ok {
+				bySlug[award.Slug()] = AwardFacet{Name: award.Name, Slug: award.Slug()}
+			}
+		}
+	}
+	awards := make([]AwardFacet, 0, len(bySlug))
+	for _, award := range bySlug {
+		awards = append(awards, award)
+	}
+	sort.Slice(awards, func(i, j int) bool { return awards[i].Name < awards[j].Name })
+	return awards
+}
+
+type HTMLResponseAward struct {
+	Base
+	Name      string
+	Slug      string
+	Won       int
+	Nominated int
+	Laureates []humandao.Laureate
+}
+
+// HandlerAward lists everyone who won or was nominated for an award, in chronological order.
+func (s *ServerHTML) HandlerAward(w http.ResponseWriter, r *http.Request) error {
+	var (
+		token = s.parseOptionalToken(r)
+		admin = IsAdmin(token)
+		slug  = chi.URLParamFromCtx(r.Context(), "award")
+	)
+
+	s.lock.Lock()
+	humans := make([]humandao.Human, 0, len(s.humans))
+	for _, human := range s.humans {
+		if !human.Draft {
+			humans = append(humans, human)
+		}
+	}
+	s.lock.Unlock()
+
+	laureates := humandao.Laureates(humans, slug)
+	if len(laureates) == 0 {
+		return NewNotFoundError(fmt.Errorf("no one has the award %v", slug))
+	}
+
+	response := HTMLResponseAward{
+		Base:      getBase(s, admin),
+		Name:      laureates[0].Award.Name,
+		Slug:      laureates[0].Award.Slug(),
+		Laureates: laureates,
+	}
+	for _, laureate := range laureates {
+		if laureate.Award.Result == humandao.AwardWon {
+			response.Won++
+		} else {
+			response.Nominated++
+		}
+	}
+	if err := s.template.ExecuteTemplate(w, "awards-id.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute awards-id template")
+	}
+
+	return nil
+}
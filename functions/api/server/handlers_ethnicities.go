@@ -0,0 +1,272 @@
+package server
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/raymonstah/asianamericanswiki/internal/ethnicity"
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+const (
+	ethnicityTopTags = 10
+	ethnicityPeople  = 10
+)
+
+// EthnicityStats describes the published humans of an ethnicity, including those of the
+// ethnicities within it.
+type EthnicityStats struct {
+	Ethnicity ethnicity.Ethnicity
+	Slug      string
+	Count     int
+	TopTags   []TagCount
+	// Notable are the most viewed humans, and Recent the ones published last.
+	Notable []humandao.Human
+	Recent  []humandao.Human
+	Decades []DecadeCount
+	// Related are the other ethnicities humans of mixed heritage have, the most shared first.
+	Related []EthnicityCount
+}
+
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// DecadeCount is how many humans were born in a decade. Percent is of the decade the most
+// humans were born in, to draw the histogram with.
+type DecadeCount struct {
+	Decade  int
+	Count   int
+	Percent int
+}
+
+type EthnicityCount struct {
+	Ethnicity ethnicity.Ethnicity
+	Slug      string
+	Count     int
+}
+
+// computeEthnicityStats returns the stats of every ethnicity, by slug.
+func computeEthnicityStats(humans []humandao.Human) map[string]EthnicityStats {
+	published := make([]humandao.Human, 0, len(humans))
+	for _, human := range humans {
+		if !human.Draft {
+			human.Path = "/humans/" + human.Path
+			published = append(published, human)
+		}
+	}
+
+	stats := make(map[string]EthnicityStats, len(ethnicity.All))
+	for _, e := range ethnicity.All {
+		members := humandao.ApplyFilters(published, humandao.ByEthnicity(e.Ethnicity))
+		stats[humandao.Slug(e.Ethnicity)] = EthnicityStats{
+			Ethnicity: e,
+			Slug:      humandao.Slug(e.Ethnicity),
+			Count:     len(members),
+			TopTags:   topTags(members),
+			Notable:   notable(members),
+			Recent:    recent(members),
+			Decades:   decades(members),
+			Related:   related(members, e),
+		}
+	}
+	return stats
+}
+
+func topTags(humans []humandao.Human) []TagCount {
+	counts := make(map[string]int)
+	for _, human := range humans {
+		for _, tag := range human.Tags {
+			counts[tag]++
+		}
+	}
+	tags := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Count != tags[j].Count {
+			return tags[i].Count > tags[j].Count
+		}
+		return tags[i].Tag < tags[j].Tag
+	})
+	return tags[:min(len(tags), ethnicityTopTags)]
+}
+
+func notable(humans []humandao.Human) []humandao.Human {
+	sorted := slices.Clone(humans)
+	slices.SortStableFunc(sorted, func(a, b humandao.Human) int {
+		return cmp.Or(cmp.Compare(b.Views, a.Views), cmp.Compare(a.Name, b.Name))
+	})
+	return sorted[:min(len(sorted), ethnicityPeople)]
+}
+
+func recent(humans []humandao.Human) []humandao.Human {
+	publishedAt := func(h humandao.Human) time.Time {
+		if h.PublishedAt.IsZero() {
+			return h.CreatedAt
+		}
+		return h.PublishedAt
+	}
+	sorted := slices.Clone(humans)
+	slices.SortStableFunc(sorted, func(a, b humandao.Human) int {
+		return cmp.Or(publishedAt(b).Compare(publishedAt(a)), cmp.Compare(a.Name, b.Name))
+	})
+	return sorted[:min(len(sorted), ethnicityPeople)]
+}
+
+// decades counts the humans by the decade they were born in, from the earliest decade to the
+// latest, including the decades in between no one was born in.
+func decades(humans []humandao.Human) []DecadeCount {
+	counts := make(map[int]int)
+	for _, human := range humans {
+		if year, _, _ := human.DOB.Parts(); year != 0 {
+			counts[year/10*10]++
+		}
+	}
+	if len(counts) == 0 {
+		return nil
+	}
+	first, last, most := 10000, 0, 0
+	for decade, count := range counts {
+		first, last, most = min(first, decade), max(last, decade), max(most, count)
+	}
+	var histogram []DecadeCount
+	for decade := first; decade <= last; decade += 10 {
+		histogram = append(histogram, DecadeCount{Decade: decade, Count: counts[decade], Percent: counts[decade] * 100 / most})
+	}
+	return histogram
+}
+
+// related counts the other ethnicities of the humans. Ethnicities that are part of e, or that e
+// is part of, aren't other ethnicities.
+func related(humans []humandao.Human, e ethnicity.Ethnicity) []EthnicityCount {
+	counts := make(map[string]int)
+	for _, human := range humans {
+		seen := make(map[string]bool)
+		for _, other := range human.Ethnicity {
+			o, ok := ethnicity.Get(other)
+			if !ok || seen[o.Ethnicity] || ethnicity.Is(o.Ethnicity, e.Ethnicity) || ethnicity.Is(e.Ethnicity, o.Ethnicity) {
+				continue
+			}
+			seen[o.Ethnicity] = true
+			counts[o.Ethnicity]++
+		}
+	}
+	relatedCounts := make([]EthnicityCount, 0, len(counts))
+	for name, count := range counts {
+		o, _ := ethnicity.Get(name)
+		relatedCounts = append(relatedCounts, EthnicityCount{Ethnicity: o, Slug: humandao.Slug(name), Count: count})
+	}
+	sort.Slice(relatedCounts, func(i, j int) bool {
+		if relatedCounts[i].Count != relatedCounts[j].Count {
+			return relatedCounts[i].Count > relatedCounts[j].Count
+		}
+		return relatedCounts[i].Ethnicity.Ethnicity < relatedCounts[j].Ethnicity.Ethnicity
+	})
+	return relatedCounts
+}
+
+// getEthnicityStats returns the stats of every ethnicity by slug. They are computed from s.humans
+// the first time they're asked for, and again after a human changes.
+func (s *ServerHTML) getEthnicityStats() map[string]EthnicityStats {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.ethnicityStats == nil {
+		s.ethnicityStats = computeEthnicityStats(s.humans)
+	}
+	return s.ethnicityStats
+}
+
+// EthnicityGroup is a region, or the non-Asian heritage, with how many humans each of its
+// ethnicities has.
+type EthnicityGroup struct {
+	Name        string
+	Ethnicities []EthnicityCount
+}
+
+type HTMLResponseEthnicities struct {
+	Base
+	Groups []EthnicityGroup
+}
+
+// HandlerEthnicities lists every ethnicity by region with how many humans it has.
+func (s *ServerHTML) HandlerEthnicities(w http.ResponseWriter, r *http.Request) error {
+	var (
+		token = s.parseOptionalToken(r)
+		admin = IsAdmin(token)
+		stats = s.getEthnicityStats()
+	)
+
+	var groups []EthnicityGroup
+	for _, group := range ethnicity.Groups() {
+		counts := EthnicityGroup{Name: group.Name}
+		for _, e := range group.Ethnicities {
+			slug := humandao.Slug(e.Ethnicity)
+			counts.Ethnicities = append(counts.Ethnicities, EthnicityCount{Ethnicity: e, Slug: slug, Count: stats[slug].Count})
+		}
+		groups = append(groups, counts)
+	}
+
+	response := HTMLResponseEthnicities{
+		Base:   getBase(s, admin),
+		Groups: groups,
+	}
+	if err := s.template.ExecuteTemplate(w, "ethnicities.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute ethnicities template")
+	}
+
+	return nil
+}
+
+type HTMLResponseEthnicity struct {
+	Base
+	EthnicityStats
+	// Parent is the group the ethnicity is part of, and Children the ones that are part of it.
+	Parent   *EthnicityCount
+	Children []EthnicityCount
+}
+
+// HandlerEthnicity shows who the humans of an ethnicity are: how many there are, what they do,
+// when they were born and what other heritage they have.
+func (s *ServerHTML) HandlerEthnicity(w http.ResponseWriter, r *http.Request) error {
+	var (
+		token = s.parseOptionalToken(r)
+		admin = IsAdmin(token)
+		name  = chi.URLParamFromCtx(r.Context(), "ethnicity")
+	)
+
+	e, ok := ethnicity.Get(name)
+	if !ok {
+		return NewNotFoundError(fmt.Errorf("unknown ethnicity %v", name))
+	}
+	if slug := humandao.Slug(e.Ethnicity); slug != name {
+		http.Redirect(w, r, "/ethnicities/"+slug, http.StatusMovedPermanently)
+		return nil
+	}
+
+	stats := s.getEthnicityStats()
+	response := HTMLResponseEthnicity{
+		Base:           getBase(s, admin),
+		EthnicityStats: stats[name],
+	}
+	if e.Parent != "" {
+		parent := stats[humandao.Slug(e.Parent)]
+		response.Parent = &EthnicityCount{Ethnicity: parent.Ethnicity, Slug: parent.Slug, Count: parent.Count}
+	}
+	for _, child := range ethnicity.Children(e.Ethnicity) {
+		c := stats[humandao.Slug(child.Ethnicity)]
+		response.Children = append(response.Children, EthnicityCount{Ethnicity: c.Ethnicity, Slug: c.Slug, Count: c.Count})
+	}
+	if err := s.template.ExecuteTemplate(w, "ethnicities-id.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute ethnicities-id template")
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+type HTMLResponseHumanHistory struct {
+	Base
+	HumanID   string
+	Human     humandao.Human
+	Revisions []humandao.Revision
+}
+
+type HTMLResponseHumanRevision struct {
+	Base
+	HumanID  string
+	Human    humandao.Human
+	Revision humandao.Revision
+	Previous *humandao.Revision
+	Changes  []humandao.FieldChange
+}
+
+// resolveHuman finds a human by ID or path, including humans in the trash. Purged humans
+// can't be found this way, so their history is looked up with the ID from the URL.
+func (s *ServerHTML) resolveHuman(ctx context.Context, pathOrID string) (humandao.Human, error) {
+	human, err := s.humanDAO.Human(ctx, humandao.HumanInput{HumanID: pathOrID, IncludeDeleted: true})
+	if err == nil {
+		return human, nil
+	}
+	return s.humanDAO.Human(ctx, humandao.HumanInput{Path: pathOrID, IncludeDeleted: true})
+}
+
+func (s *ServerHTML) humanHistory(ctx context.Context, pathOrID string) (humandao.Human, string, []humandao.Revision, error) {
+	human, err := s.resolveHuman(ctx, pathOrID)
+	if err != nil && !errors.Is(err, humandao.ErrHumanNotFound) {
+		return humandao.Human{}, "", nil, err
+	}
+
+	humanID := pathOrID
+	if human.ID != "" {
+		humanID = human.ID
+	}
+
+	revisions, _, err := s.humanDAO.Revisions(ctx, humandao.RevisionsInput{HumanID: humanID})
+	if err != nil {
+		return humandao.Human{}, "", nil, NewInternalServerError(err)
+	}
+	if human.ID == "" && len(revisions) == 0 {
+		return humandao.Human{}, "", nil, NewNotFoundError(fmt.Errorf("%w: %v", humandao.ErrHumanNotFound, pathOrID))
+	}
+	if human.ID == "" {
+		human = revisions[0].Snapshot
+	}
+
+	return human, humanID, revisions, nil
+}
+
+func (s *ServerHTML) HandlerHumanHistory(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+
+	pathOrID, err := url.PathUnescape(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return err
+	}
+
+	human, humanID, revisions, err := s.humanHistory(ctx, pathOrID)
+	if err != nil {
+		return err
+	}
+
+	response := HTMLResponseHumanHistory{
+		Base:      getBase(s, admin),
+		HumanID:   humanID,
+		Human:     human,
+		Revisions: revisions,
+	}
+	if err := s.template.ExecuteTemplate(w, "humans-id-history.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute humans-id-history template")
+	}
+
+	return nil
+}
+
+// HandlerHumanRevision shows what a single revision changed, compared to the revision before it.
+func (s *ServerHTML) HandlerHumanRevision(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+
+	pathOrID, err := url.PathUnescape(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return err
+	}
+	revisionID := chi.URLParamFromCtx(ctx, "revisionID")
+
+	human, humanID, revisions, err := s.humanHistory(ctx, pathOrID)
+	if err != nil {
+		return err
+	}
+
+	response := HTMLResponseHumanRevision{
+		Base:    getBase(s, admin),
+		HumanID: humanID,
+		Human:   human,
+	}
+	for i, revision := range revisions {
+		if revision.ID != revisionID {
+			continue
+		}
+		response.Revision = revision
+		// revisions are newest first, so the one before this is the next in the list
+		if i+1 < len(revisions) {
+			response.Previous = &revisions[i+1]
+		}
+	}
+	if response.Revision.ID == "" {
+		return NewNotFoundError(fmt.Errorf("%w: %v", humandao.ErrRevisionNotFound, revisionID))
+	}
+
+	from := ""
+	if response.Previous != nil {
+		from = response.Previous.ID
+	}
+	changes, err := s.humanDAO.DiffRevisions(ctx, humandao.DiffRevisionsInput{
+		HumanID: humanID,
+		From:    from,
+		To:      revisionID,
+	})
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+	response.Changes = changes
+
+	if err := s.template.ExecuteTemplate(w, "humans-id-revision.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute humans-id-revision template")
+	}
+
+	return nil
+}
+
+func (s *ServerHTML) HandlerHumanRestore(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		return NewUnauthorizedError(err)
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
+
+	pathOrID, err := url.PathUnescape(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return err
+	}
+	revisionID := chi.URLParamFromCtx(ctx, "revisionID")
+
+	current, humanID, _, err := s.humanHistory(ctx, pathOrID)
+	if err != nil {
+		return err
+	}
+
+	// The page carries the version it was rendered from, so restoring over a newer edit conflicts
+	// instead of silently undoing it.
+	version := current.Version
+	if value := r.FormValue("version"); value != "" {
+		version, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return NewBadRequestError(fmt.Errorf("invalid version: %w", err))
+		}
+	}
+
+	human, err := s.humanDAO.RestoreRevision(ctx, humandao.RestoreRevisionInput{HumanID: humanID, RevisionID: revisionID, Version: version})
+	if err != nil {
+		if errors.Is(err, humandao.ErrRevisionNotFound) {
+			return NewNotFoundError(err)
+		}
+		if humandao.IsInvalidHuman(err) || errors.Is(err, humandao.ErrHumanAlreadyExists) || errors.Is(err, humandao.ErrConflict) {
+			return NewBadRequestError(err)
+		}
+		return NewInternalServerError(err)
+	}
+
+	if err := s.updateIndex(human); err != nil {
+		s.logger.Error().Err(err).Str("id", human.ID).Msg("unable to update index")
+	}
+
+	s.logger.Info().Str("id", human.ID).Str("revision", revisionID).Msg("successfully restored human")
+	w.Header().Add("HX-Redirect", fmt.Sprintf("/humans/%s", human.Path))
+	return nil
+}
@@ -9,22 +9,28 @@ import (
 	"net/http"
 	"net/url"
 	"path/filepath"
-	"sort"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/raymonstah/asianamericanswiki/internal/ethnicity"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/imageutil"
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
 )
 
 type HTMLResponseHumans struct {
 	Base
 	Humans      []humandao.Human
 	Count       int
-	Ethnicities []ethnicity.Ethnicity
+	Ethnicities []ethnicity.Group
 	Tags        []string
+	Awards      []AwardFacet
+	BornIn      []PlaceFacet
+	BasedIn     []PlaceFacet
 }
 
 func (s *ServerHTML) HandlerHumans(w http.ResponseWriter, r *http.Request) error {
@@ -35,6 +41,9 @@ func (s *ServerHTML) HandlerHumans(w http.ResponseWriter, r *http.Request) error
 		dobBefore   = r.URL.Query().Get("dobBefore")
 		dobAfter    = r.URL.Query().Get("dobAfter")
 		search      = r.URL.Query().Get("search")
+		award       = r.URL.Query().Get("award")
+		bornIn      = r.URL.Query().Get("born_in")
+		basedIn     = r.URL.Query().Get("based_in")
 	)
 	
 	s.lock.Lock()
@@ -42,7 +51,11 @@ func (s *ServerHTML) HandlerHumans(w http.ResponseWriter, r *http.Request) error
 	copy(allHumans, s.humans)
 	s.lock.Unlock()
 
-	allTags := getTags(allHumans)
+	taxonomy, err := s.getTaxonomy(r.Context())
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+	allTags := getTags(taxonomy, allHumans...)
 	filters := []humandao.FilterOpt{}
 	
 	humans := make([]humandao.Human, 0, len(allHumans))
@@ -53,32 +66,17 @@ func (s *ServerHTML) HandlerHumans(w http.ResponseWriter, r *http.Request) error
 	}
 	
 	if search != "" {
-		nameQuery := bleve.NewPrefixQuery(search)
-		nameQuery.SetField("Name")
-		nameQuery.SetBoost(5.0)
-		query := bleve.NewMatchQuery(search)
-		query.SetFuzziness(1)
-
-		queryAll := bleve.NewDisjunctionQuery(query, nameQuery)
-		searchReq := bleve.NewSearchRequest(queryAll)
-		
-		s.lock.Lock()
-		result, err := s.index.Search(searchReq)
-		s.lock.Unlock()
-		
+		// bleve returns its 10 best matches when it isn't asked for more
+		hitIDs, err := s.search(search, 10)
 		if err != nil {
 			return err
 		}
-		hitIDs := make([]string, 0, len(result.Hits))
-		for _, hit := range result.Hits {
-			hitIDs = append(hitIDs, hit.ID)
-		}
 
 		filters = append(filters, humandao.ByIDs(hitIDs...))
 	}
 
 	if len(tags) > 0 {
-		filters = append(filters, humandao.ByTags(tags...))
+		filters = append(filters, taxonomy.ByTags(tags...))
 	}
 	if len(ethnicitiesList) > 0 {
 		for _, ethn := range ethnicitiesList {
@@ -88,21 +86,34 @@ func (s *ServerHTML) HandlerHumans(w http.ResponseWriter, r *http.Request) error
 	if gender != "" {
 		filters = append(filters, humandao.ByGender(humandao.Gender(gender)))
 	}
+	if award != "" {
+		filters = append(filters, humandao.ByAward(award))
+	}
+	if bornIn != "" {
+		filters = append(filters, humandao.ByBirthPlace(bornIn))
+	}
+	if basedIn != "" {
+		filters = append(filters, humandao.ByBasedIn(basedIn))
+	}
+	// a partial date covers all of it, so "born before 1990" means before the first day of 1990,
+	// and "born after 1990" means after the last day of it
 	if dobBefore != "" {
-		age, err := time.Parse("2006-01-02", dobBefore)
+		date, err := humandao.ParsePartialDate(dobBefore)
 		if err != nil {
 			return NewBadRequestError(err)
 		}
-		filters = append(filters, humandao.ByAgeOlderThan(age))
+		filters = append(filters, humandao.ByAgeOlderThan(date.Earliest()))
 	}
 	if dobAfter != "" {
-		age, err := time.Parse("2006-01-02", dobAfter)
+		date, err := humandao.ParsePartialDate(dobAfter)
 		if err != nil {
 			return NewBadRequestError(err)
 		}
-		filters = append(filters, humandao.ByAgeYoungerThan(age))
+		filters = append(filters, humandao.ByAgeYoungerThan(date.Latest()))
 	}
 
+	// the places to filter by come from published humans only, so drafts don't show up in them
+	bornInFacets, basedInFacets := getBornIn(humans), getBasedIn(humans)
 	humans = humandao.ApplyFilters(humans, filters...)
 	for i, human := range humans {
 		humans[i].Path = "/humans/" + human.Path
@@ -112,8 +123,11 @@ func (s *ServerHTML) HandlerHumans(w http.ResponseWriter, r *http.Request) error
 		Base:        getBase(s, false),
 		Count:       len(humans),
 		Humans:      humans,
-		Ethnicities: ethnicity.All,
+		Ethnicities: ethnicity.Groups(),
 		Tags:        allTags,
+		Awards:      getAwards(allHumans),
+		BornIn:      bornInFacets,
+		BasedIn:     basedInFacets,
 	}
 	if err := s.template.ExecuteTemplate(w, "humans.html", response); err != nil {
 		s.logger.Error().Err(err).Msg("unable to execute humans.html template")
@@ -122,20 +136,31 @@ func (s *ServerHTML) HandlerHumans(w http.ResponseWriter, r *http.Request) error
 	return nil
 }
 
+// searchQuery finds humans by the start of their name, by any of their fields, and by the titles
+// of their works, so searching for a film finds its cast.
+func searchQuery(search string) query.Query {
+	nameQuery := bleve.NewPrefixQuery(search)
+	nameQuery.SetField("Name")
+	nameQuery.SetBoost(5.0)
+	workQuery := bleve.NewMatchPhraseQuery(search)
+	workQuery.SetField("Works.Title")
+	workQuery.SetBoost(3.0)
+	namesQuery := bleve.NewMatchQuery(search)
+	namesQuery.SetField("Names.Value")
+	namesQuery.SetBoost(5.0)
+	matchQuery := bleve.NewMatchQuery(search)
+	matchQuery.SetFuzziness(1)
+
+	return bleve.NewDisjunctionQuery(matchQuery, nameQuery, namesQuery, workQuery)
+}
+
 func (s *ServerHTML) HandlerSearchSuggest(w http.ResponseWriter, r *http.Request) error {
 	search := r.URL.Query().Get("search")
 	if search == "" {
 		return nil
 	}
 
-	nameQuery := bleve.NewPrefixQuery(search)
-	nameQuery.SetField("Name")
-	nameQuery.SetBoost(5.0)
-	query := bleve.NewMatchQuery(search)
-	query.SetFuzziness(1)
-
-	queryAll := bleve.NewDisjunctionQuery(query, nameQuery)
-	searchReq := bleve.NewSearchRequest(queryAll)
+	searchReq := bleve.NewSearchRequest(searchQuery(search))
 	searchReq.Size = 5
 
 	s.lock.Lock()
@@ -183,26 +208,16 @@ func (s *ServerHTML) HandlerRandom(w http.ResponseWriter, r *http.Request) error
 	return nil
 }
 
-func getTags(humans []humandao.Human) []string {
-	uniqueTags := make(map[string]struct{}, 64)
-	for _, human := range humans {
-		for _, tag := range human.Tags {
-			uniqueTags[tag] = struct{}{}
-		}
-	}
-	tags := make([]string, 0, len(uniqueTags))
-	for tag := range uniqueTags {
-		tags = append(tags, tag)
-	}
-	sort.Strings(tags)
-	return tags
-}
-
 type HTMLResponseHuman struct {
 	Base
 	Human           humandao.Human
 	HumanFormFields HumanFormFields
 	Similar         []humandao.Human
+	Connections     []humandao.ConnectionGroup
+	// RelationshipChoices are the options of the form admins link humans with.
+	RelationshipChoices []RelationshipChoice
+	// Conflict lists the fields where a stale edit differs from what is saved (Before) now.
+	Conflict []humandao.FieldChange
 }
 
 func (s *ServerHTML) HandlerHuman(w http.ResponseWriter, r *http.Request) error {
@@ -226,6 +241,14 @@ func (s *ServerHTML) HandlerHuman(w http.ResponseWriter, r *http.Request) error
 			break
 		}
 	}
+	if human.ID == "" {
+		for _, h := range s.humans {
+			if slices.Contains(h.PreviousPaths, path) {
+				human = h
+				break
+			}
+		}
+	}
 	
 	var similar []humandao.Human
 	if human.ID != "" {
@@ -242,8 +265,17 @@ func (s *ServerHTML) HandlerHuman(w http.ResponseWriter, r *http.Request) error
 	if human.ID == "" {
 		return NewNotFoundError(fmt.Errorf("%w: %v", humandao.ErrHumanNotFound, path))
 	}
+	if path != human.Path && path != human.ID {
+		// renamed since the link was made; send people and crawlers to the canonical path
+		http.Redirect(w, r, fmt.Sprintf("/humans/%s", url.PathEscape(human.Path)), http.StatusMovedPermanently)
+		return nil
+	}
 
 	response := HTMLResponseHuman{Human: human, Similar: similar, Base: getBase(s, admin)}
+	response.Connections = s.connections(r.Context(), human.ID, admin)
+	if admin {
+		response.RelationshipChoices = relationshipChoices()
+	}
 	if err := s.template.ExecuteTemplate(w, "humans-id.html", response); err != nil {
 		s.logger.Error().Err(err).Msg("unable to execute humans-id template")
 	}
@@ -269,6 +301,7 @@ func (s *ServerHTML) HandlerHumanAdd(w http.ResponseWriter, r *http.Request) err
 	if !admin {
 		return NewForbiddenError(fmt.Errorf("you are not an admin"))
 	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
 
 	if err := r.ParseMultipartForm(maxMemoryMB); err != nil {
 		return err
@@ -278,8 +311,6 @@ func (s *ServerHTML) HandlerHumanAdd(w http.ResponseWriter, r *http.Request) err
 		name        = strings.TrimSpace(r.Form.Get("name"))
 		gender      = strings.TrimSpace(r.Form.Get("gender"))
 		description = strings.TrimSpace(r.Form.Get("description"))
-		dob         = strings.TrimSpace(r.Form.Get("dob"))
-		dod         = strings.TrimSpace(r.Form.Get("dod"))
 		ethnicityList   = r.Form["ethnicity"]
 		tags        = r.Form["tags"]
 		imdb        = strings.TrimSpace(r.Form.Get("imdb"))
@@ -288,6 +319,25 @@ func (s *ServerHTML) HandlerHumanAdd(w http.ResponseWriter, r *http.Request) err
 		instagram   = strings.TrimSpace(r.Form.Get("instagram"))
 		aliases     = r.Form.Get("aliases")
 	)
+	dob, dod, err := parseDatesForm(r.Form)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	sources, err := parseSourcesForm(r.Form)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	names := parseNamesForm(r.Form)
+	birthPlace, basedIn := parsePlacesForm(r.Form, humandao.Human{})
+	works, err := parseWorksForm(r.Form)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	awards, err := parseAwardsForm(r.Form)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	image := parseImageForm(r.Form)
 	var aliasesList []string
 	if aliases != "" {
 		for _, a := range strings.Split(aliases, ",") {
@@ -334,6 +384,9 @@ func (s *ServerHTML) HandlerHumanAdd(w http.ResponseWriter, r *http.Request) err
 	human, err := s.humanDAO.AddHuman(ctx, humandao.AddHumanInput{
 		Name:        name,
 		Aliases:     aliasesList,
+		Names:       names,
+		BirthPlace:  birthPlace,
+		BasedIn:     basedIn,
 		Gender:      humandao.Gender(gender),
 		DOB:         dob,
 		DOD:         dod,
@@ -346,27 +399,31 @@ func (s *ServerHTML) HandlerHumanAdd(w http.ResponseWriter, r *http.Request) err
 		Tags:        tags,
 		CreatedBy:   token.UID,
 		Draft:       true,
+		Sources:     sources,
+		Works:       works,
+		Awards:      awards,
 	})
 	if err != nil {
-		if errors.Is(err, humandao.ErrInvalidGender) {
-			return NewBadRequestError(err)
-		}
-		if errors.Is(err, humandao.ErrHumanAlreadyExists) {
+		if humandao.IsInvalidHuman(err) || errors.Is(err, humandao.ErrHumanAlreadyExists) {
 			return NewBadRequestError(err)
 		}
 		return NewInternalServerError(err)
 	}
 
-	if len(rawThumbnail) > 0 || len(rawFeaturedImage) > 0 {
-		if len(rawFeaturedImage) > 0 {
-			if _, err := s.uploader.UploadHumanImages(ctx, human, rawFeaturedImage); err != nil {
-				return err
-			}
-		} else if len(rawThumbnail) > 0 {
-			// This case is unlikely given the form, but let's handle it by using thumbnail as featured
-			if _, err := s.uploader.UploadHumanImages(ctx, human, rawThumbnail); err != nil {
-				return err
-			}
+	// the thumbnail is only uploaded on its own when there is no featured image to make it from
+	rawImage := rawFeaturedImage
+	if len(rawImage) == 0 {
+		rawImage = rawThumbnail
+	}
+	if len(rawImage) > 0 {
+		human, err = s.uploader.UploadHumanImage(ctx, imageutil.UploadHumanImageInput{
+			Human:    human,
+			RawImage: rawImage,
+			Image:    image,
+			Featured: true,
+		})
+		if err != nil {
+			return imageError(err)
 		}
 	}
 
@@ -403,20 +460,17 @@ func (s *ServerHTML) HandlerHumanEdit(w http.ResponseWriter, r *http.Request) er
 		return err
 	}
 
-	humans, err := s.humanDAO.ListHumans(ctx, humandao.ListHumansInput{
-		Limit:         1000,
-		IncludeDrafts: true,
-	})
+	taxonomy, err := s.getTaxonomy(ctx)
 	if err != nil {
-		return NewInternalServerError(fmt.Errorf("unable to list humans: %w", err))
+		return NewInternalServerError(err)
 	}
 
 	response := HTMLResponseHuman{
 		Human: human,
 		Base:  getBase(s, admin),
 		HumanFormFields: HumanFormFields{
-			Ethnicities: ethnicity.All,
-			Tags:        getTags(humans),
+			Ethnicities: ethnicity.Groups(),
+			Tags:        getTags(taxonomy, human),
 		},
 	}
 	if err := s.template.ExecuteTemplate(w, "humans-id-edit.html", response); err != nil {
@@ -438,6 +492,7 @@ func (s *ServerHTML) HandlerHumanUpdate(w http.ResponseWriter, r *http.Request)
 	if !admin {
 		return NewForbiddenError(fmt.Errorf("user is not an admin"))
 	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
 
 	if err := r.ParseMultipartForm(maxMemoryMB); err != nil {
 		return err
@@ -451,15 +506,33 @@ func (s *ServerHTML) HandlerHumanUpdate(w http.ResponseWriter, r *http.Request)
 		imdb        = strings.TrimSpace(r.Form.Get("imdb"))
 		tags        = r.Form["tags"]
 		tagsOther   = r.Form.Get("tags-other")
-		dob         = strings.TrimSpace(r.Form.Get("dob"))
 		name        = strings.TrimSpace(r.Form.Get("name"))
 		ethnicityList   = r.Form["ethnicity"]
 		gender      = strings.TrimSpace(r.Form.Get("gender"))
 		aliases     = r.Form.Get("aliases")
+		version     = strings.TrimSpace(r.Form.Get("version"))
 	)
 	if tagsOther != "" {
 		tags = append(tags, strings.Split(tagsOther, ",")...)
 	}
+	dob, dod, err := parseDatesForm(r.Form)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	sources, err := parseSourcesForm(r.Form)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	names := parseNamesForm(r.Form)
+	works, err := parseWorksForm(r.Form)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	awards, err := parseAwardsForm(r.Form)
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	image := parseImageForm(r.Form)
 
 	var aliasesList []string
 	if aliases != "" {
@@ -524,33 +597,68 @@ func (s *ServerHTML) HandlerHumanUpdate(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	human.Description = description
-	human.Aliases = aliasesList
-	human.Socials.X = x
-	human.Socials.Instagram = instagram
-	human.Socials.Website = website
-	human.Socials.IMDB = imdb
-	human.Tags = tags
-	human.DOB = dob
-	human.Ethnicity = ethnicityList
-	if gender != "" {
-		human.Gender = humandao.Gender(gender)
-	}
-	if name != "" {
-		human.Name = name
+	// The form carries the version it was rendered from, so a stale submit conflicts
+	// instead of overwriting whatever was saved in the meantime.
+	if version != "" {
+		human.Version, err = strconv.ParseInt(version, 10, 64)
+		if err != nil {
+			return NewBadRequestError(fmt.Errorf("invalid version: %w", err))
+		}
 	}
 
-	if len(rawFeaturedImage) > 0 {
-		if _, err := s.uploader.UploadHumanImages(ctx, human, rawFeaturedImage); err != nil {
-			return err
+	applyForm := func(human humandao.Human) humandao.Human {
+		human.Description = description
+		human.Aliases = aliasesList
+		human.Socials.X = x
+		human.Socials.Instagram = instagram
+		human.Socials.Website = website
+		human.Socials.IMDB = imdb
+		human.Tags = tags
+		human.DOB = dob
+		human.DOD = dod
+		human.Ethnicity = ethnicityList
+		human.Sources = sources
+		human.Names = names
+		human.BirthPlace, human.BasedIn = parsePlacesForm(r.Form, human)
+		human.Works = works
+		human.Awards = awards
+		if gender != "" {
+			human.Gender = humandao.Gender(gender)
 		}
-	} else if len(rawThumbnail) > 0 {
-		if _, err := s.uploader.UploadHumanImages(ctx, human, rawThumbnail); err != nil {
-			return err
+		if name != "" {
+			human.Name = name
 		}
-	} else {
-		if err := s.humanDAO.UpdateHuman(ctx, human); err != nil {
-			return err
+		return human
+	}
+	human = applyForm(human)
+
+	// the stored human has the new path and version, which the index and the redirect need
+	human, err = s.humanDAO.UpdateHuman(ctx, human)
+	var conflict *humandao.ConflictError
+	if errors.As(err, &conflict) {
+		return s.renderConflict(ctx, w, conflict, applyForm(conflict.Current))
+	}
+	if humandao.IsInvalidHuman(err) || errors.Is(err, humandao.ErrHumanAlreadyExists) {
+		return NewBadRequestError(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	// the upload only patches the images, so it can't undo the update above
+	rawImage := rawFeaturedImage
+	if len(rawImage) == 0 {
+		rawImage = rawThumbnail
+	}
+	if len(rawImage) > 0 {
+		human, err = s.uploader.UploadHumanImage(ctx, imageutil.UploadHumanImageInput{
+			Human:    human,
+			RawImage: rawImage,
+			Image:    image,
+			Featured: true,
+		})
+		if err != nil {
+			return imageError(err)
 		}
 	}
 
@@ -563,6 +671,196 @@ func (s *ServerHTML) HandlerHumanUpdate(w http.ResponseWriter, r *http.Request)
 	return nil
 }
 
+// parseDatesForm reads the dates of birth and death from the human forms.
+func parseDatesForm(form url.Values) (dob, dod humandao.PartialDate, err error) {
+	dob, err = humandao.ParsePartialDate(form.Get("dob"))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid date of birth: %w", err)
+	}
+	dod, err = humandao.ParsePartialDate(form.Get("dod"))
+	if err != nil {
+		return "", "", fmt.Errorf("invalid date of death: %w", err)
+	}
+	return dob, dod, nil
+}
+
+// parseImageForm reads the details of the image uploaded with the human forms, which are
+// checked when it's uploaded.
+func parseImageForm(form url.Values) humandao.Image {
+	return humandao.Image{
+		Author:      strings.TrimSpace(form.Get("image_author")),
+		License:     strings.TrimSpace(form.Get("image_license")),
+		SourceURL:   strings.TrimSpace(form.Get("image_source")),
+		Caption:     strings.TrimSpace(form.Get("image_caption")),
+		AIGenerated: form.Get("image_ai_generated") != "",
+	}
+}
+
+// rowValue returns the trimmed value of the ith row of a repeated form field, or "" when the row
+// left the field out.
+func rowValue(values []string, i int) string {
+	if i < len(values) {
+		return strings.TrimSpace(values[i])
+	}
+	return ""
+}
+
+// parseSourcesForm reads the sources from the rows of source_* fields in the human forms.
+// Rows without a URL are left out, so the blank row at the end of the form can be submitted as is.
+func parseSourcesForm(form url.Values) ([]humandao.Source, error) {
+	var (
+		urls       = form["source_url"]
+		titles     = form["source_title"]
+		publishers = form["source_publisher"]
+		accessed   = form["source_accessed"]
+		fields     = form["source_fields"]
+	)
+	var sources []humandao.Source
+	for i := range urls {
+		source := humandao.Source{
+			URL:       rowValue(urls, i),
+			Title:     rowValue(titles, i),
+			Publisher: rowValue(publishers, i),
+		}
+		if source.URL == "" {
+			continue
+		}
+		if date := rowValue(accessed, i); date != "" {
+			accessedAt, err := time.Parse("2006-01-02", date)
+			if err != nil {
+				return nil, fmt.Errorf("invalid access date for %v: %w", source.URL, err)
+			}
+			source.AccessedAt = accessedAt
+		}
+		for _, field := range strings.Split(rowValue(fields, i), ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				source.Fields = append(source.Fields, field)
+			}
+		}
+		sources = append(sources, source)
+	}
+
+	return sources, nil
+}
+
+// parseNamesForm reads the name variants from the rows of name_* fields in the human forms. Rows
+// without a value are left out.
+func parseNamesForm(form url.Values) []humandao.NameVariant {
+	var (
+		values = form["name_value"]
+		langs  = form["name_lang"]
+		types  = form["name_type"]
+	)
+	var names []humandao.NameVariant
+	for i := range values {
+		name := humandao.NameVariant{
+			Value: rowValue(values, i),
+			Lang:  rowValue(langs, i),
+			Type:  humandao.NameType(rowValue(types, i)),
+		}
+		if name.Value == "" {
+			continue
+		}
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// parseWorksForm reads the works from the rows of work_* fields in the human forms. Rows without
+// a title are left out, like the rows of sources without a URL.
+func parseWorksForm(form url.Values) ([]humandao.Work, error) {
+	var (
+		titles  = form["work_title"]
+		types   = form["work_type"]
+		years   = form["work_year"]
+		credits = form["work_credit"]
+		urls    = form["work_url"]
+	)
+	var works []humandao.Work
+	for i := range titles {
+		work := humandao.Work{
+			Title:  rowValue(titles, i),
+			Type:   humandao.WorkType(rowValue(types, i)),
+			Credit: rowValue(credits, i),
+			URL:    rowValue(urls, i),
+		}
+		if work.Title == "" {
+			continue
+		}
+		if year := rowValue(years, i); year != "" {
+			parsed, err := strconv.Atoi(year)
+			if err != nil {
+				return nil, fmt.Errorf("invalid year for %v: %w", work.Title, err)
+			}
+			work.Year = parsed
+		}
+		works = append(works, work)
+	}
+
+	return works, nil
+}
+
+// parseAwardsForm reads the awards from the rows of award_* fields in the human forms. Rows
+// without a name are left out.
+func parseAwardsForm(form url.Values) ([]humandao.Award, error) {
+	var (
+		names      = form["award_name"]
+		categories = form["award_category"]
+		years      = form["award_year"]
+		results    = form["award_result"]
+		sources    = form["award_source"]
+	)
+	var awards []humandao.Award
+	for i := range names {
+		award := humandao.Award{
+			Name:     rowValue(names, i),
+			Category: rowValue(categories, i),
+			Result:   humandao.AwardResult(rowValue(results, i)),
+			Source:   rowValue(sources, i),
+		}
+		if award.Name == "" {
+			continue
+		}
+		if year := rowValue(years, i); year != "" {
+			parsed, err := strconv.Atoi(year)
+			if err != nil {
+				return nil, fmt.Errorf("invalid year for %v: %w", award.Name, err)
+			}
+			award.Year = parsed
+		}
+		awards = append(awards, award)
+	}
+
+	return awards, nil
+}
+
+// renderConflict re-renders the edit form with a 409. The form holds the submitted values on top
+// of what is saved now, along with the fields where the two differ, so the admin can merge by hand.
+func (s *ServerHTML) renderConflict(ctx context.Context, w http.ResponseWriter, conflict *humandao.ConflictError, merged humandao.Human) error {
+	taxonomy, err := s.getTaxonomy(ctx)
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+
+	response := HTMLResponseHuman{
+		Human: merged,
+		Base:  getBase(s, true),
+		HumanFormFields: HumanFormFields{
+			Ethnicities: ethnicity.Groups(),
+			Tags:        getTags(taxonomy, conflict.Current, merged),
+		},
+		Conflict: humandao.DiffHumans(conflict.Current, merged),
+	}
+
+	w.WriteHeader(http.StatusConflict)
+	if err := s.template.ExecuteTemplate(w, "humans-id-edit.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute humans-id-edit.html template")
+	}
+
+	return nil
+}
+
 func (s *ServerHTML) HandlerHumanDelete(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	token, err := s.parseToken(r)
@@ -574,6 +872,7 @@ func (s *ServerHTML) HandlerHumanDelete(w http.ResponseWriter, r *http.Request)
 	if !admin {
 		return NewForbiddenError(fmt.Errorf("user is not an admin"))
 	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
 
 	humanPathOrID := chi.URLParamFromCtx(r.Context(), "id")
 	humanPathOrID, err = url.PathUnescape(humanPathOrID)
@@ -594,7 +893,7 @@ func (s *ServerHTML) HandlerHumanDelete(w http.ResponseWriter, r *http.Request)
 		}
 	}
 
-	if err := s.humanDAO.Delete(ctx, humandao.DeleteInput{HumanID: human.ID}); err != nil {
+	if err := s.humanDAO.Delete(ctx, humandao.DeleteInput{HumanID: human.ID, UserID: token.UID}); err != nil {
 		return err
 	}
 
@@ -602,7 +901,7 @@ func (s *ServerHTML) HandlerHumanDelete(w http.ResponseWriter, r *http.Request)
 		s.logger.Error().Err(err).Str("id", human.ID).Msg("unable to delete from index")
 	}
 
-	s.logger.Info().Str("id", human.ID).Str("name", human.Name).Msg("successfully deleted human")
+	s.logger.Info().Str("id", human.ID).Str("name", human.Name).Msg("successfully moved human to trash")
 
 	// If it's an HTMX request, we might want to redirect or just return empty
 	if r.Header.Get("HX-Request") != "" {
@@ -626,6 +925,7 @@ func (s *ServerHTML) HandlerPublish(w http.ResponseWriter, r *http.Request) erro
 	if !admin {
 		return NewForbiddenError(fmt.Errorf("user is not an admin"))
 	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
 
 	var human humandao.Human
 	
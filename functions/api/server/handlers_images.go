@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/imageutil"
+)
+
+// imageError maps the errors of changing the images of a human to the errors the handlers return.
+func imageError(err error) error {
+	switch {
+	case errors.Is(err, humandao.ErrImageNotFound), errors.Is(err, humandao.ErrHumanNotFound):
+		return NewNotFoundError(err)
+	case errors.Is(err, humandao.ErrInvalidImage):
+		return NewBadRequestError(err)
+	}
+	var conflict *humandao.ConflictError
+	if errors.As(err, &conflict) {
+		return NewConflictError(err)
+	}
+	return NewInternalServerError(err)
+}
+
+// imageHuman checks that the request is from an admin, and finds the human whose images it
+// changes. The returned context attributes the changes to the admin.
+func (s *ServerHTML) imageHuman(r *http.Request) (context.Context, humandao.Human, error) {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		return nil, humandao.Human{}, NewUnauthorizedError(err)
+	}
+	if !IsAdmin(token) {
+		return nil, humandao.Human{}, NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
+
+	human, err := s.resolveHuman(ctx, chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		if errors.Is(err, humandao.ErrHumanNotFound) {
+			return nil, humandao.Human{}, NewNotFoundError(err)
+		}
+		return nil, humandao.Human{}, NewInternalServerError(err)
+	}
+	if human.Deleted() {
+		return nil, humandao.Human{}, NewNotFoundError(fmt.Errorf("%w: %v", humandao.ErrHumanNotFound, human.ID))
+	}
+	return ctx, human, nil
+}
+
+// HandlerHumanImageAdd adds an image to the gallery of a human, along with who made it and its
+// license.
+func (s *ServerHTML) HandlerHumanImageAdd(w http.ResponseWriter, r *http.Request) error {
+	ctx, human, err := s.imageHuman(r)
+	if err != nil {
+		return err
+	}
+
+	if err := r.ParseMultipartForm(maxMemoryMB); err != nil {
+		return NewBadRequestError(err)
+	}
+	file, header, err := r.FormFile("image")
+	if err != nil {
+		return NewBadRequestError(fmt.Errorf("invalid image: %w", err))
+	}
+	defer file.Close()
+	if filepath.Ext(header.Filename) != webpExt {
+		return NewBadRequestError(fmt.Errorf("image should be in webp format"))
+	}
+	raw, err := io.ReadAll(file)
+	if err != nil {
+		return NewBadRequestError(fmt.Errorf("invalid image: %w", err))
+	}
+
+	human, err = s.uploader.UploadHumanImage(ctx, imageutil.UploadHumanImageInput{
+		Human:    human,
+		RawImage: raw,
+		Image:    parseImageForm(r.Form),
+		Featured: r.Form.Get("featured") != "",
+	})
+	if err != nil {
+		return imageError(err)
+	}
+	if err := s.updateIndex(human); err != nil {
+		s.logger.Error().Err(err).Str("id", human.ID).Msg("unable to update index")
+	}
+
+	w.Header().Add("HX-Redirect", fmt.Sprintf("/humans/%s/edit", human.Path))
+	return nil
+}
+
+// HandlerHumanImageFeature makes an image of the gallery the one shown wherever the human is.
+func (s *ServerHTML) HandlerHumanImageFeature(w http.ResponseWriter, r *http.Request) error {
+	ctx, human, err := s.imageHuman(r)
+	if err != nil {
+		return err
+	}
+
+	human, err = s.uploader.FeatureHumanImage(ctx, human, chi.URLParamFromCtx(ctx, "imageID"))
+	if err != nil {
+		return imageError(err)
+	}
+	if err := s.updateIndex(human); err != nil {
+		s.logger.Error().Err(err).Str("id", human.ID).Msg("unable to update index")
+	}
+
+	w.Header().Add("HX-Redirect", fmt.Sprintf("/humans/%s/edit", human.Path))
+	return nil
+}
+
+// HandlerHumanImageDelete removes an image from the gallery of a human.
+func (s *ServerHTML) HandlerHumanImageDelete(w http.ResponseWriter, r *http.Request) error {
+	ctx, human, err := s.imageHuman(r)
+	if err != nil {
+		return err
+	}
+
+	human, err = s.uploader.DeleteHumanImage(ctx, human, chi.URLParamFromCtx(ctx, "imageID"))
+	if err != nil {
+		return imageError(err)
+	}
+	if err := s.updateIndex(human); err != nil {
+		s.logger.Error().Err(err).Str("id", human.ID).Msg("unable to update index")
+	}
+
+	w.Header().Add("HX-Redirect", fmt.Sprintf("/humans/%s/edit", human.Path))
+	return nil
+}
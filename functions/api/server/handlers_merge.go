@@ -0,0 +1,154 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+type HTMLResponseMerge struct {
+	Base
+	WinnerQuery string
+	LoserQuery  string
+	Winner      humandao.Human
+	Loser       humandao.Human
+	// Fields are the values the admin can pick a winner for. Fields that are the same on both humans are left out.
+	Fields []MergeFieldChoice
+	// Preview is the winner with the loser folded in, keeping every field from the winner.
+	Preview humandao.Human
+	Error   string
+}
+
+// MergeFieldChoice is one field of a merge, with the value each human has for it.
+type MergeFieldChoice struct {
+	Field  string
+	Winner string
+	Loser  string
+}
+
+// mergeFieldChoices returns the MergeFields that differ between winner and loser.
+func mergeFieldChoices(winner, loser humandao.Human) []MergeFieldChoice {
+	changes := humandao.DiffHumans(winner, loser)
+	var choices []MergeFieldChoice
+	for _, field := range humandao.MergeFields {
+		choice := MergeFieldChoice{Field: field}
+		var winnerValues, loserValues []string
+		for _, change := range changes {
+			if change.Field == field {
+				winnerValues = append(winnerValues, change.Before)
+				loserValues = append(loserValues, change.After)
+			} else if nested, ok := strings.CutPrefix(change.Field, field+"."); ok {
+				winnerValues = append(winnerValues, nested+": "+change.Before)
+				loserValues = append(loserValues, nested+": "+change.After)
+			}
+		}
+		if len(winnerValues) == 0 {
+			continue
+		}
+		choice.Winner = strings.Join(winnerValues, "\n")
+		choice.Loser = strings.Join(loserValues, "\n")
+		choices = append(choices, choice)
+	}
+	return choices
+}
+
+// HandlerMerge shows two humans side by side, so an admin can pick which values survive a merge.
+func (s *ServerHTML) HandlerMerge(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+
+	response := HTMLResponseMerge{
+		Base:        getBase(s, admin),
+		WinnerQuery: strings.TrimSpace(r.URL.Query().Get("winner")),
+		LoserQuery:  strings.TrimSpace(r.URL.Query().Get("loser")),
+	}
+	if response.WinnerQuery != "" && response.LoserQuery != "" {
+		winner, winnerErr := s.resolveHuman(ctx, response.WinnerQuery)
+		loser, loserErr := s.resolveHuman(ctx, response.LoserQuery)
+		switch {
+		case winnerErr != nil:
+			response.Error = winnerErr.Error()
+		case loserErr != nil:
+			response.Error = loserErr.Error()
+		case winner.ID == loser.ID:
+			response.Error = "a human can't be merged into itself"
+		case winner.Deleted() || loser.Deleted():
+			response.Error = "humans in the trash can't be merged"
+		default:
+			response.Winner = winner
+			response.Loser = loser
+			response.Fields = mergeFieldChoices(winner, loser)
+			response.Preview = humandao.MergeHumans(winner, loser, nil)
+		}
+	}
+
+	if err := s.template.ExecuteTemplate(w, "admin-merge.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute admin-merge template")
+	}
+
+	return nil
+}
+
+// HandlerMergeSubmit merges the loser into the winner. Every field in humandao.MergeFields can be
+// posted as "winner" or "loser" to pick where its value comes from; the winner is the default.
+func (s *ServerHTML) HandlerMergeSubmit(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		return NewUnauthorizedError(err)
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
+
+	if err := r.ParseForm(); err != nil {
+		return NewBadRequestError(fmt.Errorf("invalid form received: %w", err))
+	}
+
+	input := humandao.MergeInput{
+		WinnerID: r.FormValue("winner"),
+		LoserID:  r.FormValue("loser"),
+	}
+	for _, field := range humandao.MergeFields {
+		if r.FormValue(field) == "loser" {
+			input.FromLoser = append(input.FromLoser, field)
+		}
+	}
+
+	merged, err := s.humanDAO.Merge(ctx, input)
+	if err != nil {
+		if errors.Is(err, humandao.ErrInvalidMerge) {
+			return NewBadRequestError(err)
+		}
+		if errors.Is(err, humandao.ErrHumanNotFound) {
+			return NewNotFoundError(err)
+		}
+		return NewInternalServerError(err)
+	}
+
+	if err := s.deleteFromIndex(input.LoserID); err != nil {
+		s.logger.Error().Err(err).Str("id", input.LoserID).Msg("unable to delete from index")
+	}
+	if err := s.updateIndex(merged); err != nil {
+		s.logger.Error().Err(err).Str("id", merged.ID).Msg("unable to update index")
+	}
+
+	s.logger.Info().Str("winner", input.WinnerID).Str("loser", input.LoserID).Strs("fromLoser", input.FromLoser).Msg("successfully merged humans")
+	w.Header().Add("HX-Redirect", fmt.Sprintf("/humans/%s", merged.Path))
+	return nil
+}
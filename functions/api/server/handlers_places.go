@@ -0,0 +1,140 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
+)
+
+// PlaceFacet is a city, region or country that can be picked to filter humans by.
+type PlaceFacet struct {
+	Name string
+	Slug string
+}
+
+// getPlaceFacets returns the distinct names that part picks out of the places, sorted by name.
+func getPlaceFacets(places []place.Place, part func(p place.Place) string) []PlaceFacet {
+	bySlug := make(map[string]PlaceFacet)
+	for _, p := range places {
+		name := part(p)
+		if name == "" {
+			continue
+		}
+		if _, ok := bySlug[humandao.Slug(name)]; !ok {
+			bySlug[humandao.Slug(name)] = PlaceFacet{Name: name, Slug: humandao.Slug(name)}
+		}
+	}
+	facets := make([]PlaceFacet, 0, len(bySlug))
+	for _, facet := range bySlug {
+		facets = append(facets, facet)
+	}
+	sort.Slice(facets, func(i, j int) bool { return facets[i].Name < facets[j].Name })
+	return facets
+}
+
+// getBornIn returns the countries the humans were born in.
+func getBornIn(humans []humandao.Human) []PlaceFacet {
+	var places []place.Place
+	for _, human := range humans {
+		if human.BirthPlace != nil {
+			places = append(places, *human.BirthPlace)
+		}
+	}
+	return getPlaceFacets(places, func(p place.Place) string { return p.Country })
+}
+
+// getBasedIn returns the cities the humans are based in.
+func getBasedIn(humans []humandao.Human) []PlaceFacet {
+	var places []place.Place
+	for _, human := range humans {
+		places = append(places, human.BasedIn...)
+	}
+	return getPlaceFacets(places, func(p place.Place) string { return p.City })
+}
+
+// parsePlacesForm reads the birth_place field and the based_in rows of the human forms. A place
+// that is written the same as one the human already has keeps its coordinates, so coordinates
+// added by hand aren't lost on every edit.
+func parsePlacesForm(form url.Values, human humandao.Human) (*place.Place, []place.Place) {
+	var known []place.Place
+	if human.BirthPlace != nil {
+		known = append(known, *human.BirthPlace)
+	}
+	known = append(known, human.BasedIn...)
+	parse := func(s string) place.Place {
+		s = strings.TrimSpace(s)
+		for _, p := range known {
+			if strings.EqualFold(p.String(), s) {
+				return p
+			}
+		}
+		return place.Parse(s)
+	}
+
+	birthPlace := parse(form.Get("birth_place"))
+	var basedIn []place.Place
+	for _, s := range form["based_in"] {
+		if p := parse(s); !p.IsZero() {
+			basedIn = append(basedIn, p)
+		}
+	}
+	return &birthPlace, basedIn
+}
+
+// MapMarker is a human pinned to a place on the map.
+type MapMarker struct {
+	Name  string  `json:"name"`
+	Path  string  `json:"path"`
+	Place string  `json:"place"`
+	Lat   float64 `json:"lat"`
+	Lng   float64 `json:"lng"`
+	// Born is set when the marker is where the human was born, rather than where they are based.
+	Born bool `json:"born"`
+}
+
+type HTMLResponseMap struct {
+	Base
+	Markers []MapMarker
+}
+
+// mapMarkers pins the published humans to every place of theirs that has coordinates.
+func mapMarkers(humans []humandao.Human) []MapMarker {
+	markers := make([]MapMarker, 0, len(humans))
+	for _, human := range humans {
+		if human.Draft {
+			continue
+		}
+		marker := func(p place.Place, born bool) MapMarker {
+			return MapMarker{Name: human.Name, Path: "/humans/" + human.Path, Place: p.String(), Lat: p.Lat, Lng: p.Lng, Born: born}
+		}
+		if human.BirthPlace != nil && human.BirthPlace.HasCoordinates() {
+			markers = append(markers, marker(*human.BirthPlace, true))
+		}
+		for _, p := range human.BasedIn {
+			if p.HasCoordinates() {
+				markers = append(markers, marker(p, false))
+			}
+		}
+	}
+	return markers
+}
+
+// HandlerMap plots where humans were born and where they are based.
+func (s *ServerHTML) HandlerMap(w http.ResponseWriter, r *http.Request) error {
+	s.lock.Lock()
+	markers := mapMarkers(s.humans)
+	s.lock.Unlock()
+
+	response := HTMLResponseMap{
+		Base:    getBase(s, false),
+		Markers: markers,
+	}
+	if err := s.template.ExecuteTemplate(w, "map.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute map.html template")
+	}
+	return nil
+}
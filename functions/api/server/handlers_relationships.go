@@ -0,0 +1,282 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+const (
+	// maxGraphDepth is how many relationships away from a human the graph reaches.
+	maxGraphDepth = 2
+	// maxGraphNodes keeps the graph of a well connected human small enough to draw.
+	maxGraphNodes = 150
+)
+
+// RelationshipChoice is an option of the form that links two humans, read from the side of the
+// human whose page it's on. Value is the type, followed by ":to" when that human is on the To side.
+type RelationshipChoice struct {
+	Value string
+	Label string
+}
+
+func relationshipChoices() []RelationshipChoice {
+	var choices []RelationshipChoice
+	for _, t := range humandao.RelationshipTypes {
+		choices = append(choices, RelationshipChoice{Value: string(t), Label: t.Label(true)})
+		if !t.Symmetric() {
+			choices = append(choices, RelationshipChoice{Value: string(t) + ":to", Label: t.Label(false)})
+		}
+	}
+	return choices
+}
+
+// parseRelationshipChoice returns the type of a RelationshipChoice value, and whether the human
+// whose page it's on is the From side of it.
+func parseRelationshipChoice(value string) (humandao.RelationshipType, bool) {
+	t, side, _ := strings.Cut(value, ":")
+	return humandao.RelationshipType(t), side != "to"
+}
+
+// humanLookup returns a function that finds the humans of the index by ID. Drafts are only found by admins.
+func (s *ServerHTML) humanLookup(admin bool) func(id string) (humandao.Human, bool) {
+	s.lock.Lock()
+	byID := make(map[string]humandao.Human, len(s.humans))
+	for _, h := range s.humans {
+		if h.Draft && !admin {
+			continue
+		}
+		byID[h.ID] = h
+	}
+	s.lock.Unlock()
+
+	return func(id string) (humandao.Human, bool) {
+		h, ok := byID[id]
+		return h, ok
+	}
+}
+
+// connections returns the connections of a human that the viewer is allowed to see. They are
+// nice to have, so a failure is logged and the page is shown without them.
+func (s *ServerHTML) connections(ctx context.Context, humanID string, admin bool) []humandao.ConnectionGroup {
+	relationships, err := s.humanDAO.Relationships(ctx, humandao.RelationshipsInput{HumanID: humanID})
+	if err != nil {
+		s.logger.Error().Err(err).Str("id", humanID).Msg("unable to get relationships")
+		return nil
+	}
+	return humandao.Connections(humanID, relationships, s.humanLookup(admin))
+}
+
+// HandlerRelationshipAdd links the human of the page to another one, given by path or ID.
+func (s *ServerHTML) HandlerRelationshipAdd(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		return NewUnauthorizedError(err)
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
+
+	if err := r.ParseForm(); err != nil {
+		return NewBadRequestError(err)
+	}
+
+	human, err := s.resolveHuman(ctx, chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		if errors.Is(err, humandao.ErrHumanNotFound) {
+			return NewNotFoundError(err)
+		}
+		return NewInternalServerError(err)
+	}
+	other, err := s.resolveHuman(ctx, strings.TrimPrefix(strings.TrimSpace(r.Form.Get("other")), "/humans/"))
+	if err != nil {
+		if errors.Is(err, humandao.ErrHumanNotFound) {
+			return NewNotFoundError(err)
+		}
+		return NewInternalServerError(err)
+	}
+
+	t, fromSide := parseRelationshipChoice(r.Form.Get("relationship"))
+	input := humandao.AddRelationshipInput{Type: t, From: human.ID, To: other.ID, Note: r.Form.Get("note")}
+	if !fromSide {
+		input.From, input.To = other.ID, human.ID
+	}
+	if _, err := s.humanDAO.AddRelationship(ctx, input); err != nil {
+		if errors.Is(err, humandao.ErrInvalidRelationship) || errors.Is(err, humandao.ErrRelationshipExists) {
+			return NewBadRequestError(err)
+		}
+		if errors.Is(err, humandao.ErrHumanNotFound) {
+			return NewNotFoundError(err)
+		}
+		return NewInternalServerError(err)
+	}
+
+	w.Header().Add("HX-Redirect", fmt.Sprintf("/humans/%s", human.Path))
+	return nil
+}
+
+// HandlerRelationshipRemove unlinks two humans.
+func (s *ServerHTML) HandlerRelationshipRemove(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		return NewUnauthorizedError(err)
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+
+	relationshipID, err := url.PathUnescape(chi.URLParamFromCtx(ctx, "relationshipID"))
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	if err := s.humanDAO.RemoveRelationship(ctx, humandao.RemoveRelationshipInput{RelationshipID: relationshipID}); err != nil {
+		if errors.Is(err, humandao.ErrRelationshipNotFound) {
+			return NewNotFoundError(err)
+		}
+		return NewInternalServerError(err)
+	}
+
+	w.Header().Add("HX-Redirect", fmt.Sprintf("/humans/%s", chi.URLParamFromCtx(ctx, "id")))
+	return nil
+}
+
+type GraphNode struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Thumbnail string `json:"thumbnail,omitempty"`
+	// Depth is how many relationships away from the human the graph is about this one is.
+	Depth int `json:"depth"`
+}
+
+type GraphEdge struct {
+	ID   string                    `json:"id"`
+	Type humandao.RelationshipType `json:"type"`
+	From string                    `json:"from"`
+	To   string                    `json:"to"`
+	Note string                    `json:"note,omitempty"`
+}
+
+// Graph is the neighbourhood of a human: the humans they are related to, and the humans those
+// are related to, up to the requested depth.
+type Graph struct {
+	Nodes []GraphNode `json:"nodes"`
+	Edges []GraphEdge `json:"edges"`
+	// Truncated is set when humans were left out to stay under maxGraphNodes.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// HandlerHumanGraph returns the graph around a human as JSON, for drawing it. The depth query
+// parameter defaults to 1 and is at most maxGraphDepth.
+func (s *ServerHTML) HandlerHumanGraph(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ctx   = r.Context()
+		token = s.parseOptionalToken(r)
+		admin = IsAdmin(token)
+		depth = 1
+	)
+
+	if raw := r.URL.Query().Get("depth"); raw != "" {
+		var err error
+		depth, err = strconv.Atoi(raw)
+		if err != nil || depth < 1 || depth > maxGraphDepth {
+			return NewBadRequestError(fmt.Errorf("depth must be between 1 and %d", maxGraphDepth))
+		}
+	}
+
+	path, err := url.PathUnescape(chi.URLParamFromCtx(ctx, "id"))
+	if err != nil {
+		return NewBadRequestError(err)
+	}
+	lookup := s.humanLookup(admin)
+	human, err := s.resolveHuman(ctx, path)
+	if err != nil && !errors.Is(err, humandao.ErrHumanNotFound) {
+		return NewInternalServerError(err)
+	}
+	root, ok := lookup(human.ID)
+	if !ok {
+		return NewNotFoundError(fmt.Errorf("%w: %v", humandao.ErrHumanNotFound, path))
+	}
+
+	graph, err := s.graph(ctx, root, depth, lookup)
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+	if err := json.NewEncoder(w).Encode(graph); err != nil {
+		s.logger.Error().Err(err).Msg("unable to encode graph")
+	}
+	return nil
+}
+
+// graph walks the relationships of root breadth first, leaving out humans that lookup can't find.
+func (s *ServerHTML) graph(ctx context.Context, root humandao.Human, depth int, lookup func(id string) (humandao.Human, bool)) (Graph, error) {
+	graph := Graph{Nodes: []GraphNode{graphNode(root, 0)}, Edges: []GraphEdge{}}
+	seen := map[string]bool{root.ID: true}
+	seenEdges := make(map[string]bool)
+
+	frontier := []string{root.ID}
+	for d := 1; d <= depth && len(frontier) > 0; d++ {
+		var next []string
+		for _, id := range frontier {
+			relationships, err := s.humanDAO.Relationships(ctx, humandao.RelationshipsInput{HumanID: id})
+			if err != nil {
+				return Graph{}, err
+			}
+			for _, relationship := range relationships {
+				otherID := relationship.Other(id)
+				if !seen[otherID] {
+					other, ok := lookup(otherID)
+					if !ok {
+						continue
+					}
+					if len(graph.Nodes) >= maxGraphNodes {
+						graph.Truncated = true
+						continue
+					}
+					seen[otherID] = true
+					graph.Nodes = append(graph.Nodes, graphNode(other, d))
+					next = append(next, otherID)
+				}
+				if seenEdges[relationship.ID] {
+					continue
+				}
+				seenEdges[relationship.ID] = true
+				graph.Edges = append(graph.Edges, GraphEdge{
+					ID:   relationship.ID,
+					Type: relationship.Type,
+					From: relationship.From,
+					To:   relationship.To,
+					Note: relationship.Note,
+				})
+			}
+		}
+		frontier = next
+	}
+
+	return graph, nil
+}
+
+func graphNode(human humandao.Human, depth int) GraphNode {
+	return GraphNode{
+		ID:        human.ID,
+		Name:      human.Name,
+		Path:      human.Path,
+		Thumbnail: human.Images.Thumbnail,
+		Depth:     depth,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
@@ -60,6 +61,31 @@ func (s *ServerHTML) HandlerSitemap(w http.ResponseWriter, r *http.Request) erro
 		Priority:   0.8,
 	})
 
+	// Add tags, leaving out the ones no one has yet
+	tagStats, err := s.getTagStats(r.Context())
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+	urls = append(urls, URL{
+		Loc:        "https://asianamericans.wiki/tags",
+		ChangeFreq: "weekly",
+		Priority:   0.6,
+	})
+	slugs := make([]string, 0, len(tagStats))
+	for slug, stats := range tagStats {
+		if stats.Count > 0 {
+			slugs = append(slugs, slug)
+		}
+	}
+	sort.Strings(slugs)
+	for _, slug := range slugs {
+		urls = append(urls, URL{
+			Loc:        fmt.Sprintf("https://asianamericans.wiki/tags/%s", slug),
+			ChangeFreq: "weekly",
+			Priority:   0.6,
+		})
+	}
+
 	// Add individual humans
 	for _, human := range humans {
 		if human.Draft {
@@ -0,0 +1,470 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+// taxonomyTTL is how long the tag taxonomy is cached for. Changes made on this server reset it
+// right away, but other servers only pick them up once it expires.
+const taxonomyTTL = 5 * time.Minute
+
+// getTaxonomy returns the tags humans can be given.
+func (s *ServerHTML) getTaxonomy(ctx context.Context) (humandao.Taxonomy, error) {
+	s.lock.Lock()
+	taxonomy, loadedAt := s.taxonomy, s.taxonomyLoadedAt
+	s.lock.Unlock()
+	if taxonomy != nil && time.Since(loadedAt) < taxonomyTTL {
+		return taxonomy, nil
+	}
+
+	taxonomy, err := s.humanDAO.Tags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get tags: %w", err)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.taxonomy, s.taxonomyLoadedAt = taxonomy, time.Now()
+	s.tagStats = nil
+	return taxonomy, nil
+}
+
+func (s *ServerHTML) resetTaxonomy() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.taxonomy = nil
+	s.tagStats = nil
+}
+
+// getTags returns the tags of the taxonomy, along with any tags the humans have that aren't in
+// it, so the forms don't drop tags humans were given before the taxonomy was enforced.
+func getTags(taxonomy humandao.Taxonomy, humans ...humandao.Human) []string {
+	uniqueTags := make(map[string]struct{}, len(taxonomy))
+	for _, tag := range taxonomy.Names() {
+		uniqueTags[tag] = struct{}{}
+	}
+	for _, human := range humans {
+		for _, tag := range human.Tags {
+			uniqueTags[tag] = struct{}{}
+		}
+	}
+	tags := make([]string, 0, len(uniqueTags))
+	for tag := range uniqueTags {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// AdminTag is a tag of the taxonomy, with how many humans have it.
+type AdminTag struct {
+	humandao.Tag
+	Count int
+}
+
+type HTMLResponseAdminTags struct {
+	Base
+	Tags []AdminTag
+	// Unknown are the tags humans have that aren't in the taxonomy, with how many have each.
+	Unknown []TagCount
+}
+
+// HandlerAdminTags lists the tags of the taxonomy, to be created, edited, renamed and merged.
+func (s *ServerHTML) HandlerAdminTags(w http.ResponseWriter, r *http.Request) error {
+	token, err := s.parseToken(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+
+	taxonomy, err := s.humanDAO.Tags(r.Context())
+	if err != nil {
+		return NewInternalServerError(fmt.Errorf("unable to get tags: %w", err))
+	}
+
+	counts := make(map[string]int)
+	s.lock.Lock()
+	for _, human := range s.humans {
+		for _, tag := range human.Tags {
+			counts[tag]++
+		}
+	}
+	s.lock.Unlock()
+
+	response := HTMLResponseAdminTags{Base: getBase(s, admin)}
+	for _, tag := range taxonomy {
+		response.Tags = append(response.Tags, AdminTag{Tag: tag, Count: counts[tag.Name]})
+		delete(counts, tag.Name)
+	}
+	for tag, count := range counts {
+		response.Unknown = append(response.Unknown, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(response.Unknown, func(i, j int) bool { return response.Unknown[i].Tag < response.Unknown[j].Tag })
+
+	if err := s.template.ExecuteTemplate(w, "admin-tags.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute admin-tags template")
+	}
+
+	return nil
+}
+
+// tagError maps the errors of changing the taxonomy to the status they are returned with.
+func tagError(err error) error {
+	if errors.Is(err, humandao.ErrTagNotFound) {
+		return NewNotFoundError(err)
+	}
+	if errors.Is(err, humandao.ErrInvalidTag) || errors.Is(err, humandao.ErrTagExists) {
+		return NewBadRequestError(err)
+	}
+	return NewInternalServerError(err)
+}
+
+// changeTags runs change as the admin making the request, then sends them back to the tags page.
+func (s *ServerHTML) changeTags(w http.ResponseWriter, r *http.Request, change func(ctx context.Context) (humandao.Tag, error)) error {
+	token, err := s.parseToken(r)
+	if err != nil {
+		return NewUnauthorizedError(err)
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return NewBadRequestError(fmt.Errorf("invalid form received: %w", err))
+	}
+
+	tag, err := change(humandao.WithAuthor(r.Context(), token.UID))
+	if err != nil {
+		return tagError(err)
+	}
+	s.resetTaxonomy()
+
+	s.logger.Info().Str("tag", tag.Name).Str("by", token.UID).Msg("successfully changed tag")
+	w.Header().Add("HX-Redirect", "/admin/tags")
+	return nil
+}
+
+// HandlerAdminTagSave creates a tag, or updates the parent, synonyms and description of one.
+func (s *ServerHTML) HandlerAdminTagSave(w http.ResponseWriter, r *http.Request) error {
+	return s.changeTags(w, r, func(ctx context.Context) (humandao.Tag, error) {
+		var synonyms []string
+		for _, synonym := range strings.Split(r.FormValue("synonyms"), ",") {
+			if synonym = strings.TrimSpace(synonym); synonym != "" {
+				synonyms = append(synonyms, synonym)
+			}
+		}
+		return s.humanDAO.SaveTag(ctx, humandao.SaveTagInput{Tag: humandao.Tag{
+			Name:        r.FormValue("name"),
+			Parent:      r.FormValue("parent"),
+			Synonyms:    synonyms,
+			Description: strings.TrimSpace(r.FormValue("description")),
+		}})
+	})
+}
+
+// HandlerAdminTagRename renames a tag, and every human that has it.
+func (s *ServerHTML) HandlerAdminTagRename(w http.ResponseWriter, r *http.Request) error {
+	return s.changeTags(w, r, func(ctx context.Context) (humandao.Tag, error) {
+		return s.humanDAO.RenameTag(ctx, humandao.RenameTagInput{From: r.FormValue("from"), To: r.FormValue("to")})
+	})
+}
+
+// HandlerAdminTagMerge merges a tag into another, and gives the humans that had it the other.
+func (s *ServerHTML) HandlerAdminTagMerge(w http.ResponseWriter, r *http.Request) error {
+	return s.changeTags(w, r, func(ctx context.Context) (humandao.Tag, error) {
+		return s.humanDAO.MergeTags(ctx, humandao.MergeTagsInput{From: r.FormValue("from"), Into: r.FormValue("into")})
+	})
+}
+
+const (
+	tagPeople      = 10
+	tagRelated     = 10
+	tagPageSize    = 24
+	trendingMinAge = 7 * 24 * time.Hour
+)
+
+// TagStats describes the published humans with a tag, including those with a kind of it.
+type TagStats struct {
+	Tag   humandao.Tag
+	Slug  string
+	Count int
+	// Top are the most viewed humans, and Trending the ones viewed the most for how long
+	// they've been published.
+	Top      []humandao.Human
+	Trending []humandao.Human
+	// Related are the other tags the humans have, the most shared first.
+	Related []TagCount
+	// Humans are all of them, by name.
+	Humans []humandao.Human
+}
+
+// computeTagStats returns the stats of every tag of the taxonomy, by slug.
+func computeTagStats(humans []humandao.Human, taxonomy humandao.Taxonomy, now time.Time) map[string]TagStats {
+	published := make([]humandao.Human, 0, len(humans))
+	for _, human := range humans {
+		if !human.Draft {
+			human.Path = "/humans/" + human.Path
+			published = append(published, human)
+		}
+	}
+
+	stats := make(map[string]TagStats, len(taxonomy))
+	for _, tag := range taxonomy {
+		members := humandao.ApplyFilters(published, taxonomy.ByTags(tag.Name))
+		byName := slices.Clone(members)
+		slices.SortStableFunc(byName, func(a, b humandao.Human) int { return cmp.Compare(a.Name, b.Name) })
+		stats[humandao.Slug(tag.Name)] = TagStats{
+			Tag:      tag,
+			Slug:     humandao.Slug(tag.Name),
+			Count:    len(members),
+			Top:      notable(members),
+			Trending: trending(members, now),
+			Related:  relatedTags(members, taxonomy, tag.Name),
+			Humans:   byName,
+		}
+	}
+	return stats
+}
+
+// trending sorts the humans by their views per day since they were published. Humans published
+// in the last week count as a week old, so a few views on the first day don't put them on top.
+func trending(humans []humandao.Human, now time.Time) []humandao.Human {
+	perDay := func(h humandao.Human) float64 {
+		published := h.PublishedAt
+		if published.IsZero() {
+			published = h.CreatedAt
+		}
+		age := max(now.Sub(published), trendingMinAge)
+		return float64(h.Views) / age.Hours() * 24
+	}
+	sorted := slices.Clone(humans)
+	slices.SortStableFunc(sorted, func(a, b humandao.Human) int {
+		return cmp.Or(cmp.Compare(perDay(b), perDay(a)), cmp.Compare(a.Name, b.Name))
+	})
+	return sorted[:min(len(sorted), tagPeople)]
+}
+
+// relatedTags counts the other tags of the humans. Tags that are a kind of the tag, or that the
+// tag is a kind of, aren't other tags.
+func relatedTags(humans []humandao.Human, taxonomy humandao.Taxonomy, name string) []TagCount {
+	counts := make(map[string]int)
+	for _, human := range humans {
+		for _, other := range human.Tags {
+			if taxonomy.Is(other, name) || taxonomy.Is(name, other) {
+				continue
+			}
+			counts[other]++
+		}
+	}
+	related := make([]TagCount, 0, len(counts))
+	for tag, count := range counts {
+		related = append(related, TagCount{Tag: tag, Count: count})
+	}
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Count != related[j].Count {
+			return related[i].Count > related[j].Count
+		}
+		return related[i].Tag < related[j].Tag
+	})
+	return related[:min(len(related), tagRelated)]
+}
+
+// getTagStats returns the stats of every tag by slug. They are computed from s.humans the first
+// time they're asked for, and again after a human or the taxonomy changes.
+func (s *ServerHTML) getTagStats(ctx context.Context) (map[string]TagStats, error) {
+	taxonomy, err := s.getTaxonomy(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.tagStats == nil {
+		s.tagStats = computeTagStats(s.humans, taxonomy, time.Now())
+	}
+	return s.tagStats, nil
+}
+
+// findTag returns the tag of a human with the slug.
+func (s *ServerHTML) findTag(slug string) (string, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, human := range s.humans {
+		for _, tag := range human.Tags {
+			if humandao.Slug(tag) == slug {
+				return tag, true
+			}
+		}
+	}
+	return "", false
+}
+
+// TagGroup is a tag that isn't a kind of another, with the tags that are a kind of it.
+type TagGroup struct {
+	TagStats
+	Children []TagStats
+}
+
+type HTMLResponseTags struct {
+	Base
+	Groups []TagGroup
+}
+
+// HandlerTags lists every tag, under the tag it is a kind of, with how many humans it has.
+func (s *ServerHTML) HandlerTags(w http.ResponseWriter, r *http.Request) error {
+	var (
+		token = s.parseOptionalToken(r)
+		admin = IsAdmin(token)
+	)
+
+	taxonomy, err := s.getTaxonomy(r.Context())
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+	stats, err := s.getTagStats(r.Context())
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+
+	var groups []TagGroup
+	for _, tag := range taxonomy {
+		if tag.Parent != "" {
+			continue
+		}
+		group := TagGroup{TagStats: stats[humandao.Slug(tag.Name)]}
+		var descendants func(name string)
+		descendants = func(name string) {
+			for _, child := range taxonomy.Children(name) {
+				group.Children = append(group.Children, stats[humandao.Slug(child.Name)])
+				descendants(child.Name)
+			}
+		}
+		descendants(tag.Name)
+		groups = append(groups, group)
+	}
+
+	response := HTMLResponseTags{
+		Base:   getBase(s, admin),
+		Groups: groups,
+	}
+	if err := s.template.ExecuteTemplate(w, "tags.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute tags template")
+	}
+
+	return nil
+}
+
+type HTMLResponseTag struct {
+	Base
+	TagStats
+	Parent   *TagStats
+	Children []TagStats
+	// Page is the page of Humans shown, from 1 to Pages.
+	Page  int
+	Pages int
+}
+
+// PrevPage and NextPage are 0 when there is no page before or after the one shown.
+func (r HTMLResponseTag) PrevPage() int {
+	if r.Page > 1 {
+		return r.Page - 1
+	}
+	return 0
+}
+
+func (r HTMLResponseTag) NextPage() int {
+	if r.Page < r.Pages {
+		return r.Page + 1
+	}
+	return 0
+}
+
+// HandlerTag shows the humans with a tag, a page at a time, along with the most popular of them
+// and the tags they share.
+func (s *ServerHTML) HandlerTag(w http.ResponseWriter, r *http.Request) error {
+	var (
+		ctx   = r.Context()
+		token = s.parseOptionalToken(r)
+		admin = IsAdmin(token)
+		name  = chi.URLParamFromCtx(ctx, "tag")
+		page  = 1
+	)
+	if p := r.URL.Query().Get("page"); p != "" {
+		parsed, err := strconv.Atoi(p)
+		if err != nil || parsed < 1 {
+			return NewBadRequestError(fmt.Errorf("invalid page %q", p))
+		}
+		page = parsed
+	}
+
+	taxonomy, err := s.getTaxonomy(ctx)
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+	stats, err := s.getTagStats(ctx)
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+
+	tagStats, ok := stats[name]
+	if !ok {
+		// the tag is written another way, or is a synonym of a tag
+		tag, ok := taxonomy.Get(strings.ReplaceAll(name, "-", " "))
+		if !ok {
+			tag, ok = taxonomy.Get(name)
+		}
+		if ok {
+			http.Redirect(w, r, "/tags/"+humandao.Slug(tag.Name), http.StatusMovedPermanently)
+			return nil
+		}
+		// humans given the tag before the taxonomy was enforced can still be browsed
+		if legacy, ok := s.findTag(name); ok {
+			http.Redirect(w, r, "/humans?tag="+url.QueryEscape(legacy), http.StatusFound)
+			return nil
+		}
+		return NewNotFoundError(fmt.Errorf("unknown tag %v", name))
+	}
+
+	pages := max(1, (len(tagStats.Humans)+tagPageSize-1)/tagPageSize)
+	if page > pages {
+		return NewNotFoundError(fmt.Errorf("tag %v has %v pages", name, pages))
+	}
+	response := HTMLResponseTag{
+		Base:     getBase(s, admin),
+		TagStats: tagStats,
+		Page:     page,
+		Pages:    pages,
+	}
+	response.Humans = tagStats.Humans[(page-1)*tagPageSize : min(page*tagPageSize, len(tagStats.Humans))]
+	if tagStats.Tag.Parent != "" {
+		parent := stats[humandao.Slug(tagStats.Tag.Parent)]
+		response.Parent = &parent
+	}
+	for _, child := range taxonomy.Children(tagStats.Tag.Name) {
+		response.Children = append(response.Children, stats[humandao.Slug(child.Name)])
+	}
+	if err := s.template.ExecuteTemplate(w, "tags-id.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute tags-id template")
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/imageutil"
+)
+
+type HTMLResponseTrash struct {
+	Base
+	Humans []humandao.Human
+}
+
+func (s *ServerHTML) HandlerTrash(w http.ResponseWriter, r *http.Request) error {
+	token, err := s.parseToken(r)
+	if err != nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+
+	humans, _, err := s.humanDAO.Trash(r.Context(), humandao.TrashInput{})
+	if err != nil {
+		return NewInternalServerError(err)
+	}
+
+	response := HTMLResponseTrash{
+		Base:   getBase(s, admin),
+		Humans: humans,
+	}
+	if err := s.template.ExecuteTemplate(w, "admin-trash.html", response); err != nil {
+		s.logger.Error().Err(err).Msg("unable to execute admin-trash template")
+	}
+
+	return nil
+}
+
+// HandlerTrashRestore takes a human out of the trash and puts it back on the site.
+func (s *ServerHTML) HandlerTrashRestore(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		return NewUnauthorizedError(err)
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
+
+	id := chi.URLParamFromCtx(ctx, "id")
+	human, err := s.humanDAO.Undelete(ctx, humandao.UndeleteInput{HumanID: id, UserID: token.UID})
+	if err != nil {
+		if errors.Is(err, humandao.ErrHumanNotFound) {
+			return NewNotFoundError(err)
+		}
+		if errors.Is(err, humandao.ErrHumanAlreadyExists) || errors.Is(err, humandao.ErrHumanMerged) {
+			return NewBadRequestError(err)
+		}
+		return NewInternalServerError(err)
+	}
+
+	if err := s.updateIndex(human); err != nil {
+		s.logger.Error().Err(err).Str("id", human.ID).Msg("unable to update index")
+	}
+
+	s.logger.Info().Str("id", human.ID).Str("name", human.Name).Msg("successfully restored human from trash")
+	w.Header().Add("HX-Redirect", fmt.Sprintf("/humans/%s", human.Path))
+	return nil
+}
+
+// HandlerTrashPurge permanently removes a human in the trash, along with its uploaded images.
+func (s *ServerHTML) HandlerTrashPurge(w http.ResponseWriter, r *http.Request) error {
+	ctx := r.Context()
+	token, err := s.parseToken(r)
+	if err != nil {
+		return NewUnauthorizedError(err)
+	}
+
+	admin := IsAdmin(token)
+	if !admin {
+		return NewForbiddenError(fmt.Errorf("user is not an admin"))
+	}
+
+	id := chi.URLParamFromCtx(ctx, "id")
+	human, err := s.humanDAO.Human(ctx, humandao.HumanInput{HumanID: id, IncludeDeleted: true})
+	if err != nil {
+		if errors.Is(err, humandao.ErrHumanNotFound) {
+			return NewNotFoundError(err)
+		}
+		return NewInternalServerError(err)
+	}
+
+	if err := s.humanDAO.Purge(ctx, humandao.PurgeInput{HumanID: id}); err != nil {
+		if errors.Is(err, humandao.ErrHumanNotFound) {
+			return NewNotFoundError(err)
+		}
+		if errors.Is(err, humandao.ErrHumanNotDeleted) {
+			return NewBadRequestError(err)
+		}
+		return NewInternalServerError(err)
+	}
+
+	// the human is already gone, so a failure here is logged rather than reported as a failed purge.
+	if s.storageClient != nil {
+		inUse, err := s.imagesInUse(ctx, human)
+		if err != nil {
+			s.logger.Error().Err(err).Str("id", id).Msg("unable to check if images of purged human are in use")
+		} else if !inUse {
+			if err := s.uploader.DeleteHumanImages(ctx, id); err != nil {
+				s.logger.Error().Err(err).Str("id", id).Msg("unable to delete images of purged human")
+			}
+		}
+	}
+
+	s.logger.Info().Str("id", id).Msg("successfully purged human")
+	w.Header().Add("HX-Redirect", "/admin/trash")
+	return nil
+}
+
+// imagesInUse reports whether any other human shows the images uploaded for the human. The winner
+// of a merge takes the images of the loser, and can be merged again itself, so every human is
+// checked rather than the one the human was merged into. Drafts and the trash are checked too, since
+// they can be published or restored.
+func (s *ServerHTML) imagesInUse(ctx context.Context, human humandao.Human) (bool, error) {
+	humans, err := humandao.IterateHumans(ctx, s.humanDAO, humandao.ListHumansInput{IncludeDrafts: true}).GetAll()
+	if err != nil {
+		return false, fmt.Errorf("unable to list humans: %w", err)
+	}
+	trashed, _, err := s.humanDAO.Trash(ctx, humandao.TrashInput{})
+	if err != nil {
+		return false, fmt.Errorf("unable to list deleted humans: %w", err)
+	}
+	return slices.ContainsFunc(append(humans, trashed...), func(other humandao.Human) bool {
+		return other.ID != human.ID && imageutil.UsesImagesOf(other, human.ID)
+	}), nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/raymonstah/asianamericanswiki/functions/api"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/imageutil"
 	"github.com/raymonstah/asianamericanswiki/internal/xai"
 )
 
@@ -34,10 +35,7 @@ func (s *ServerHTML) HandlerXAIAdmin(w http.ResponseWriter, r *http.Request) err
 		return NewForbiddenError(fmt.Errorf("user is not an admin"))
 	}
 
-	humans, err := s.humanDAO.ListHumans(r.Context(), humandao.ListHumansInput{
-		Limit:         1000,
-		IncludeDrafts: true,
-	})
+	humans, err := humandao.IterateHumans(r.Context(), s.humanDAO, humandao.ListHumansInput{IncludeDrafts: true}).GetAll()
 	if err != nil {
 		return NewInternalServerError(fmt.Errorf("unable to list humans: %w", err))
 	}
@@ -251,6 +249,7 @@ func (s *ServerHTML) HandlerXAIUpload(w http.ResponseWriter, r *http.Request) er
 	if !admin {
 		return NewForbiddenError(fmt.Errorf("user is not an admin"))
 	}
+	ctx = humandao.WithAuthor(ctx, token.UID)
 
 	if err := r.ParseForm(); err != nil {
 		return NewBadRequestError(err)
@@ -273,8 +272,13 @@ func (s *ServerHTML) HandlerXAIUpload(w http.ResponseWriter, r *http.Request) er
 		return NewInternalServerError(err)
 	}
 
-	human.AIGenerated = true
-	if _, err := s.uploader.UploadHumanImages(ctx, human, raw); err != nil {
+	human, err = s.uploader.UploadHumanImage(ctx, imageutil.UploadHumanImageInput{
+		Human:    human,
+		RawImage: raw,
+		Image:    humandao.Image{AIGenerated: true},
+		Featured: true,
+	})
+	if err != nil {
 		return NewInternalServerError(err)
 	}
 
@@ -4,6 +4,9 @@ const ethnicity = urlParams.get("ethnicity");
 const tag = urlParams.get("tag");
 const gender = urlParams.get("gender");
 const search = urlParams.get("search");
+const award = urlParams.get("award");
+const bornIn = urlParams.get("born_in");
+const basedIn = urlParams.get("based_in");
 
 var ethnicitySelected = document.getElementById("ethnicity");
 if (ethnicity != null) {
@@ -33,6 +36,21 @@ if (tag != null) {
   tagSelected.value = tag;
 }
 
+if (award != null) {
+  var awardSelected = document.getElementById("award");
+  awardSelected.value = award;
+}
+
+if (bornIn != null) {
+  var bornInSelected = document.getElementById("born_in");
+  bornInSelected.value = bornIn;
+}
+
+if (basedIn != null) {
+  var basedInSelected = document.getElementById("based_in");
+  basedInSelected.value = basedIn;
+}
+
 var searchInput = document.getElementById("search");
 if (search != null) {
   searchInput.value = search;
@@ -46,6 +64,9 @@ function doSearch() {
   var minAgeSelected = document.getElementById("minAge");
   var maxAgeSelected = document.getElementById("maxAge");
   var tagSelected = document.getElementById("tags");
+  var awardSelected = document.getElementById("award");
+  var bornInSelected = document.getElementById("born_in");
+  var basedInSelected = document.getElementById("based_in");
   const params = new URLSearchParams(
     removeEmpty({
       dobBefore: convertToYYYYMMDDString(minAgeSelected.value),
@@ -53,6 +74,9 @@ function doSearch() {
       gender: genderSelected.value,
       ethnicity: ethnicitySelected.value,
       tag: tagSelected.value,
+      award: awardSelected.value,
+      born_in: bornInSelected.value,
+      based_in: basedInSelected.value,
       search: searchInput.value,
     })
   );
@@ -13,10 +13,11 @@ import (
 	"sync"
 	"time"
 
-	"cloud.google.com/go/firestore"
 	"cloud.google.com/go/storage"
 	"firebase.google.com/go/v4/auth"
 	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	blevesearch "github.com/blevesearch/bleve/v2/search"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/httplog"
 	"github.com/raymonstah/asianamericanswiki/functions/api"
@@ -36,24 +37,33 @@ var maxMemoryMB = int64(10 << 20) // 10MB
 type ServerHTML struct {
 	local         bool
 	authClient    Authorizer
-	humanDAO      *humandao.DAO
+	humanDAO      humandao.HumanStore
 	logger        zerolog.Logger
 	template      *template.Template
 	storageClient *storage.Client
 	storageURL    string
 	xaiClient     *xai.Client
 	uploader      *imageutil.Uploader
+	// feed is the changes to the published humans, which the API watches.
+	feed *humanFeed
 
 	index  bleve.Index
 	humans []humandao.Human
 	lock   sync.Mutex
+	// ethnicityStats and tagStats are computed from humans when they're first needed, and reset
+	// when humans change.
+	ethnicityStats map[string]EthnicityStats
+	tagStats       map[string]TagStats
+	// taxonomy is cached for taxonomyTTL from taxonomyLoadedAt.
+	taxonomy         humandao.Taxonomy
+	taxonomyLoadedAt time.Time
 
 	firebaseConfig FirebaseConfig
 }
 
 type ServerHTMLConfig struct {
 	Local          bool
-	HumanDAO       *humandao.DAO
+	HumanDAO       humandao.HumanStore
 	Logger         zerolog.Logger
 	AuthClient     Authorizer
 	StorageClient  *storage.Client
@@ -78,6 +88,7 @@ func NewServerHTML(conf ServerHTMLConfig) *ServerHTML {
 		storageURL:     storageURL,
 		xaiClient:      conf.XAIClient,
 		uploader:       uploader,
+		feed:           newHumanFeed(),
 		firebaseConfig: conf.FirebaseConfig,
 	}
 }
@@ -87,17 +98,20 @@ func (s *ServerHTML) initializeIndex(ctx context.Context) error {
 		s.logger.Info().Dur("elapsed", time.Since(now)).Msg("index initialized")
 	}(time.Now())
 	mapping := bleve.NewIndexMapping()
-	humansMapping := bleve.NewDocumentMapping()
-	mapping.AddDocumentMapping("humans", humansMapping)
+	// names in Chinese, Japanese and Korean aren't separated by spaces, so they are indexed as
+	// bigrams to be found by part of the name too. Humans have no type of their own, so they are
+	// indexed by the default mapping.
+	namesMapping := bleve.NewDocumentMapping()
+	nameValueMapping := bleve.NewTextFieldMapping()
+	nameValueMapping.Analyzer = cjk.AnalyzerName
+	namesMapping.AddFieldMappingsAt("Value", nameValueMapping)
+	mapping.DefaultMapping.AddSubDocumentMapping("Names", namesMapping)
 	index, err := bleve.NewMemOnly(mapping)
 	if err != nil {
 		return err
 	}
 
-	humans, err := s.humanDAO.ListHumans(ctx, humandao.ListHumansInput{
-		Limit:         500,
-		IncludeDrafts: true,
-	})
+	humans, err := humandao.IterateHumans(ctx, s.humanDAO, humandao.ListHumansInput{IncludeDrafts: true}).GetAll()
 	if err != nil {
 		return err
 	}
@@ -112,10 +126,16 @@ func (s *ServerHTML) initializeIndex(ctx context.Context) error {
 	defer s.lock.Unlock()
 	s.index = index
 	s.humans = humans
+	s.ethnicityStats = nil
+	s.tagStats = nil
 	return nil
 }
 
 func (s *ServerHTML) updateIndex(human humandao.Human) error {
+	if human.Deleted() {
+		return s.deleteFromIndex(human.ID)
+	}
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
@@ -136,6 +156,8 @@ func (s *ServerHTML) updateIndex(human humandao.Human) error {
 	if !found {
 		s.humans = append(s.humans, human)
 	}
+	s.ethnicityStats = nil
+	s.tagStats = nil
 
 	return nil
 }
@@ -156,13 +178,75 @@ func (s *ServerHTML) deleteFromIndex(id string) error {
 			break
 		}
 	}
+	s.ethnicityStats = nil
+	s.tagStats = nil
 
 	return nil
 }
 
+// publishedHumans returns a copy of the humans that aren't drafts.
+func (s *ServerHTML) publishedHumans() []humandao.Human {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	humans := make([]humandao.Human, 0, len(s.humans))
+	for _, human := range s.humans {
+		if !human.Draft {
+			humans = append(humans, human)
+		}
+	}
+	return humans
+}
+
+// search returns the IDs of up to size humans that match the search, the best matches first, or
+// of every human that does when size is 0. Drafts are indexed too, so they can be among them.
+func (s *ServerHTML) search(search string, size int) ([]string, error) {
+	hits, err := s.searchHits(search, size)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	return ids, nil
+}
+
+// searchScores returns how well each human that matches the search matches it, by ID.
+func (s *ServerHTML) searchScores(search string) (map[string]float64, error) {
+	hits, err := s.searchHits(search, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	scores := make(map[string]float64, len(hits))
+	for _, hit := range hits {
+		scores[hit.ID] = hit.Score
+	}
+	return scores, nil
+}
+
+// searchHits returns the best size matches of the search, or every match when size is 0.
+func (s *ServerHTML) searchHits(search string, size int) (blevesearch.DocumentMatchCollection, error) {
+	searchReq := bleve.NewSearchRequest(searchQuery(search))
+
+	s.lock.Lock()
+	searchReq.Size = size
+	if size == 0 {
+		searchReq.Size = len(s.humans)
+	}
+	result, err := s.index.Search(searchReq)
+	s.lock.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("unable to search humans: %w", err)
+	}
+	return result.Hits, nil
+}
+
 func (s *ServerHTML) watchHumans(ctx context.Context) {
 	it := s.humanDAO.Snapshots(ctx)
 	defer it.Stop()
+	defer s.feed.close()
 
 	for {
 		snap, err := it.Next()
@@ -175,24 +259,19 @@ func (s *ServerHTML) watchHumans(ctx context.Context) {
 		}
 
 		for _, change := range snap.Changes {
-			var human humandao.Human
-			if err := change.Doc.DataTo(&human); err != nil {
-				s.logger.Error().Err(err).Msg("error decoding human from firestore change")
-				continue
-			}
-			human.ID = change.Doc.Ref.ID
-
+			human := change.Human
 			switch change.Kind {
-			case firestore.DocumentAdded, firestore.DocumentModified:
+			case humandao.ChangeAdded, humandao.ChangeModified:
 				if err := s.updateIndex(human); err != nil {
 					s.logger.Error().Err(err).Str("id", human.ID).Msg("error updating index from watch")
 				}
-			case firestore.DocumentRemoved:
-				if err := s.deleteFromIndex(change.Doc.Ref.ID); err != nil {
-					s.logger.Error().Err(err).Str("id", change.Doc.Ref.ID).Msg("error deleting from index from watch")
+			case humandao.ChangeRemoved:
+				if err := s.deleteFromIndex(human.ID); err != nil {
+					s.logger.Error().Err(err).Str("id", human.ID).Msg("error deleting from index from watch")
 				}
 			}
 		}
+		s.feed.apply(snap.Changes)
 	}
 }
 
@@ -218,7 +297,14 @@ func (s *ServerHTML) Register(router chi.Router) error {
 			"slicesContains": slicesContain,
 			"year":           time.Now().Year,
 			"imagePrompt":    xai.DefaultImagePrompt,
+			"imageLicenses":  func() []string { return humandao.ImageLicenses },
 			"join":           strings.Join,
+			"sourceFields":   func() []string { return humandao.SourceFields },
+			"workTypes":      func() []humandao.WorkType { return humandao.WorkTypes },
+			"awardResults":   func() []humandao.AwardResult { return humandao.AwardResults },
+			"nameTypes":      func() []humandao.NameType { return humandao.NameTypes },
+			"inc":            func(i int) int { return i + 1 },
+			"slug":           humandao.Slug,
 			"nl2br": func(text string) template.HTML {
 				return template.HTML(strings.ReplaceAll(template.HTMLEscapeString(text), "\n", "<br>"))
 			},
@@ -236,13 +322,28 @@ func (s *ServerHTML) Register(router chi.Router) error {
 	router.Get("/sitemap.xml", HttpHandler(s.HandlerSitemap).Serve(s.HandlerError))
 	router.Get("/robots.txt", HttpHandler(s.HandlerRobots).Serve(s.HandlerError))
 	router.Get("/humans", HttpHandler(s.HandlerHumans).Serve(s.HandlerError))
+	router.Get("/awards/{award}", HttpHandler(s.HandlerAward).Serve(s.HandlerError))
+	router.Get("/map", HttpHandler(s.HandlerMap).Serve(s.HandlerError))
+	router.Get("/ethnicities", HttpHandler(s.HandlerEthnicities).Serve(s.HandlerError))
+	router.Get("/ethnicities/{ethnicity}", HttpHandler(s.HandlerEthnicity).Serve(s.HandlerError))
+	router.Get("/tags", HttpHandler(s.HandlerTags).Serve(s.HandlerError))
+	router.Get("/tags/{tag}", HttpHandler(s.HandlerTag).Serve(s.HandlerError))
 	router.Get("/search/suggest", HttpHandler(s.HandlerSearchSuggest).Serve(s.HandlerError))
 	router.Get("/humans/{id}", HttpHandler(s.HandlerHuman).Serve(s.HandlerError))
 	router.Post("/humans", HttpHandler(s.HandlerHumanAdd).Serve(s.HandlerError))
 	router.Post("/humans/{id}", HttpHandler(s.HandlerHumanUpdate).Serve(s.HandlerError))
 	router.Get("/humans/{id}/edit", HttpHandler(s.HandlerHumanEdit).Serve(s.HandlerError))
+	router.Get("/humans/{id}/history", HttpHandler(s.HandlerHumanHistory).Serve(s.HandlerError))
+	router.Get("/humans/{id}/history/{revisionID}", HttpHandler(s.HandlerHumanRevision).Serve(s.HandlerError))
+	router.Post("/humans/{id}/history/{revisionID}/restore", HttpHandler(s.HandlerHumanRestore).Serve(s.HandlerError))
 	router.Post("/humans/{id}/publish", HttpHandler(s.HandlerPublish).Serve(s.HandlerError))
 	router.Delete("/humans/{id}", HttpHandler(s.HandlerHumanDelete).Serve(s.HandlerError))
+	router.Post("/humans/{id}/images", HttpHandler(s.HandlerHumanImageAdd).Serve(s.HandlerError))
+	router.Post("/humans/{id}/images/{imageID}/feature", HttpHandler(s.HandlerHumanImageFeature).Serve(s.HandlerError))
+	router.Delete("/humans/{id}/images/{imageID}", HttpHandler(s.HandlerHumanImageDelete).Serve(s.HandlerError))
+	router.Get("/humans/{id}/graph", Handler(s.HandlerHumanGraph).ServeHTTP)
+	router.Post("/humans/{id}/relationships", HttpHandler(s.HandlerRelationshipAdd).Serve(s.HandlerError))
+	router.Delete("/humans/{id}/relationships/{relationshipID}", HttpHandler(s.HandlerRelationshipRemove).Serve(s.HandlerError))
 	router.Get("/login", HttpHandler(s.HandlerLogin).Serve(s.HandlerError))
 	router.Post("/login", HttpHandler(s.HandlerLogin).Serve(s.HandlerError))
 	router.Get("/admin", HttpHandler(s.HandlerAdmin).Serve(s.HandlerError))
@@ -251,6 +352,15 @@ func (s *ServerHTML) Register(router chi.Router) error {
 	router.Get("/admin/xai/human/{id}", HttpHandler(s.HandlerXAIHuman).Serve(s.HandlerError))
 	router.Post("/admin/xai/generate", HttpHandler(s.HandlerXAIGenerate).Serve(s.HandlerError))
 	router.Post("/admin/xai/upload", HttpHandler(s.HandlerXAIUpload).Serve(s.HandlerError))
+	router.Get("/admin/merge", HttpHandler(s.HandlerMerge).Serve(s.HandlerError))
+	router.Post("/admin/merge", HttpHandler(s.HandlerMergeSubmit).Serve(s.HandlerError))
+	router.Get("/admin/trash", HttpHandler(s.HandlerTrash).Serve(s.HandlerError))
+	router.Post("/admin/trash/{id}/restore", HttpHandler(s.HandlerTrashRestore).Serve(s.HandlerError))
+	router.Delete("/admin/trash/{id}", HttpHandler(s.HandlerTrashPurge).Serve(s.HandlerError))
+	router.Get("/admin/tags", HttpHandler(s.HandlerAdminTags).Serve(s.HandlerError))
+	router.Post("/admin/tags", HttpHandler(s.HandlerAdminTagSave).Serve(s.HandlerError))
+	router.Post("/admin/tags/rename", HttpHandler(s.HandlerAdminTagRename).Serve(s.HandlerError))
+	router.Post("/admin/tags/merge", HttpHandler(s.HandlerAdminTagMerge).Serve(s.HandlerError))
 	router.Post("/admin/refresh-index", HttpHandler(s.HandlerRefreshIndex).Serve(s.HandlerError))
 	router.Post("/admin/humans/{id}/refresh-index", HttpHandler(s.HandlerRefreshHumanIndex).Serve(s.HandlerError))
 
@@ -2,14 +2,21 @@ package server
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	firebase "firebase.google.com/go/v4"
 	"github.com/raymonstah/asianamericanswiki/functions/api"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
 	"github.com/tj/assert"
 )
 
@@ -91,3 +98,702 @@ func Test_HTMLServer(t *testing.T) {
 		})
 	}
 }
+
+func Test_HTMLServer_RenamedHuman(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(humandao.Human{
+			ID:            "nora",
+			Name:          "Nora Lum",
+			Path:          "nora-lum",
+			PreviousPaths: []string{"awkwafina"},
+		}),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans/awkwafina", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMovedPermanently, w.Result().StatusCode)
+	assert.Equal(t, "/humans/nora-lum", w.Result().Header.Get("Location"))
+
+	req = httptest.NewRequest(http.MethodGet, "/humans/nora-lum", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+}
+
+func Test_HTMLServer_DeletedHuman(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{ID: "nora", Name: "Nora Lum", Path: "nora-lum"},
+			humandao.Human{ID: "bruce", Name: "Bruce Lee", Path: "bruce-lee", DeletedAt: time.Now(), DeletedBy: "admin"},
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans/bruce-lee", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+
+	req = httptest.NewRequest(http.MethodGet, "/sitemap.xml", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "/humans/nora-lum")
+	assert.NotContains(t, w.Body.String(), "/humans/bruce-lee")
+}
+
+func Test_HTMLServer_Sources(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(humandao.Human{
+			ID:          "nora",
+			Name:        "Nora Lum",
+			Path:        "nora-lum",
+			Description: "Rapper and actress.",
+			Sources: []humandao.Source{
+				{URL: "https://example.com/nora", Title: "Nora Lum profile", Publisher: "Example", Fields: []string{"description"}},
+			},
+		}),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans/nora-lum", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, `href="#source-1"`)
+	assert.Contains(t, body, `id="source-1"`)
+	assert.Contains(t, body, "Nora Lum profile")
+}
+
+func Test_HTMLServer_Connections(t *testing.T) {
+	ctx := context.Background()
+	dao := humandao.NewMemoryDAO(
+		humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong"},
+		humandao.Human{ID: "randall", Name: "Randall Park", Path: "randall-park"},
+		humandao.Human{ID: "bruce", Name: "Bruce Lee", Path: "bruce-lee"},
+		humandao.Human{ID: "draft", Name: "Not Yet", Path: "not-yet", Draft: true},
+	)
+	for _, input := range []humandao.AddRelationshipInput{
+		{Type: humandao.RelationshipCollaborator, From: "ali", To: "randall", Note: "Always Be My Maybe"},
+		{Type: humandao.RelationshipInfluencedBy, From: "randall", To: "bruce"},
+		{Type: humandao.RelationshipFamily, From: "ali", To: "draft"},
+	} {
+		_, err := dao.AddRelationship(ctx, input)
+		assert.NoError(t, err)
+	}
+	s := NewServer(Config{HumanDAO: dao})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans/ali-wong", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, "Connections")
+	assert.Contains(t, body, `href="/humans/randall-park"`)
+	assert.Contains(t, body, "Always Be My Maybe")
+	assert.NotContains(t, body, "Not Yet")
+
+	req = httptest.NewRequest(http.MethodGet, "/humans/ali-wong/graph?depth=2", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	var graph Graph
+	assert.NoError(t, json.NewDecoder(w.Body).Decode(&graph))
+	var names []string
+	for _, node := range graph.Nodes {
+		names = append(names, node.Name)
+	}
+	assert.Equal(t, []string{"Ali Wong", "Randall Park", "Bruce Lee"}, names)
+	assert.Equal(t, 2, len(graph.Edges))
+	assert.Equal(t, 2, graph.Nodes[2].Depth)
+
+	req = httptest.NewRequest(http.MethodGet, "/humans/ali-wong/graph?depth=3", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+}
+
+func Test_HTMLServer_Works(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{
+				ID:   "michelle",
+				Name: "Michelle Yeoh",
+				Path: "michelle-yeoh",
+				Works: []humandao.Work{
+					{Title: "Everything Everywhere All at Once", Type: humandao.WorkFilm, Year: 2022, Credit: "Evelyn Quan Wang"},
+				},
+			},
+			humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong"},
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans/michelle-yeoh", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Evelyn Quan Wang")
+
+	req = httptest.NewRequest(http.MethodGet, "/humans?search="+url.QueryEscape("Everything Everywhere All at Once"), nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Michelle Yeoh")
+	assert.NotContains(t, w.Body.String(), "Ali Wong")
+}
+
+func Test_HTMLServer_Names(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{ID: "michelle", Name: "Michelle Yeoh", Path: "michelle-yeoh", Names: []humandao.NameVariant{
+				{Value: "楊紫瓊", Lang: "zh-Hant", Type: humandao.NameBirth},
+			}},
+			humandao.Human{ID: "steven", Name: "Steven Yeun", Path: "steven-yeun", Names: []humandao.NameVariant{
+				{Value: "연상엽", Lang: "ko", Type: humandao.NameBirth},
+			}},
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans/michelle-yeoh", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), `lang="zh-Hant">楊紫瓊`)
+
+	for _, search := range []string{"楊紫瓊", "紫瓊"} {
+		req = httptest.NewRequest(http.MethodGet, "/humans?search="+url.QueryEscape(search), nil)
+		w = httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), "Michelle Yeoh", search)
+		assert.NotContains(t, w.Body.String(), "Steven Yeun", search)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/humans?search="+url.QueryEscape("연상엽"), nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), "Steven Yeun")
+	assert.NotContains(t, w.Body.String(), "Michelle Yeoh")
+}
+
+func Test_HTMLServer_NamesCJK(t *testing.T) {
+	// both names start with 楊, so only a search by the bigrams of a name tells them apart
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{ID: "michelle", Name: "Michelle Yeoh", Path: "michelle-yeoh", Names: []humandao.NameVariant{
+				{Value: "楊紫瓊", Lang: "zh-Hant", Type: humandao.NameBirth},
+			}},
+			humandao.Human{ID: "miriam", Name: "Miriam Yeung", Path: "miriam-yeung", Names: []humandao.NameVariant{
+				{Value: "楊千嬅", Lang: "zh-Hant", Type: humandao.NameBirth},
+			}},
+		),
+	})
+
+	for search, expected := range map[string]string{"楊紫瓊": "Michelle Yeoh", "紫瓊": "Michelle Yeoh", "楊千嬅": "Miriam Yeung"} {
+		req := httptest.NewRequest(http.MethodGet, "/humans?search="+url.QueryEscape(search), nil)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+		assert.Contains(t, w.Body.String(), expected, search)
+		for _, other := range []string{"Michelle Yeoh", "Miriam Yeung"} {
+			if other != expected {
+				assert.NotContains(t, w.Body.String(), other, search)
+			}
+		}
+	}
+}
+
+func Test_parseNamesForm(t *testing.T) {
+	names := parseNamesForm(url.Values{
+		"name_value": {"楊紫瓊", " ", "Yeoh Choo Kheng"},
+		"name_lang":  {"zh-Hant", "", "ms"},
+		"name_type":  {"birth", "", "romanization"},
+	})
+	assert.Equal(t, []humandao.NameVariant{
+		{Value: "楊紫瓊", Lang: "zh-Hant", Type: humandao.NameBirth},
+		{Value: "Yeoh Choo Kheng", Lang: "ms", Type: humandao.NameRomanization},
+	}, names)
+}
+
+func Test_HTMLServer_Awards(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{ID: "ang", Name: "Ang Lee", Path: "ang-lee", Awards: []humandao.Award{
+				{Name: "Academy Award", Category: "Best Director", Year: 2006, Result: humandao.AwardWon},
+			}},
+			humandao.Human{ID: "michelle", Name: "Michelle Yeoh", Path: "michelle-yeoh", Awards: []humandao.Award{
+				{Name: "Academy Award", Category: "Best Actress", Year: 2023, Result: humandao.AwardWon},
+			}},
+			humandao.Human{ID: "chloe", Name: "Chloe Kim", Path: "chloe-kim", Awards: []humandao.Award{
+				{Name: "Olympic Gold Medal", Year: 2018, Result: humandao.AwardWon},
+			}},
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/awards/academy-award", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.True(t, strings.Index(body, "Ang Lee") < strings.Index(body, "Michelle Yeoh"))
+	assert.NotContains(t, body, "Chloe Kim")
+
+	req = httptest.NewRequest(http.MethodGet, "/humans?award=olympic-gold-medal", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Chloe Kim")
+	assert.NotContains(t, w.Body.String(), "Ang Lee")
+
+	req = httptest.NewRequest(http.MethodGet, "/awards/nobel-prize", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func Test_HTMLServer_Ethnicities(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{ID: "ke", Name: "Ke Huy Quan", Path: "ke-huy-quan", Ethnicity: []string{"vietnamese", "chinese"}},
+			humandao.Human{ID: "bobby", Name: "Bobby Lee", Path: "bobby-lee", Ethnicity: []string{"korean"}},
+			humandao.Human{ID: "hmong", Name: "Sunisa Lee", Path: "sunisa-lee", Ethnicity: []string{"hmong"}},
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans?ethnicity=southeast+asian", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, "Ke Huy Quan")
+	assert.Contains(t, body, "Sunisa Lee")
+	assert.NotContains(t, body, "Bobby Lee")
+	assert.Contains(t, body, `<optgroup label="southeast asian">`)
+}
+
+func Test_HTMLServer_EthnicityPages(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{ID: "ke", Name: "Ke Huy Quan", Path: "ke-huy-quan", Ethnicity: []string{"vietnamese", "chinese"}, Tags: []string{"actor"}},
+			humandao.Human{ID: "bobby", Name: "Bobby Lee", Path: "bobby-lee", Ethnicity: []string{"korean"}},
+			humandao.Human{ID: "draft", Name: "Draft Person", Path: "draft-person", Ethnicity: []string{"vietnamese"}, Draft: true},
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/ethnicities", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), `href="/ethnicities/southeast-asian"`)
+
+	req = httptest.NewRequest(http.MethodGet, "/ethnicities/vietnamese", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, "Ke Huy Quan")
+	assert.NotContains(t, body, "Bobby Lee")
+	assert.NotContains(t, body, "Draft Person")
+	assert.Contains(t, body, `href="/ethnicities/chinese"`)
+	assert.Contains(t, body, `href="/ethnicities/southeast-asian"`)
+
+	req = httptest.NewRequest(http.MethodGet, "/ethnicities/Southeast%20Asian", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusMovedPermanently, w.Result().StatusCode)
+	assert.Equal(t, "/ethnicities/southeast-asian", w.Result().Header.Get("Location"))
+
+	req = httptest.NewRequest(http.MethodGet, "/ethnicities/martian", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func Test_computeEthnicityStats(t *testing.T) {
+	stats := computeEthnicityStats([]humandao.Human{
+		{Name: "A", Path: "a", Ethnicity: []string{"japanese"}, DOB: "1962", Views: 5, Tags: []string{"actor", "comedian"}},
+		{Name: "B", Path: "b", Ethnicity: []string{"okinawan", "white"}, DOB: "1985-03-01", Views: 9, Tags: []string{"actor"}},
+		{Name: "C", Path: "c", Ethnicity: []string{"korean"}, DOB: "1981"},
+	})
+
+	japanese := stats["japanese"]
+	assert.Equal(t, 2, japanese.Count)
+	assert.Equal(t, []TagCount{{Tag: "actor", Count: 2}, {Tag: "comedian", Count: 1}}, japanese.TopTags)
+	assert.Equal(t, "B", japanese.Notable[0].Name)
+	assert.Equal(t, "/humans/b", japanese.Notable[0].Path)
+	assert.Equal(t, []DecadeCount{
+		{Decade: 1960, Count: 1, Percent: 100},
+		{Decade: 1970, Count: 0, Percent: 0},
+		{Decade: 1980, Count: 1, Percent: 100},
+	}, japanese.Decades)
+	assert.Equal(t, 1, len(japanese.Related))
+	assert.Equal(t, "white", japanese.Related[0].Ethnicity.Ethnicity)
+
+	assert.Equal(t, 3, stats["east-asian"].Count)
+	assert.Equal(t, 0, stats["thai"].Count)
+}
+
+func Test_parseSourcesForm(t *testing.T) {
+	sources, err := parseSourcesForm(url.Values{
+		"source_url":       {"https://example.com/a", ""},
+		"source_title":     {"A", ""},
+		"source_publisher": {"Example", ""},
+		"source_accessed":  {"2024-01-02", ""},
+		"source_fields":    {"dob, description", ""},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []humandao.Source{{
+		URL:        "https://example.com/a",
+		Title:      "A",
+		Publisher:  "Example",
+		AccessedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		Fields:     []string{"dob", "description"},
+	}}, sources)
+
+	_, err = parseSourcesForm(url.Values{"source_url": {"https://example.com"}, "source_accessed": {"yesterday"}})
+	assert.Error(t, err)
+}
+
+func Test_parseWorksForm(t *testing.T) {
+	form := url.Values{
+		"work_title":  {"Minari", ""},
+		"work_type":   {"film", "film"},
+		"work_year":   {"2020", ""},
+		"work_credit": {"Jacob Yi", ""},
+		"work_url":    {"", ""},
+	}
+	works, err := parseWorksForm(form)
+	assert.NoError(t, err)
+	assert.Equal(t, []humandao.Work{{Title: "Minari", Type: humandao.WorkFilm, Year: 2020, Credit: "Jacob Yi"}}, works)
+
+	form.Set("work_year", "twenty twenty")
+	_, err = parseWorksForm(form)
+	assert.Error(t, err)
+}
+
+func Test_parseDatesForm(t *testing.T) {
+	dob, dod, err := parseDatesForm(url.Values{"dob": {" 1940-11 "}, "dod": {"1973-07-20"}})
+	assert.NoError(t, err)
+	assert.Equal(t, humandao.PartialDate("1940-11"), dob)
+	assert.Equal(t, humandao.PartialDate("1973-07-20"), dod)
+
+	_, _, err = parseDatesForm(url.Values{"dob": {"11/27/1940"}})
+	assert.Error(t, err)
+}
+
+func Test_HTMLServer_Places(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{ID: "steven", Name: "Steven Yeun", Path: "steven-yeun",
+				BirthPlace: &place.Place{City: "Seoul", Country: "South Korea", Lat: 37.5665, Lng: 126.978},
+				BasedIn:    []place.Place{{City: "Los Angeles", Region: "California", Country: "United States", Lat: 34.0522, Lng: -118.2437}},
+			},
+			humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong",
+				BirthPlace: &place.Place{City: "San Francisco", Region: "California", Country: "United States", Lat: 37.7749, Lng: -122.4194},
+			},
+			humandao.Human{ID: "draft", Name: "Draft Person", Path: "draft-person", Draft: true,
+				BasedIn: []place.Place{{City: "Honolulu", Region: "Hawaii", Country: "United States", Lat: 21.3069, Lng: -157.8583}},
+			},
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans?born_in=south-korea", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, "Steven Yeun")
+	assert.NotContains(t, body, "Ali Wong")
+	assert.NotContains(t, body, "Honolulu")
+
+	req = httptest.NewRequest(http.MethodGet, "/humans?based_in=california", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Steven Yeun")
+	assert.NotContains(t, w.Body.String(), "Ali Wong")
+
+	req = httptest.NewRequest(http.MethodGet, "/humans/steven-yeun", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Seoul, South Korea")
+	assert.Contains(t, w.Body.String(), "Los Angeles, California, United States")
+}
+
+func Test_HTMLServer_Map(t *testing.T) {
+	s := NewServer(Config{
+		HumanDAO: humandao.NewMemoryDAO(
+			humandao.Human{ID: "steven", Name: "Steven Yeun", Path: "steven-yeun",
+				BirthPlace: &place.Place{City: "Seoul", Country: "South Korea", Lat: 37.5665, Lng: 126.978},
+			},
+			humandao.Human{ID: "nowhere", Name: "Nowhere Person", Path: "nowhere-person",
+				BasedIn: []place.Place{{City: "Smallville"}},
+			},
+			humandao.Human{ID: "draft", Name: "Draft Person", Path: "draft-person", Draft: true,
+				BasedIn: []place.Place{{City: "Honolulu", Region: "Hawaii", Country: "United States", Lat: 21.3069, Lng: -157.8583}},
+			},
+		),
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/map", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, "Steven Yeun")
+	assert.NotContains(t, body, "Nowhere Person")
+	assert.NotContains(t, body, "Draft Person")
+}
+
+func Test_parsePlacesForm(t *testing.T) {
+	human := humandao.Human{BasedIn: []place.Place{{City: "Smallville", Region: "Kansas", Country: "United States", Lat: 39.78, Lng: -98.5}}}
+	birthPlace, basedIn := parsePlacesForm(url.Values{
+		"birth_place": {"Seoul, Korea"},
+		"based_in":    {"Smallville, Kansas, United States", " ", "Toronto"},
+	}, human)
+	assert.Equal(t, "Seoul, South Korea", birthPlace.String())
+	assert.True(t, birthPlace.HasCoordinates())
+	assert.Equal(t, 2, len(basedIn))
+	assert.Equal(t, human.BasedIn[0], basedIn[0])
+	assert.Equal(t, "Toronto, Ontario, Canada", basedIn[1].String())
+
+	birthPlace, basedIn = parsePlacesForm(url.Values{}, human)
+	assert.True(t, birthPlace.IsZero())
+	assert.Equal(t, 0, len(basedIn))
+}
+
+func Test_HTMLServer_Tags(t *testing.T) {
+	dao := humandao.NewMemoryDAO(
+		humandao.Human{ID: "awkwafina", Name: "Awkwafina", Path: "awkwafina", Tags: []string{"rapper", "actor"}},
+		humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong", Tags: []string{"comedian", "juggler"}},
+	)
+	s := NewServer(Config{HumanDAO: dao, AuthClient: NoOpAuthorizer{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans?tag=musician", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Awkwafina")
+	assert.NotContains(t, w.Body.String(), "Ali Wong")
+
+	req = httptest.NewRequest(http.MethodGet, "/admin/tags", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "fake-session"})
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), `value="actress, voice actor"`)
+	assert.Contains(t, w.Body.String(), `href="/humans?tag=juggler"`)
+
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: "session", Value: "fake-session"})
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		return w
+	}
+
+	w = post("/admin/tags", url.Values{"name": {"Circus Performer"}, "parent": {"entertainer"}, "synonyms": {"acrobat, juggler"}})
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Equal(t, "/admin/tags", w.Result().Header.Get("HX-Redirect"))
+	human, err := dao.Human(context.Background(), humandao.HumanInput{HumanID: "ali"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"comedian", "circus performer"}, human.Tags)
+
+	w = post("/admin/tags", url.Values{"name": {"stuntman"}, "parent": {"stunts"}})
+	assert.Equal(t, http.StatusBadRequest, w.Result().StatusCode)
+
+	w = post("/admin/tags/merge", url.Values{"from": {"circus performer"}, "into": {"comedian"}})
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	human, err = dao.Human(context.Background(), humandao.HumanInput{HumanID: "ali"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"comedian"}, human.Tags)
+
+	w = post("/admin/tags/rename", url.Values{"from": {"rapper"}, "to": {"hip hop artist"}})
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	human, err = dao.Human(context.Background(), humandao.HumanInput{HumanID: "awkwafina"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hip hop artist", "actor"}, human.Tags)
+
+	w = post("/admin/tags/rename", url.Values{"from": {"ballerina"}, "to": {"dancer"}})
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
+
+func Test_HTMLServer_TagPages(t *testing.T) {
+	humans := []humandao.Human{
+		{ID: "awkwafina", Name: "Awkwafina", Path: "awkwafina", Tags: []string{"rapper", "actor"}},
+		{ID: "ali", Name: "Ali Wong", Path: "ali-wong", Tags: []string{"comedian", "juggler"}},
+		{ID: "draft", Name: "Draft Person", Path: "draft-person", Tags: []string{"rapper"}, Draft: true},
+	}
+	for i := range 30 {
+		humans = append(humans, humandao.Human{ID: fmt.Sprint("singer-", i), Name: fmt.Sprintf("Singer %02d", i), Path: fmt.Sprint("singer-", i), Tags: []string{"singer"}})
+	}
+	s := NewServer(Config{HumanDAO: humandao.NewMemoryDAO(humans...)})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		return w
+	}
+
+	w := get("/tags")
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), `href="/tags/rapper"`)
+
+	w = get("/tags/musician")
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, "Awkwafina")
+	assert.Contains(t, body, "Singer 00")
+	assert.NotContains(t, body, "Singer 29")
+	assert.NotContains(t, body, "Draft Person")
+	assert.Contains(t, body, `href="/tags/actor"`)
+	assert.Contains(t, body, `<link rel="next" href="/tags/musician?page=2" />`)
+
+	w = get("/tags/musician?page=2")
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "Singer 29")
+	assert.Equal(t, http.StatusNotFound, get("/tags/musician?page=3").Result().StatusCode)
+	assert.Equal(t, http.StatusBadRequest, get("/tags/musician?page=0").Result().StatusCode)
+
+	w = get("/tags/actress")
+	assert.Equal(t, http.StatusMovedPermanently, w.Result().StatusCode)
+	assert.Equal(t, "/tags/actor", w.Result().Header.Get("Location"))
+
+	w = get("/tags/juggler")
+	assert.Equal(t, http.StatusFound, w.Result().StatusCode)
+	assert.Equal(t, "/humans?tag=juggler", w.Result().Header.Get("Location"))
+	assert.Equal(t, http.StatusNotFound, get("/tags/unicyclist").Result().StatusCode)
+
+	w = get("/sitemap.xml")
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	assert.Contains(t, w.Body.String(), "<loc>https://asianamericans.wiki/tags/rapper</loc>")
+	assert.NotContains(t, w.Body.String(), "<loc>https://asianamericans.wiki/tags/politician</loc>")
+}
+
+func Test_computeTagStats(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	stats := computeTagStats([]humandao.Human{
+		{Name: "A", Path: "a", Views: 100, PublishedAt: now.AddDate(-1, 0, 0), Tags: []string{"rapper", "actor"}},
+		{Name: "B", Path: "b", Views: 50, PublishedAt: now.AddDate(0, 0, -10), Tags: []string{"singer", "actor"}},
+		{Name: "C", Path: "c", Views: 5, PublishedAt: now.Add(-time.Hour), Tags: []string{"singer", "songwriter"}},
+	}, humandao.DefaultTags, now)
+
+	musician := stats["musician"]
+	assert.Equal(t, 3, musician.Count)
+	assert.Equal(t, "A", musician.Top[0].Name)
+	assert.Equal(t, "/humans/a", musician.Top[0].Path)
+	assert.Equal(t, []string{"B", "C", "A"}, []string{musician.Trending[0].Name, musician.Trending[1].Name, musician.Trending[2].Name})
+	assert.Equal(t, []TagCount{{Tag: "actor", Count: 2}}, musician.Related)
+
+	// songwriter is a kind of musician, like singer, but not a kind of singer
+	assert.Equal(t, []TagCount{{Tag: "actor", Count: 1}, {Tag: "songwriter", Count: 1}}, stats["singer"].Related)
+	assert.Equal(t, 0, stats["politician"].Count)
+}
+
+func Test_HTMLServer_Images(t *testing.T) {
+	ctx := context.Background()
+	images := humandao.Images{}.
+		WithImage(humandao.Image{ID: "portrait", URL: "https://example.com/portrait.webp", Author: "Jane Doe", License: "CC BY 4.0", Caption: "At the premiere", SourceURL: "https://commons.wikimedia.org/wiki/File:Portrait.jpg"}, true).
+		WithImage(humandao.Image{ID: "stage", URL: "https://example.com/stage.webp", AIGenerated: true}, false)
+	dao := humandao.NewMemoryDAO(humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong", Images: images})
+	s := NewServer(Config{HumanDAO: dao, AuthClient: NoOpAuthorizer{}})
+
+	req := httptest.NewRequest(http.MethodGet, "/humans/ali-wong", nil)
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body := w.Body.String()
+	assert.Contains(t, body, "At the premiere")
+	assert.Contains(t, body, "Photo by Jane Doe, CC BY 4.0")
+	assert.Contains(t, body, `href="https://commons.wikimedia.org/wiki/File:Portrait.jpg"`)
+	assert.Contains(t, body, "Gallery")
+	assert.Contains(t, body, "AI-generated image")
+
+	req = httptest.NewRequest(http.MethodGet, "/humans/ali-wong/edit", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "fake-session"})
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode)
+	body = w.Body.String()
+	assert.Contains(t, body, `hx-post="/humans/ali/images/stage/feature"`)
+	assert.Contains(t, body, `<option value="CC BY-SA 4.0">`)
+
+	req = httptest.NewRequest(http.MethodPost, "/humans/ali/images/stage/feature", nil)
+	w = httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Result().StatusCode)
+
+	for _, request := range []struct {
+		method, target string
+		status         int
+	}{
+		{http.MethodPost, "/humans/ali/images/missing/feature", http.StatusNotFound},
+		{http.MethodDelete, "/humans/nobody/images/portrait", http.StatusNotFound},
+		{http.MethodPost, "/humans/ali/images/stage/feature", http.StatusOK},
+		{http.MethodDelete, "/humans/ali/images/portrait", http.StatusOK},
+	} {
+		req = httptest.NewRequest(request.method, request.target, nil)
+		req.AddCookie(&http.Cookie{Name: "session", Value: "fake-session"})
+		w = httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		assert.Equal(t, request.status, w.Result().StatusCode, request.target)
+	}
+	assert.Equal(t, "/humans/ali-wong/edit", w.Result().Header.Get("HX-Redirect"))
+
+	human, err := dao.Human(ctx, humandao.HumanInput{HumanID: "ali"})
+	assert.NoError(t, err)
+	assert.Equal(t, "stage", human.Images.FeaturedID)
+	assert.Equal(t, "https://example.com/stage.webp", human.Images.Featured)
+	assert.Equal(t, 1, len(human.Images.Gallery))
+}
+
+func Test_imageError(t *testing.T) {
+	for err, status := range map[error]int{
+		humandao.ErrImageNotFound:                http.StatusNotFound,
+		humandao.ErrInvalidImage:                 http.StatusBadRequest,
+		&humandao.ConflictError{}:                http.StatusConflict,
+		errors.New("unable to reach the bucket"): http.StatusInternalServerError,
+	} {
+		var response ErrorResponse
+		assert.True(t, errors.As(imageError(err), &response))
+		assert.Equal(t, status, response.Status, err.Error())
+	}
+}
+
+func Test_imagesInUse(t *testing.T) {
+	ctx := context.Background()
+	taken := humandao.Images{}.WithImage(humandao.Image{ID: "one", Object: "taken/one/original.webp", URL: "https://storage.googleapis.com/images/taken/one/original.webp"}, true)
+	copied := humandao.Images{}.WithImage(humandao.Image{ID: "two", Object: "copied/two/original.webp", URL: "https://storage.googleapis.com/images/copied/two/original.webp"}, true)
+	dao := humandao.NewMemoryDAO(
+		humandao.Human{ID: "winner", Name: "Ali Wong"},
+		humandao.Human{ID: "taken", Name: "Ali Wong Duplicate", Images: taken},
+		humandao.Human{ID: "left", Name: "Ali Wong Again", Images: humandao.Images{Featured: "https://storage.googleapis.com/images/left/original.webp"}},
+		humandao.Human{ID: "final", Name: "Ali Wong Final"},
+		humandao.Human{ID: "deleted", Name: "Deleted", DeletedAt: time.Now()},
+		humandao.Human{ID: "copied", Name: "Copied", DeletedAt: time.Now(), Images: copied},
+		humandao.Human{ID: "copy", Name: "Copy", Images: copied},
+	)
+	s := NewServerHTML(ServerHTMLConfig{HumanDAO: dao, AuthClient: NoOpAuthorizer{}})
+
+	// the gallery of the loser is merged into the winner, but an image featured before there was a
+	// gallery is only taken when the merge keeps the loser's images
+	_, err := dao.Merge(ctx, humandao.MergeInput{WinnerID: "winner", LoserID: "taken"})
+	assert.NoError(t, err)
+	_, err = dao.Merge(ctx, humandao.MergeInput{WinnerID: "winner", LoserID: "left"})
+	assert.NoError(t, err)
+	// the winner is merged again and purged, so only the human it was merged into shows the images
+	_, err = dao.Merge(ctx, humandao.MergeInput{WinnerID: "final", LoserID: "winner"})
+	assert.NoError(t, err)
+	assert.NoError(t, dao.Purge(ctx, humandao.PurgeInput{HumanID: "winner"}))
+
+	for id, expected := range map[string]bool{"taken": true, "left": false, "deleted": false, "copied": true} {
+		human, err := dao.Human(ctx, humandao.HumanInput{HumanID: id, IncludeDeleted: true})
+		assert.NoError(t, err)
+		inUse, err := s.imagesInUse(ctx, human)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, inUse, id)
+	}
+}
@@ -2,7 +2,8 @@ package server
 
 import (
 	context "context"
-	"fmt"
+	"errors"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -13,8 +14,10 @@ import (
 	"github.com/go-chi/cors"
 	"github.com/go-chi/httplog"
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
-	"github.com/patrickmn/go-cache"
 	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
 	"github.com/raymonstah/asianamericanswiki/internal/ratelimiter"
@@ -34,7 +37,7 @@ type FirebaseConfig struct {
 
 type Config struct {
 	AuthClient     Authorizer
-	HumanDAO       *humandao.DAO
+	HumanDAO       humandao.HumanStore
 	UserDAO        *userdao.DAO
 	Logger         zerolog.Logger
 	Version        string
@@ -48,27 +51,44 @@ type Server struct {
 	authClient    Authorizer
 	router        chi.Router
 	logger        zerolog.Logger
-	humanCache    *cache.Cache
 	rateLimiter   *ratelimiter.RateLimiter
-	humanDAO      *humandao.DAO
+	humanDAO      humandao.HumanStore
 	userDAO       *userdao.DAO
 	version       string
 	xaiClient     *xai.Client
 	storageClient *storage.Client
+	grpcServer    *grpc.Server
 }
 
+// ServeHTTP serves gRPC calls made over HTTP/2 with the gRPC server, and everything else with the
+// router. gRPC calls have their own deadlines, so they aren't cut off by the read and write
+// timeouts of the http.Server, which WatchHumans streams outlive.
 func (s *Server) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	if request.ProtoMajor == 2 && strings.HasPrefix(request.Header.Get("Content-Type"), "application/grpc") {
+		rc := http.NewResponseController(writer)
+		if err := rc.SetReadDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			s.logger.Error().Err(err).Msg("unable to clear read deadline")
+		}
+		if err := rc.SetWriteDeadline(time.Time{}); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			s.logger.Error().Err(err).Msg("unable to clear write deadline")
+		}
+		s.grpcServer.ServeHTTP(writer, request)
+		return
+	}
 	s.router.ServeHTTP(writer, request)
 }
 
+// GRPCServer serves the API to gRPC clients, along with server reflection and health checks.
+func (s *Server) GRPCServer() *grpc.Server {
+	return s.grpcServer
+}
+
 func NewServer(config Config) *Server {
 	r := chi.NewRouter()
-	humanCache := cache.New(5*time.Minute, 10*time.Minute)
 	s := &Server{
 		authClient:    config.AuthClient,
 		router:        r,
 		logger:        config.Logger,
-		humanCache:    humanCache,
 		rateLimiter:   ratelimiter.New(3, time.Second),
 		humanDAO:      config.HumanDAO,
 		userDAO:       config.UserDAO,
@@ -82,10 +102,9 @@ func NewServer(config Config) *Server {
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Compress(5))
 	r.Use(cors.Handler(cors.Options{
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
 	}))
-	s.setupRoutes()
 	htmlServer := NewServerHTML(ServerHTMLConfig{
 		Local:          config.Local,
 		HumanDAO:       config.HumanDAO,
@@ -95,6 +114,7 @@ func NewServer(config Config) *Server {
 		XAIClient:      config.XAIClient,
 		FirebaseConfig: config.FirebaseConfig,
 	})
+	s.setupRoutes(htmlServer)
 	if err := htmlServer.Register(r); err != nil {
 		panic(err)
 	}
@@ -102,22 +122,42 @@ func NewServer(config Config) *Server {
 	return s
 }
 
-func (s *Server) setupRoutes() {
+// setupRoutes serves the API under /api, and to gRPC clients. It reads the humans the HTML server
+// keeps in memory, and searches them with its index.
+func (s *Server) setupRoutes(htmlServer *ServerHTML) {
 	humanServer := &HumanServer{
-		logger:     s.logger,
-		humanCache: s.humanCache,
-		humanDAO:   s.humanDAO,
-		version:    s.version,
+		logger:   s.logger,
+		humanDAO: s.humanDAO,
+		html:     htmlServer,
+		version:  s.version,
+	}
+
+	// the gateway calls the API through an in-memory connection, so its calls go through the same
+	// interceptors as those of any other client
+	s.grpcServer = newGRPCServer(s.logger, s.authClient, humanServer)
+	listener := bufconn.Listen(1 << 20)
+	go func() {
+		if err := s.grpcServer.Serve(listener); err != nil {
+			s.logger.Error().Err(err).Msg("unable to serve grpc")
+		}
+	}()
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		panic(err)
 	}
 
 	gwmux := runtime.NewServeMux()
 	ctx := context.Background()
-	if err := RegisterHumanServiceHandlerServer(ctx, gwmux, humanServer); err != nil {
+	if err := RegisterHumanServiceHandlerClient(ctx, gwmux, NewHumanServiceClient(conn)); err != nil {
 		panic(err)
 	}
 
 	s.router.Group(func(r chi.Router) {
 		r.Use(httplog.RequestLogger(s.logger))
+		r.Get("/api/v1/humans:watch", humanServer.HandlerWatchHumans)
 		r.HandleFunc("/api/*", func(w http.ResponseWriter, r *http.Request) {
 			r.URL.Path = strings.ReplaceAll(r.URL.Path, "/api", "")
 			gwmux.ServeHTTP(w, r)
@@ -128,10 +168,10 @@ func (s *Server) setupRoutes() {
 type HumanServer struct {
 	UnimplementedHumanServiceServer // embed by value
 
-	version    string
-	logger     zerolog.Logger
-	humanCache *cache.Cache
-	humanDAO   *humandao.DAO
+	version  string
+	logger   zerolog.Logger
+	humanDAO humandao.HumanStore
+	html     *ServerHTML
 }
 
 func (s *HumanServer) Version(ctx context.Context, req *VersionRequest) (*VersionResponse, error) {
@@ -142,55 +182,3 @@ func (s *HumanServer) Version(ctx context.Context, req *VersionRequest) (*Versio
 		sizeCache:     0,
 	}, nil
 }
-
-func (s *HumanServer) Humans(ctx context.Context, req *HumansRequest) (*HumansResponse, error) {
-	humansRaw, ok := s.humanCache.Get("humans")
-	if ok {
-		s.logger.Debug().Msg("cache hit")
-		humans := convert(humansRaw.([]humandao.Human))
-		return &HumansResponse{
-			Humans: humans,
-		}, nil
-	}
-	humans, err := s.humanDAO.ListHumans(ctx, humandao.ListHumansInput{
-		Limit:  500,
-		Offset: 0,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("unable to list humans: %w", err)
-	}
-
-	s.humanCache.Set("humans", humans, cache.DefaultExpiration)
-	protoHumans := convert(humans)
-
-	return &HumansResponse{
-		Humans: protoHumans,
-	}, nil
-}
-
-func convert(humans []humandao.Human) []*Human {
-	var humansOut []*Human
-	for _, human := range humans {
-		humanOut := &Human{
-			Id:          human.ID,
-			Name:        human.Name,
-			Path:        human.Path,
-			Dob:         human.DOB,
-			Dod:         human.DOD,
-			Tags:        human.Tags,
-			Ethnicity:   human.Ethnicity,
-			Image:       human.FeaturedImage,
-			Description: human.Description,
-			Socials: &HumanSocial{
-				Instagram: human.Socials.Instagram,
-				X:         human.Socials.X,
-				Website:   human.Socials.Website,
-				Imdb:      human.Socials.IMDB,
-			},
-			Gender: Gender(Gender_value[strings.ToUpper(string(human.Gender))]),
-		}
-		humansOut = append(humansOut, humanOut)
-	}
-
-	return humansOut
-}
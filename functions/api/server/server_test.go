@@ -1,18 +1,37 @@
 package server
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	firebase "firebase.google.com/go/v4"
+	"firebase.google.com/go/v4/auth"
 	"github.com/raymonstah/asianamericanswiki/functions/api"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
+	"github.com/rs/zerolog"
 	"github.com/segmentio/ksuid"
 	"github.com/tj/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 func Test_Server(t *testing.T) {
@@ -53,7 +72,7 @@ func Test_Server(t *testing.T) {
 	assert.NoError(t, err)
 	defer func() { _ = resp.Body.Close() }()
 	assert.Equal(t, http.StatusOK, resp.StatusCode)
-	var humansResponse HumansResponse
+	var humansResponse ListHumansResponse
 	raw, err := io.ReadAll(resp.Body)
 	assert.NoError(t, err)
 	err = protojson.Unmarshal(raw, &humansResponse)
@@ -61,3 +80,626 @@ func Test_Server(t *testing.T) {
 
 	assert.NotEmpty(t, humansResponse.Humans, "at least one human should exist.. did you seed the database?")
 }
+
+func Test_convertPartialDate(t *testing.T) {
+	assert.Nil(t, convertPartialDate(""))
+	assert.Equal(t, int32(1940), convertPartialDate("1940").GetYear())
+	assert.Equal(t, int32(0), convertPartialDate("1940").GetMonth())
+
+	date := convertPartialDate("1940-11-27")
+	assert.Equal(t, int32(1940), date.GetYear())
+	assert.Equal(t, int32(11), date.GetMonth())
+	assert.Equal(t, int32(27), date.GetDay())
+}
+
+func Test_convertHuman(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	human := humandao.Human{
+		ID:            "bruce",
+		Name:          "Bruce Lee",
+		Aliases:       []string{"Little Dragon"},
+		Names:         []humandao.NameVariant{{Value: "李小龍", Lang: "zh-Hant", Type: humandao.NameBirth}},
+		Path:          "bruce-lee",
+		PreviousPaths: []string{"bruce"},
+		DOB:           "1940-11-27",
+		DOD:           "1973-07",
+		Tags:          []string{"actor", "martial artist"},
+		Ethnicity:     []string{"chinese"},
+		BirthPlace:    &place.Place{City: "San Francisco", Region: "California", Country: "United States", Lat: 37.77, Lng: -122.42},
+		BasedIn:       []place.Place{{City: "Hong Kong", Country: "Hong Kong"}},
+		BirthLocation: "San Francisco",
+		Location:      []string{"Hong Kong", "Seattle"},
+		InfluencedBy:  []string{"ip-man"},
+		Draft:         true,
+		AIGenerated:   true,
+		Description:   "Martial artist.",
+		CreatedAt:     at,
+		CreatedBy:     "creator",
+		UpdatedAt:     at.Add(time.Hour),
+		Version:       3,
+		PublishedBy:   "publisher",
+		PublishedAt:   at.Add(2 * time.Hour),
+		Socials:       humandao.Socials{IMDB: "nm0000045", Website: "https://brucelee.com", X: "brucelee", YouTube: "brucelee", Instagram: "brucelee"},
+		Views:         42,
+		Gender:        humandao.GenderMale,
+		Similar:       []string{"jackie"},
+		Images: humandao.Images{
+			Featured:   "https://example.com/a.webp",
+			Thumbnail:  "https://example.com/a.jpg",
+			FeaturedID: "a",
+			Gallery: []humandao.Image{{
+				ID: "a", Object: "a.webp", ThumbnailObject: "a.jpg", URL: "https://example.com/a.webp", ThumbnailURL: "https://example.com/a.jpg",
+				Width: 800, Height: 600, SourceURL: "https://commons.wikimedia.org/wiki/File:A.jpg", Author: "Jane Doe", License: "CC BY 4.0",
+				AIGenerated: true, Caption: "In 1971", UploadedAt: at, UploadedBy: "uploader",
+			}},
+		},
+		Works:   []humandao.Work{{Title: "Enter the Dragon", Type: humandao.WorkFilm, Year: 1973, Credit: "Lee", URL: "https://www.imdb.com/title/tt0070034/"}},
+		Awards:  []humandao.Award{{Name: "Hong Kong Film Award", Category: "Lifetime Achievement", Year: 1994, Result: humandao.AwardWon, Source: "https://example.com/award"}},
+		Sources: []humandao.Source{{URL: "https://example.com/bio", Title: "Biography", Publisher: "Example", AccessedAt: at, Fields: []string{"dob"}}},
+	}
+	// the fields of humans in the trash aren't in the proto, since the API never returns them
+	notConverted := []string{"FeaturedImage", "DeletedAt", "DeletedBy", "MergedInto"}
+
+	// every other field is set, so a field added to humandao.Human but not to the proto fails
+	value := reflect.ValueOf(human)
+	for i := range value.NumField() {
+		name := value.Type().Field(i).Name
+		if !slices.Contains(notConverted, name) {
+			assert.False(t, value.Field(i).IsZero(), name)
+		}
+	}
+
+	raw, err := protojson.Marshal(convertHuman(human))
+	assert.NoError(t, err)
+	var message Human
+	assert.NoError(t, protojson.Unmarshal(raw, &message))
+	assert.Equal(t, human.Images.Featured, message.GetImage())
+	converted, err := humanFromProto(&message)
+	assert.NoError(t, err)
+	assert.Equal(t, human, converted)
+
+	assert.Equal(t, Gender_GENDER_UNSPECIFIED, convertGender(""))
+	assert.Equal(t, Gender_GENDER_UNSPECIFIED, convertGender("robot"))
+	assert.Equal(t, Gender_NONBINARY, convertGender(humandao.GenderNonBinary))
+	assert.Equal(t, humandao.Gender(""), genderFromProto(Gender_GENDER_UNSPECIFIED))
+	assert.Equal(t, humandao.GenderNonBinary, genderFromProto(Gender_NONBINARY))
+	// FEMALE and NONBINARY are on the wire as they were before GENDER_UNSPECIFIED
+	assert.Equal(t, []Gender{1, 2}, []Gender{Gender_FEMALE, Gender_NONBINARY})
+	assert.Equal(t, humandao.GenderMale, genderFromProto(Gender_MALE))
+
+	converted, err = humanFromProto(&Human{DobDate: &PartialDate{Year: 1940, Month: 11}})
+	assert.NoError(t, err)
+	assert.Equal(t, humandao.PartialDate("1940-11"), converted.DOB)
+	_, err = humanFromProto(&Human{DobDate: &PartialDate{Year: 1940, Month: 13}})
+	assert.True(t, errors.Is(err, humandao.ErrInvalidDate))
+}
+
+func Test_HumanServer(t *testing.T) {
+	dao := humandao.NewMemoryDAO(
+		humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong", PreviousPaths: []string{"ali"}, Gender: humandao.GenderFemale, DOB: "1982-04-19", Tags: []string{"comedian", "actor"}, Ethnicity: []string{"Chinese", "Vietnamese"}, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		humandao.Human{ID: "bruce", Name: "Bruce Lee", Path: "bruce-lee", Aliases: []string{"Little Dragon"}, Gender: humandao.GenderMale, DOB: "1940-11-27", DOD: "1973-07-20", Tags: []string{"actor"}, Ethnicity: []string{"Chinese"}, CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)},
+		humandao.Human{ID: "randall", Name: "Randall Park", Path: "randall-park", Gender: humandao.GenderMale, DOB: "1974", Tags: []string{"actor"}, Ethnicity: []string{"Korean"}, CreatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		humandao.Human{ID: "draft", Name: "Not Yet", Path: "not-yet", Draft: true, Tags: []string{"actor"}},
+	)
+	s := NewServer(Config{HumanDAO: dao})
+
+	get := func(t *testing.T, target string, response proto.Message) int {
+		t.Helper()
+		req := httptest.NewRequest(http.MethodGet, target, nil)
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		if w.Code == http.StatusOK {
+			assert.NoError(t, protojson.Unmarshal(w.Body.Bytes(), response))
+		}
+		return w.Code
+	}
+	names := func(humans []*Human) []string {
+		var names []string
+		for _, human := range humans {
+			names = append(names, human.GetName())
+		}
+		return names
+	}
+
+	t.Run("get", func(t *testing.T) {
+		var human Human
+		assert.Equal(t, http.StatusOK, get(t, "/api/v1/humans/ali", &human))
+		assert.Equal(t, "Ali Wong", human.GetName())
+		assert.Equal(t, http.StatusOK, get(t, "/api/v1/humans/bruce-lee", &human))
+		assert.Equal(t, "bruce", human.GetId())
+		assert.Equal(t, http.StatusNotFound, get(t, "/api/v1/humans/draft", &human))
+		assert.Equal(t, http.StatusNotFound, get(t, "/api/v1/humans/nobody", &human))
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var response ListHumansResponse
+		assert.Equal(t, http.StatusOK, get(t, "/api/v1/humans", &response))
+		assert.Equal(t, []string{"Randall Park", "Bruce Lee", "Ali Wong"}, names(response.GetHumans()))
+		assert.Equal(t, int32(3), response.GetTotalSize())
+		assert.Equal(t, "", response.GetNextPageToken())
+
+		for target, expected := range map[string][]string{
+			"/api/v1/humans?order_by=name":                             {"Ali Wong", "Bruce Lee", "Randall Park"},
+			"/api/v1/humans?order_by=dob%20desc":                       {"Ali Wong", "Randall Park", "Bruce Lee"},
+			"/api/v1/humans?tags=comedian&tags=musician":               {"Ali Wong"},
+			"/api/v1/humans?ethnicity=East%20Asian&order_by=name":      {"Ali Wong", "Bruce Lee", "Randall Park"},
+			"/api/v1/humans?ethnicity=Chinese&gender=male":             {"Bruce Lee"},
+			"/api/v1/humans?dob_after=1980":                            {"Ali Wong"},
+			"/api/v1/humans?dob_before=1980":                           {"Randall Park"},
+			"/api/v1/humans?name=dragon":                               nil,
+			"/api/v1/humans?name=dragon&include_aliases=true":          {"Bruce Lee"},
+			"/api/v1/humans?orderBy=views&pageSize=1&includeAliases=1": {"Ali Wong"},
+		} {
+			response := ListHumansResponse{}
+			assert.Equal(t, http.StatusOK, get(t, target, &response), target)
+			assert.Equal(t, expected, names(response.GetHumans()), target)
+		}
+
+		for _, target := range []string{
+			"/api/v1/humans?gender=robot",
+			"/api/v1/humans?order_by=height",
+			"/api/v1/humans?order_by=name%20sideways",
+			"/api/v1/humans?dob_before=yesterday",
+			"/api/v1/humans?page_size=-1",
+			"/api/v1/humans?page_token=nope",
+		} {
+			assert.Equal(t, http.StatusBadRequest, get(t, target, &response), target)
+		}
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		var seen []string
+		token := ""
+		for range 3 {
+			var response ListHumansResponse
+			assert.Equal(t, http.StatusOK, get(t, "/api/v1/humans?order_by=name&page_size=2&page_token="+token, &response))
+			seen = append(seen, names(response.GetHumans())...)
+			token = response.GetNextPageToken()
+			if token == "" {
+				break
+			}
+			// a token only works for the request it was handed out for
+			assert.Equal(t, http.StatusBadRequest, get(t, "/api/v1/humans?order_by=views&page_size=2&page_token="+token, &response))
+		}
+		assert.Equal(t, []string{"Ali Wong", "Bruce Lee", "Randall Park"}, seen)
+	})
+
+	t.Run("pages of a changing list", func(t *testing.T) {
+		var first ListHumansResponse
+		assert.Equal(t, http.StatusOK, get(t, "/api/v1/humans?page_size=2", &first))
+		assert.Equal(t, []string{"Randall Park", "Bruce Lee"}, names(first.GetHumans()))
+
+		// a human added to the top of the list doesn't push the last human of a page onto the next
+		_, err := dao.AddHuman(humandao.WithAuthor(context.Background(), "admin"), humandao.AddHumanInput{Name: "Sandra Oh", Gender: humandao.GenderFemale})
+		assert.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			var response ListHumansResponse
+			return get(t, "/api/v1/humans", &response) == http.StatusOK && response.GetTotalSize() == 4
+		}, 5*time.Second, 10*time.Millisecond)
+
+		var second ListHumansResponse
+		assert.Equal(t, http.StatusOK, get(t, "/api/v1/humans?page_size=2&page_token="+first.GetNextPageToken(), &second))
+		assert.Equal(t, []string{"Ali Wong"}, names(second.GetHumans()))
+		assert.Equal(t, "", second.GetNextPageToken())
+	})
+
+	t.Run("search", func(t *testing.T) {
+		var response SearchHumansResponse
+		assert.Equal(t, http.StatusOK, get(t, "/api/v1/humans:search?query=bruce", &response))
+		assert.Equal(t, []string{"Bruce Lee"}, names(response.GetHumans()))
+		assert.Equal(t, http.StatusOK, get(t, "/api/v1/humans:search?query=not%20yet", &response))
+		assert.Equal(t, 0, len(response.GetHumans()))
+		assert.Equal(t, http.StatusBadRequest, get(t, "/api/v1/humans:search", &response))
+	})
+}
+
+// tokenAuthorizer verifies the ID tokens it has, and rejects every other.
+type tokenAuthorizer struct {
+	NoOpAuthorizer
+	tokens map[string]*auth.Token
+}
+
+func (a tokenAuthorizer) VerifyIDToken(ctx context.Context, idToken string) (*auth.Token, error) {
+	token, ok := a.tokens[idToken]
+	if !ok {
+		return nil, errors.New("invalid token")
+	}
+	return token, nil
+}
+
+func Test_HumanServer_Writes(t *testing.T) {
+	dao := humandao.NewMemoryDAO(
+		humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong", Gender: humandao.GenderFemale, Socials: humandao.Socials{X: "aliwong", YouTube: "aliwong"}, CreatedBy: "creator", Version: 3, Images: humandao.Images{
+			Gallery: []humandao.Image{{ID: "one", Object: "ali/one.webp", ThumbnailObject: "ali/one-thumb.webp", URL: "https://images.example/ali/one.webp", UploadedBy: "uploader"}},
+		}},
+	)
+	s := NewServer(Config{HumanDAO: dao, AuthClient: tokenAuthorizer{tokens: map[string]*auth.Token{
+		"admin": {UID: "admin-user", Claims: map[string]any{"admin": true}},
+		"user":  {UID: "some-user", Claims: map[string]any{}},
+	}}})
+
+	call := func(t *testing.T, method, target, token, body string, response proto.Message) int {
+		t.Helper()
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		w := httptest.NewRecorder()
+		s.ServeHTTP(w, req)
+		if w.Code == http.StatusOK && response != nil {
+			assert.NoError(t, protojson.Unmarshal(w.Body.Bytes(), response))
+		}
+		return w.Code
+	}
+
+	t.Run("auth", func(t *testing.T) {
+		body := `{"name": "Bruce Lee"}`
+		assert.Equal(t, http.StatusUnauthorized, call(t, http.MethodPost, "/api/v1/humans", "", body, nil))
+		assert.Equal(t, http.StatusUnauthorized, call(t, http.MethodPost, "/api/v1/humans", "forged", body, nil))
+		assert.Equal(t, http.StatusForbidden, call(t, http.MethodPost, "/api/v1/humans", "user", body, nil))
+		assert.Equal(t, http.StatusForbidden, call(t, http.MethodDelete, "/api/v1/humans/ali", "user", "", nil))
+		// reads don't need a token
+		assert.Equal(t, http.StatusOK, call(t, http.MethodGet, "/api/v1/humans/ali", "", "", &Human{}))
+	})
+
+	t.Run("admin fields", func(t *testing.T) {
+		for _, token := range []string{"", "user", "forged"} {
+			var human Human
+			assert.Equal(t, http.StatusOK, call(t, http.MethodGet, "/api/v1/humans/ali", token, "", &human), token)
+			assert.Equal(t, "", human.GetCreatedBy(), token)
+			assert.Equal(t, int64(0), human.GetVersion(), token)
+			image := human.GetImages().GetGallery()[0]
+			assert.Equal(t, "https://images.example/ali/one.webp", image.GetUrl(), token)
+			assert.Equal(t, "", image.GetObject()+image.GetThumbnailObject()+image.GetUploadedBy(), token)
+
+			var response ListHumansResponse
+			assert.Equal(t, http.StatusOK, call(t, http.MethodGet, "/api/v1/humans", token, "", &response), token)
+			assert.Equal(t, "", response.GetHumans()[0].GetCreatedBy(), token)
+		}
+
+		var human Human
+		assert.Equal(t, http.StatusOK, call(t, http.MethodGet, "/api/v1/humans/ali", "admin", "", &human))
+		assert.Equal(t, "creator", human.GetCreatedBy())
+		assert.Equal(t, int64(3), human.GetVersion())
+		image := human.GetImages().GetGallery()[0]
+		assert.Equal(t, "ali/one.webp", image.GetObject())
+		assert.Equal(t, "ali/one-thumb.webp", image.GetThumbnailObject())
+		assert.Equal(t, "uploader", image.GetUploadedBy())
+		var response SearchHumansResponse
+		assert.Equal(t, http.StatusOK, call(t, http.MethodGet, "/api/v1/humans:search?query=ali", "admin", "", &response))
+		assert.Equal(t, "creator", response.GetHumans()[0].GetCreatedBy())
+	})
+
+	t.Run("create and publish", func(t *testing.T) {
+		var created Human
+		assert.Equal(t, http.StatusOK, call(t, http.MethodPost, "/api/v1/humans", "admin", `{"name": "Bruce Lee", "gender": "MALE", "dobDate": {"year": 1940, "month": 11, "day": 27}, "ethnicity": ["Chinese"], "socials": {"imdb": "nm0000045"}}`, &created))
+		assert.Equal(t, "bruce-lee", created.GetPath())
+		assert.Equal(t, "1940-11-27", created.GetDob())
+		assert.Equal(t, []string{"chinese"}, created.GetEthnicity())
+		stored, err := dao.Human(context.Background(), humandao.HumanInput{HumanID: created.GetId()})
+		assert.NoError(t, err)
+		assert.True(t, stored.Draft)
+		assert.Equal(t, "admin-user", stored.CreatedBy)
+		assert.Equal(t, http.StatusNotFound, call(t, http.MethodGet, "/api/v1/humans/bruce-lee", "", "", &Human{}))
+
+		// the same validation as the forms
+		assert.Equal(t, http.StatusBadRequest, call(t, http.MethodPost, "/api/v1/humans", "admin", `{"name": " "}`, nil))
+		assert.Equal(t, http.StatusBadRequest, call(t, http.MethodPost, "/api/v1/humans", "admin", `{"name": "Someone", "ethnicity": ["martian"]}`, nil))
+		assert.Equal(t, http.StatusBadRequest, call(t, http.MethodPost, "/api/v1/humans", "admin", `{"name": "Someone", "dob": "yesterday"}`, nil))
+		assert.Equal(t, http.StatusBadRequest, call(t, http.MethodPost, "/api/v1/humans", "admin", `{"name": "Someone"}`, nil))
+		assert.Equal(t, http.StatusConflict, call(t, http.MethodPost, "/api/v1/humans", "admin", `{"name": "Bruce Lee", "gender": "MALE"}`, nil))
+
+		assert.Equal(t, http.StatusOK, call(t, http.MethodPost, "/api/v1/humans/bruce-lee:publish", "admin", "{}", &created))
+		stored, err = dao.Human(context.Background(), humandao.HumanInput{HumanID: created.GetId()})
+		assert.NoError(t, err)
+		assert.False(t, stored.Draft)
+		assert.Equal(t, "admin-user", stored.PublishedBy)
+		assert.Equal(t, http.StatusOK, call(t, http.MethodGet, "/api/v1/humans/bruce-lee", "", "", &Human{}))
+		var response SearchHumansResponse
+		assert.Equal(t, http.StatusOK, call(t, http.MethodGet, "/api/v1/humans:search?query=bruce", "", "", &response))
+		assert.Equal(t, 1, len(response.GetHumans()))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		var updated Human
+		// without a mask, the gateway updates the fields of the body
+		assert.Equal(t, http.StatusOK, call(t, http.MethodPatch, "/api/v1/humans/ali-wong", "admin", `{"description": "Comedian.", "socials": {"instagram": "aliwong"}}`, &updated))
+		assert.Equal(t, "Comedian.", updated.GetDescription())
+		assert.Equal(t, "Ali Wong", updated.GetName())
+		assert.Equal(t, "aliwong", updated.GetSocials().GetInstagram())
+		stored, err := dao.Human(context.Background(), humandao.HumanInput{HumanID: "ali"})
+		assert.NoError(t, err)
+		assert.Equal(t, humandao.Socials{X: "aliwong", YouTube: "aliwong", Instagram: "aliwong"}, stored.Socials)
+
+		// with one, only the fields of the mask are updated
+		assert.Equal(t, http.StatusOK, call(t, http.MethodPatch, "/api/v1/humans/ali?update_mask=dob", "admin", `{"dob": "1982-04-19", "description": "ignored"}`, &updated))
+		assert.Equal(t, "1982-04-19", updated.GetDob())
+		assert.Equal(t, "Comedian.", updated.GetDescription())
+
+		assert.Equal(t, http.StatusBadRequest, call(t, http.MethodPatch, "/api/v1/humans/ali", "admin", `{"name": ""}`, nil))
+		assert.Equal(t, http.StatusBadRequest, call(t, http.MethodPatch, "/api/v1/humans/ali", "admin", `{"path": "somewhere-else"}`, nil))
+		assert.Equal(t, http.StatusNotFound, call(t, http.MethodPatch, "/api/v1/humans/nobody", "admin", `{"name": "Nobody"}`, nil))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		assert.Equal(t, http.StatusOK, call(t, http.MethodDelete, "/api/v1/humans/ali-wong", "admin", "", nil))
+		stored, err := dao.Human(context.Background(), humandao.HumanInput{HumanID: "ali", IncludeDeleted: true})
+		assert.NoError(t, err)
+		assert.Equal(t, "admin-user", stored.DeletedBy)
+		assert.Equal(t, http.StatusNotFound, call(t, http.MethodGet, "/api/v1/humans/ali", "", "", &Human{}))
+		assert.Equal(t, http.StatusNotFound, call(t, http.MethodDelete, "/api/v1/humans/ali", "admin", "", nil))
+	})
+}
+
+func Test_GRPCServer(t *testing.T) {
+	ctx := context.Background()
+	dao := humandao.NewMemoryDAO(humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong", Gender: humandao.GenderFemale})
+	s := NewServer(Config{HumanDAO: dao, Version: "test", AuthClient: tokenAuthorizer{tokens: map[string]*auth.Token{
+		"admin": {UID: "admin-user", Claims: map[string]any{"admin": true}},
+	}}})
+
+	// gRPC is served on its own listener, and over h2c next to the rest of the site
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() { _ = s.GRPCServer().Serve(listener) }()
+	t.Cleanup(s.GRPCServer().Stop)
+	site := httptest.NewUnstartedServer(s)
+	site.Config.Protocols = new(http.Protocols)
+	site.Config.Protocols.SetHTTP1(true)
+	site.Config.Protocols.SetUnencryptedHTTP2(true)
+	site.Start()
+	t.Cleanup(site.Close)
+
+	for name, address := range map[string]string{"grpc-port": listener.Addr().String(), "h2c": site.Listener.Addr().String()} {
+		t.Run(name, func(t *testing.T) {
+			conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+			assert.NoError(t, err)
+			defer func() { _ = conn.Close() }()
+			client := NewHumanServiceClient(conn)
+
+			version, err := client.Version(ctx, &VersionRequest{})
+			assert.NoError(t, err)
+			assert.Equal(t, "test", version.GetVersion())
+			human, err := client.GetHuman(ctx, &GetHumanRequest{Id: "ali-wong"})
+			assert.NoError(t, err)
+			assert.Equal(t, "Ali Wong", human.GetName())
+			_, err = client.GetHuman(ctx, &GetHumanRequest{Id: "nobody"})
+			assert.Equal(t, codes.NotFound, status.Code(err))
+			// the deprecated RPC still lists every published human
+			humans, err := client.Humans(ctx, &HumansRequest{})
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(humans.GetHumans()))
+			assert.Equal(t, "Ali Wong", humans.GetHumans()[0].GetName())
+
+			_, err = client.CreateHuman(ctx, &CreateHumanRequest{Human: &Human{Name: "Bruce Lee " + name, Gender: Gender_MALE}})
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			authorized := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer admin")
+			created, err := client.CreateHuman(authorized, &CreateHumanRequest{Human: &Human{Name: "Bruce Lee " + name, Gender: Gender_MALE}})
+			assert.NoError(t, err)
+			assert.True(t, created.GetDraft())
+
+			health, err := grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{Service: HumanService_ServiceDesc.ServiceName})
+			assert.NoError(t, err)
+			assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, health.GetStatus())
+
+			stream, err := grpc_reflection_v1.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+			assert.NoError(t, err)
+			assert.NoError(t, stream.Send(&grpc_reflection_v1.ServerReflectionRequest{
+				MessageRequest: &grpc_reflection_v1.ServerReflectionRequest_ListServices{},
+			}))
+			reflected, err := stream.Recv()
+			assert.NoError(t, err)
+			var services []string
+			for _, service := range reflected.GetListServicesResponse().GetService() {
+				services = append(services, service.GetName())
+			}
+			assert.Contains(t, services, HumanService_ServiceDesc.ServiceName)
+			assert.NoError(t, stream.CloseSend())
+		})
+	}
+
+	t.Run("h2c timeouts", func(t *testing.T) {
+		// the site is served with read and write timeouts, which streams outlive
+		site := httptest.NewUnstartedServer(s)
+		site.Config.Protocols = new(http.Protocols)
+		site.Config.Protocols.SetUnencryptedHTTP2(true)
+		site.Config.ReadTimeout = 200 * time.Millisecond
+		site.Config.WriteTimeout = 200 * time.Millisecond
+		site.Start()
+		t.Cleanup(site.Close)
+		conn, err := grpc.NewClient(site.Listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		watchCtx, stop := context.WithTimeout(ctx, 10*time.Second)
+		defer stop()
+		stream, err := NewHumanServiceClient(conn).WatchHumans(watchCtx, &WatchHumansRequest{SkipExisting: true})
+		assert.NoError(t, err)
+		event, err := stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, ChangeType_CURRENT, event.GetType())
+
+		time.Sleep(500 * time.Millisecond)
+		description := "Comedian."
+		_, err = dao.PatchHuman(humandao.WithAuthor(ctx, "admin"), "ali", humandao.HumanPatch{Description: &description})
+		assert.NoError(t, err)
+		event, err = stream.Recv()
+		assert.NoError(t, err)
+		assert.Equal(t, ChangeType_MODIFIED, event.GetType())
+		assert.Equal(t, description, event.GetHuman().GetDescription())
+	})
+
+	t.Run("recovery", func(t *testing.T) {
+		panics := func(ctx context.Context, req any) (any, error) { panic("oops") }
+		_, err := recoveryInterceptor(zerolog.Nop())(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/test"}, panics)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func Test_HumanServer_Watch(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	admin := humandao.WithAuthor(ctx, "admin")
+	dao := humandao.NewMemoryDAO(
+		humandao.Human{ID: "ali", Name: "Ali Wong", Path: "ali-wong", Gender: humandao.GenderFemale},
+		humandao.Human{ID: "draft", Name: "Not Yet", Path: "not-yet", Gender: humandao.GenderMale, Draft: true},
+	)
+	s := NewServer(Config{HumanDAO: dao})
+	site := httptest.NewServer(s)
+	t.Cleanup(site.Close)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() { _ = s.GRPCServer().Serve(listener) }()
+	t.Cleanup(s.GRPCServer().Stop)
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := NewHumanServiceClient(conn)
+
+	t.Run("grpc", func(t *testing.T) {
+		watchCtx, stop := context.WithCancel(ctx)
+		defer stop()
+		stream, err := client.WatchHumans(watchCtx, &WatchHumansRequest{})
+		assert.NoError(t, err)
+		recv := func(kind ChangeType, id string) *HumanEvent {
+			t.Helper()
+			event, err := stream.Recv()
+			assert.NoError(t, err)
+			assert.Equal(t, kind, event.GetType())
+			assert.Equal(t, id, event.GetHuman().GetId())
+			return event
+		}
+
+		// drafts aren't published, so they aren't watched
+		assert.Equal(t, "", recv(ChangeType_ADDED, "ali").GetResumeToken())
+		recv(ChangeType_CURRENT, "")
+
+		assert.NoError(t, dao.Publish(admin, humandao.PublishInput{HumanID: "draft", UserID: "admin"}))
+		added := recv(ChangeType_ADDED, "draft")
+		// a visit only changes the views, which isn't an event
+		assert.NoError(t, dao.View(ctx, humandao.ViewInput{HumanID: "ali"}))
+		description := "Comedian."
+		_, err = dao.PatchHuman(admin, "ali", humandao.HumanPatch{Description: &description})
+		assert.NoError(t, err)
+		modified := recv(ChangeType_MODIFIED, "ali").GetHuman()
+		assert.Equal(t, description, modified.GetDescription())
+		// the feed is public, so it leaves out what only admins get to see
+		assert.Equal(t, int64(0), modified.GetVersion())
+		assert.NoError(t, dao.Delete(admin, humandao.DeleteInput{HumanID: "ali", UserID: "admin"}))
+		recv(ChangeType_REMOVED, "ali")
+		stop()
+
+		// resuming after an event sends the ones after it
+		stream, err = client.WatchHumans(ctx, &WatchHumansRequest{ResumeToken: added.GetResumeToken()})
+		assert.NoError(t, err)
+		recv(ChangeType_MODIFIED, "ali")
+		recv(ChangeType_REMOVED, "ali")
+		recv(ChangeType_CURRENT, "")
+
+		// a token from before a restart starts the watch over
+		stream, err = client.WatchHumans(ctx, &WatchHumansRequest{ResumeToken: (&humanFeed{epoch: "restarted"}).token(1)})
+		assert.NoError(t, err)
+		recv(ChangeType_RESYNC, "")
+		recv(ChangeType_ADDED, "draft")
+		recv(ChangeType_CURRENT, "")
+
+		stream, err = client.WatchHumans(ctx, &WatchHumansRequest{ResumeToken: "nope"})
+		assert.NoError(t, err)
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("sse", func(t *testing.T) {
+		watchCtx, stop := context.WithCancel(ctx)
+		defer stop()
+		req, err := http.NewRequestWithContext(watchCtx, http.MethodGet, site.URL+"/api/v1/humans:watch?skip_existing=true", nil)
+		assert.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		assert.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(resp.Body)
+		next := func() (fields map[string]string, event *HumanEvent) {
+			t.Helper()
+			fields, event = make(map[string]string), &HumanEvent{}
+			for {
+				line, err := reader.ReadString('\n')
+				assert.NoError(t, err)
+				line = strings.TrimSuffix(line, "\n")
+				if line == "" {
+					break
+				}
+				name, value, _ := strings.Cut(line, ": ")
+				fields[name] = value
+			}
+			assert.NoError(t, protojson.Unmarshal([]byte(fields["data"]), event))
+			return fields, event
+		}
+
+		current, _ := next()
+		assert.Equal(t, "current", current["event"])
+		assert.NotEqual(t, "", current["id"])
+
+		description := "Not yet known."
+		_, err = dao.PatchHuman(admin, "draft", humandao.HumanPatch{Description: &description})
+		assert.NoError(t, err)
+		modified, event := next()
+		assert.Equal(t, "modified", modified["event"])
+		assert.Equal(t, event.GetResumeToken(), modified["id"])
+		assert.Equal(t, description, event.GetHuman().GetDescription())
+
+		for target, code := range map[string]int{
+			"/api/v1/humans:watch?skip_existing=maybe": http.StatusBadRequest,
+			"/api/v1/humans:watch?resume_token=nope":   http.StatusBadRequest,
+		} {
+			resp, err := http.Get(site.URL + target)
+			assert.NoError(t, err)
+			assert.NoError(t, resp.Body.Close())
+			assert.Equal(t, code, resp.StatusCode, target)
+		}
+	})
+}
+
+func Test_humanFeed(t *testing.T) {
+	feed := newHumanFeed()
+	feed.apply(nil)
+	for i := range watchHistory + 1 {
+		feed.apply([]humandao.Change{{Kind: humandao.ChangeModified, Human: humandao.Human{ID: "ali", Description: strconv.Itoa(i)}}})
+	}
+	// the first event, which added ali, is no longer kept
+	_, ok := feed.since(0)
+	assert.False(t, ok)
+	events, ok := feed.since(1)
+	assert.True(t, ok)
+	assert.Equal(t, watchHistory, len(events))
+	assert.Equal(t, ChangeType_MODIFIED, events[0].kind)
+	_, ok = feed.since(uint64(watchHistory + 2))
+	assert.False(t, ok)
+
+	// a token for an event that is no longer kept starts the watch over, and so does one that
+	// falls behind
+	var kinds []ChangeType
+	ctx, cancel := context.WithCancel(context.Background())
+	err := feed.watch(ctx, feed.token(0), false, func(event *HumanEvent) error {
+		kinds = append(kinds, event.GetType())
+		if len(kinds) == 3 {
+			for i := range watchHistory + 1 {
+				feed.apply([]humandao.Change{{Kind: humandao.ChangeModified, Human: humandao.Human{ID: "ali", Name: strconv.Itoa(i)}}})
+			}
+		}
+		if len(kinds) == 6 {
+			cancel()
+		}
+		return nil
+	})
+	assert.Equal(t, codes.Canceled, status.Code(err))
+	resync := []ChangeType{ChangeType_RESYNC, ChangeType_ADDED, ChangeType_CURRENT}
+	assert.Equal(t, append(resync, resync...), kinds)
+
+	feed.close()
+	err = feed.watch(context.Background(), "", true, func(*HumanEvent) error { return nil })
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+}
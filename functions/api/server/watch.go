@@ -0,0 +1,265 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"slices"
+	"sync"
+
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+)
+
+// watchHistory is how many events back a watch can resume from.
+const watchHistory = 1000
+
+var (
+	ErrInvalidResumeToken = errors.New("invalid resume token")
+	ErrWatchClosed        = errors.New("humans are no longer being watched")
+)
+
+// humanEvent is a change to the published humans. Events are numbered in the order they
+// happened, starting at 1.
+type humanEvent struct {
+	seq   uint64
+	kind  ChangeType
+	human humandao.Human
+}
+
+// resumeToken is what a resume_token points at: the last event a watch got, from the feed of this
+// process, whose events are numbered from scratch.
+type resumeToken struct {
+	Epoch string `json:"e"`
+	Seq   uint64 `json:"s"`
+}
+
+// humanFeed turns the changes to every human into changes to the published humans, keeping the
+// latest of them so watches can resume after the last one they got.
+type humanFeed struct {
+	epoch string
+	// loaded is closed once the feed has the humans of the first snapshot to start watches with.
+	loaded chan struct{}
+
+	lock      sync.Mutex
+	published map[string]humandao.Human
+	seq       uint64
+	history   []humanEvent
+	// changed is closed, and replaced, whenever there are new events or the feed closes.
+	changed chan struct{}
+	closed  bool
+}
+
+func newHumanFeed() *humanFeed {
+	return &humanFeed{
+		epoch:   ksuid.New().String(),
+		loaded:  make(chan struct{}),
+		changed: make(chan struct{}),
+	}
+}
+
+// apply turns a snapshot of changes into events. The first snapshot is every human, which watches
+// start with instead.
+func (f *humanFeed) apply(changes []humandao.Change) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.closed {
+		return
+	}
+
+	first := f.published == nil
+	if first {
+		f.published = make(map[string]humandao.Human)
+	}
+	for _, change := range changes {
+		human := change.Human
+		previous, was := f.published[human.ID]
+		is := change.Kind != humandao.ChangeRemoved && !human.Draft && !human.Deleted()
+		if is {
+			f.published[human.ID] = human
+		} else {
+			delete(f.published, human.ID)
+		}
+		if first {
+			continue
+		}
+
+		var kind ChangeType
+		switch {
+		case !was && is:
+			kind = ChangeType_ADDED
+		case was && is:
+			// views change on every visit, which isn't worth an event
+			previous.Views = human.Views
+			if reflect.DeepEqual(previous, human) {
+				continue
+			}
+			kind = ChangeType_MODIFIED
+		case was && !is:
+			kind = ChangeType_REMOVED
+		default:
+			continue
+		}
+		f.seq++
+		f.history = append(f.history, humanEvent{seq: f.seq, kind: kind, human: human})
+	}
+	if len(f.history) > watchHistory {
+		f.history = slices.Clone(f.history[len(f.history)-watchHistory:])
+	}
+
+	if first {
+		close(f.loaded)
+		return
+	}
+	close(f.changed)
+	f.changed = make(chan struct{})
+}
+
+// close ends every watch once it has sent the events so far. Nothing is applied after it.
+func (f *humanFeed) close() {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.closed {
+		return
+	}
+	f.closed = true
+	close(f.changed)
+	// watches waiting for the first snapshot end right away
+	if f.published == nil {
+		f.published = make(map[string]humandao.Human)
+		close(f.loaded)
+	}
+}
+
+// token is the resume token of the event numbered seq.
+func (f *humanFeed) token(seq uint64) string {
+	// a token is a string and a number, which always marshal
+	raw, _ := json.Marshal(resumeToken{Epoch: f.epoch, Seq: seq})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// since returns the events after the one numbered seq, and false if some of them are no longer
+// kept. The caller must hold f.lock.
+func (f *humanFeed) since(seq uint64) ([]humanEvent, bool) {
+	oldest := f.seq + 1
+	if len(f.history) > 0 {
+		oldest = f.history[0].seq
+	}
+	if seq > f.seq || seq+1 < oldest {
+		return nil, false
+	}
+	return slices.Clone(f.history[len(f.history)-int(f.seq-seq):]), true
+}
+
+func (f *humanFeed) event(event humanEvent) *HumanEvent {
+	return &HumanEvent{Type: event.kind, Human: publicHuman(event.human), ResumeToken: f.token(event.seq)}
+}
+
+// added returns every published human as added, the way a watch starts. The caller must hold f.lock.
+func (f *humanFeed) added() []*HumanEvent {
+	events := make([]*HumanEvent, 0, len(f.published))
+	for _, human := range f.published {
+		events = append(events, &HumanEvent{Type: ChangeType_ADDED, Human: publicHuman(human)})
+	}
+	slices.SortFunc(events, func(a, b *HumanEvent) int { return cmp.Compare(a.GetHuman().GetId(), b.GetHuman().GetId()) })
+	return events
+}
+
+// resync starts a watch over when the events it missed are no longer kept: a RESYNC event, every
+// published human as added, and a CURRENT event. The caller must hold f.lock.
+func (f *humanFeed) resync() []*HumanEvent {
+	events := append([]*HumanEvent{{Type: ChangeType_RESYNC}}, f.added()...)
+	return append(events, &HumanEvent{Type: ChangeType_CURRENT, ResumeToken: f.token(f.seq)})
+}
+
+// watch sends the events after the one the resume token points at, or every published human as
+// added when there is none, followed by a CURRENT event. It then sends the events as they happen,
+// until ctx is done or the feed closes. The events are only kept in memory, and only the latest of
+// them, so a token from another process, or one that is too old, starts the watch over with a
+// RESYNC event instead, as does a watch that falls behind.
+func (f *humanFeed) watch(ctx context.Context, token string, skipExisting bool, send func(*HumanEvent) error) error {
+	select {
+	case <-f.loaded:
+	case <-ctx.Done():
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	var cursor resumeToken
+	if token != "" {
+		raw, err := base64.RawURLEncoding.DecodeString(token)
+		if err == nil {
+			err = json.Unmarshal(raw, &cursor)
+		}
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "%v: %v", ErrInvalidResumeToken, err)
+		}
+	}
+
+	f.lock.Lock()
+	var (
+		backlog []*HumanEvent
+		seq     = f.seq
+	)
+	switch {
+	case token != "":
+		events, ok := f.since(cursor.Seq)
+		if cursor.Epoch != f.epoch || !ok {
+			backlog = f.resync()
+			break
+		}
+		for _, event := range events {
+			backlog = append(backlog, f.event(event))
+		}
+		backlog = append(backlog, &HumanEvent{Type: ChangeType_CURRENT, ResumeToken: f.token(seq)})
+	case !skipExisting:
+		backlog = append(f.added(), &HumanEvent{Type: ChangeType_CURRENT, ResumeToken: f.token(seq)})
+	default:
+		backlog = []*HumanEvent{{Type: ChangeType_CURRENT, ResumeToken: f.token(seq)}}
+	}
+	f.lock.Unlock()
+
+	for _, event := range backlog {
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+
+	for {
+		f.lock.Lock()
+		events, ok := f.since(seq)
+		changed, closed := f.changed, f.closed
+		backlog = nil
+		for _, event := range events {
+			backlog = append(backlog, f.event(event))
+			seq = event.seq
+		}
+		if !ok {
+			// the watch fell behind the events that are kept
+			backlog, seq = f.resync(), f.seq
+		}
+		f.lock.Unlock()
+
+		for _, event := range backlog {
+			if err := send(event); err != nil {
+				return err
+			}
+		}
+		if len(backlog) > 0 {
+			continue
+		}
+		if closed {
+			return status.Error(codes.Unavailable, ErrWatchClosed.Error())
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
@@ -43,10 +43,11 @@ func ImgUploaded(ctx context.Context, e event.Event) error {
 }
 
 type Handler struct {
-	humanDAO *humandao.DAO
+	humanDAO humandao.HumanStore
 }
 
 func (h Handler) do(ctx context.Context, bucket, fileName string) error {
+	ctx = humandao.WithAuthor(ctx, "imguploaded")
 	human, err := h.humanDAO.Human(ctx, humandao.HumanInput{
 		HumanID: fileName, // the file name is the human id
 	})
@@ -60,10 +61,10 @@ func (h Handler) do(ctx context.Context, bucket, fileName string) error {
 	}
 
 	// update the human with the image url
-	human.FeaturedImage = fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, fileName)
-	log.Printf("Updating %v (%v) with image url: %v", human.Name, human.ID, human.FeaturedImage)
-	if err := h.humanDAO.UpdateHuman(ctx, human); err != nil {
-		return fmt.Errorf("humanDAO.UpdateHuman: %w", err)
+	featuredImage := fmt.Sprintf("https://storage.googleapis.com/%s/%s", bucket, fileName)
+	log.Printf("Updating %v (%v) with image url: %v", human.Name, human.ID, featuredImage)
+	if _, err := h.humanDAO.PatchHuman(ctx, human.ID, humandao.HumanPatch{FeaturedImage: &featuredImage}); err != nil {
+		return fmt.Errorf("humanDAO.PatchHuman: %w", err)
 	}
 
 	return nil
@@ -15,7 +15,6 @@ require (
 	github.com/golangci/golangci-lint/v2 v2.10.1
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.27.7
 	github.com/modelcontextprotocol/go-sdk v1.4.0
-	github.com/patrickmn/go-cache v2.1.0+incompatible
 	github.com/rs/zerolog v1.31.0
 	github.com/sashabaranov/go-openai v1.36.0
 	github.com/segmentio/ksuid v1.0.4
@@ -26,6 +25,7 @@ require (
 	github.com/weaviate/weaviate-go-client/v5 v5.6.0
 	golang.org/x/image v0.28.0
 	golang.org/x/sync v0.20.0
+	golang.org/x/text v0.35.0
 	golang.org/x/vuln v1.1.4
 	google.golang.org/api v0.245.0
 	google.golang.org/genproto/googleapis/api v0.0.0-20260128011058-8636f8732409
@@ -335,7 +335,6 @@ require (
 	golang.org/x/net v0.52.0 // indirect
 	golang.org/x/oauth2 v0.34.0 // indirect
 	golang.org/x/sys v0.42.0 // indirect
-	golang.org/x/text v0.35.0 // indirect
 	golang.org/x/time v0.12.0 // indirect
 	google.golang.org/appengine/v2 v2.0.5 // indirect
 	google.golang.org/genproto v0.0.0-20250603155806-513f23925822 // indirect
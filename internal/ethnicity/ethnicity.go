@@ -1,167 +1,395 @@
 package ethnicity
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
 
 type Ethnicity struct {
 	Ethnicity string
 	Country   string
 	Emoji     string
+	// Parent is the broader group the ethnicity belongs to: a region like "east asian", or another
+	// ethnicity like "japanese" for "okinawan". Regions and non-Asian heritage have none.
+	Parent string
+	// Aliases are other ways the ethnicity is written, which are stored as Ethnicity instead.
+	Aliases []string
 }
 
 var Laotian = Ethnicity{
 	Ethnicity: "laotian",
 	Country:   "Laos",
 	Emoji:     "🇱🇦",
+	Parent:    "southeast asian",
+	Aliases:   []string{"lao"},
 }
 
 var Malaysian = Ethnicity{
 	Ethnicity: "malaysian",
 	Country:   "Malaysia",
 	Emoji:     "🇲🇾",
+	Parent:    "southeast asian",
 }
 
 var Maldivian = Ethnicity{
 	Ethnicity: "maldivian",
 	Country:   "Maldives",
 	Emoji:     "🇲🇻",
+	Parent:    "south asian",
 }
 
-var Bengalis = Ethnicity{
-	Ethnicity: "bengalis",
+var Bangladeshi = Ethnicity{
+	Ethnicity: "bangladeshi",
 	Country:   "Bangladesh",
 	Emoji:     "🇧🇩",
+	Aliases:   []string{"bengalis"},
+	Parent:    "south asian",
 }
 
 var Bhutanese = Ethnicity{
 	Ethnicity: "bhutanese",
 	Country:   "Bhutan",
 	Emoji:     "🇧🇹",
+	Parent:    "south asian",
 }
 
 var Bruneian = Ethnicity{
 	Ethnicity: "bruneian",
 	Country:   "Brunei",
 	Emoji:     "🇧🇳",
+	Parent:    "southeast asian",
 }
 
 var Cambodian = Ethnicity{
 	Ethnicity: "cambodian",
 	Country:   "Cambodia",
 	Emoji:     "🇰🇭",
+	Parent:    "southeast asian",
+	Aliases:   []string{"khmer"},
 }
 
 var Chinese = Ethnicity{
 	Ethnicity: "chinese",
 	Country:   "China",
 	Emoji:     "🇨🇳",
+	Parent:    "east asian",
 }
 
 var Filipino = Ethnicity{
 	Ethnicity: "filipino",
 	Country:   "Philippines",
 	Emoji:     "🇵🇭",
+	Parent:    "southeast asian",
+	Aliases:   []string{"filipina", "pilipino"},
 }
 
 var Vietnamese = Ethnicity{
 	Ethnicity: "vietnamese",
 	Country:   "Vietnam",
 	Emoji:     "🇻🇳",
+	Parent:    "southeast asian",
+	Aliases:   []string{"viet"},
 }
 
 var Afghan = Ethnicity{
 	Ethnicity: "afghan",
 	Country:   "Afghanistan",
 	Emoji:     "🇦🇫",
+	Parent:    "south asian",
 }
 
 var Indian = Ethnicity{
 	Ethnicity: "indian",
 	Country:   "India",
 	Emoji:     "🇮🇳",
+	Parent:    "south asian",
 }
 
 var Korean = Ethnicity{
 	Ethnicity: "korean",
 	Country:   "Korea",
 	Emoji:     "🇰🇷",
+	Parent:    "east asian",
 }
 
 var HongKong = Ethnicity{
 	Ethnicity: "hong kong",
 	Country:   "Hong Kong",
 	Emoji:     "🇭🇰",
+	Parent:    "east asian",
+	Aliases:   []string{"hongkonger"},
 }
 
 var Indonesian = Ethnicity{
 	Ethnicity: "indonesian",
 	Country:   "Indonesia",
 	Emoji:     "🇮🇩",
+	Parent:    "southeast asian",
 }
 
 var Japanese = Ethnicity{
 	Ethnicity: "japanese",
 	Country:   "Japan",
 	Emoji:     "🇯🇵",
+	Parent:    "east asian",
 }
 
 var Singaporean = Ethnicity{
 	Ethnicity: "singaporean",
 	Country:   "Singapore",
 	Emoji:     "🇸🇬",
+	Parent:    "southeast asian",
 }
 
 var Thai = Ethnicity{
 	Ethnicity: "thai",
 	Country:   "Thailand",
 	Emoji:     "🇹🇭",
+	Parent:    "southeast asian",
 }
 
 var Taiwanese = Ethnicity{
 	Ethnicity: "taiwanese",
 	Country:   "Taiwan",
 	Emoji:     "🇹🇼",
+	Parent:    "east asian",
 }
 
 var Macanese = Ethnicity{
 	Ethnicity: "macanese",
 	Country:   "Macao",
 	Emoji:     "🇲🇴",
+	Parent:    "east asian",
+	Aliases:   []string{"macau"},
 }
 
 var Mongolian = Ethnicity{
 	Ethnicity: "mongolian",
 	Country:   "Mongolia",
 	Emoji:     "🇲🇳",
+	Parent:    "east asian",
 }
 
 var Burmese = Ethnicity{
 	Ethnicity: "burmese",
 	Country:   "Myanmar (Burma)",
 	Emoji:     "🇲🇲",
+	Parent:    "southeast asian",
+	Aliases:   []string{"myanmar"},
 }
 
 var Nepali = Ethnicity{
 	Ethnicity: "nepali",
 	Country:   "Nepal",
 	Emoji:     "🇳🇵",
+	Parent:    "south asian",
+	Aliases:   []string{"nepalese"},
 }
 
 var SriLankan = Ethnicity{
 	Ethnicity: "sri lankan",
 	Country:   "Sri Lanka",
 	Emoji:     "🇱🇰",
+	Parent:    "south asian",
 }
 
-var Canadian = Ethnicity{
-	Ethnicity: "canadian",
+var Pakistani = Ethnicity{
+	Ethnicity: "pakistani",
+	Country:   "Pakistan",
+	Emoji:     "🇵🇰",
+	Parent:    "south asian",
 }
 
-var Dutch = Ethnicity{
-	Ethnicity: "dutch",
+var Timorese = Ethnicity{
+	Ethnicity: "timorese",
+	Country:   "Timor-Leste",
+	Emoji:     "🇹🇱",
+	Parent:    "southeast asian",
+}
+
+var Kazakh = Ethnicity{
+	Ethnicity: "kazakh",
+	Country:   "Kazakhstan",
+	Emoji:     "🇰🇿",
+	Parent:    "central asian",
+}
+
+var Kyrgyz = Ethnicity{
+	Ethnicity: "kyrgyz",
+	Country:   "Kyrgyzstan",
+	Emoji:     "🇰🇬",
+	Parent:    "central asian",
+}
+
+var Tajik = Ethnicity{
+	Ethnicity: "tajik",
+	Country:   "Tajikistan",
+	Emoji:     "🇹🇯",
+	Parent:    "central asian",
+}
+
+var Turkmen = Ethnicity{
+	Ethnicity: "turkmen",
+	Country:   "Turkmenistan",
+	Emoji:     "🇹🇲",
+	Parent:    "central asian",
+}
+
+var Uzbek = Ethnicity{
+	Ethnicity: "uzbek",
+	Country:   "Uzbekistan",
+	Emoji:     "🇺🇿",
+	Parent:    "central asian",
 }
 
 var Hawaiian = Ethnicity{
 	Ethnicity: "hawaiian",
+	Parent:    "pacific islander",
+	Aliases:   []string{"native hawaiian"},
+}
+
+var Samoan = Ethnicity{
+	Ethnicity: "samoan",
+	Country:   "Samoa",
+	Emoji:     "🇼🇸",
+	Parent:    "pacific islander",
+}
+
+var Tongan = Ethnicity{
+	Ethnicity: "tongan",
+	Country:   "Tonga",
+	Emoji:     "🇹🇴",
+	Parent:    "pacific islander",
+}
+
+var Fijian = Ethnicity{
+	Ethnicity: "fijian",
+	Country:   "Fiji",
+	Emoji:     "🇫🇯",
+	Parent:    "pacific islander",
+}
+
+var Chamorro = Ethnicity{
+	Ethnicity: "chamorro",
+	Country:   "Guam",
+	Emoji:     "🇬🇺",
+	Parent:    "pacific islander",
+}
+
+var Marshallese = Ethnicity{
+	Ethnicity: "marshallese",
+	Country:   "Marshall Islands",
+	Emoji:     "🇲🇭",
+	Parent:    "pacific islander",
+}
+
+// Subgroups are peoples within an ethnicity, or spread across several countries of a region.
+
+var Cantonese = Ethnicity{
+	Ethnicity: "cantonese",
+	Parent:    "chinese",
+}
+
+var Hakka = Ethnicity{
+	Ethnicity: "hakka",
+	Parent:    "chinese",
+}
+
+var Taishanese = Ethnicity{
+	Ethnicity: "taishanese",
+	Parent:    "chinese",
+	Aliases:   []string{"toisanese"},
+}
+
+var Tibetan = Ethnicity{
+	Ethnicity: "tibetan",
+	Parent:    "east asian",
+}
+
+var Okinawan = Ethnicity{
+	Ethnicity: "okinawan",
+	Parent:    "japanese",
+	Aliases:   []string{"ryukyuan"},
+}
+
+var Hmong = Ethnicity{
+	Ethnicity: "hmong",
+	Parent:    "southeast asian",
+}
+
+var Mien = Ethnicity{
+	Ethnicity: "mien",
+	Parent:    "southeast asian",
+	Aliases:   []string{"iu mien"},
+}
+
+var Karen = Ethnicity{
+	Ethnicity: "karen",
+	Parent:    "burmese",
+}
+
+var Punjabi = Ethnicity{
+	Ethnicity: "punjabi",
+	Parent:    "south asian",
+}
+
+var Gujarati = Ethnicity{
+	Ethnicity: "gujarati",
+	Parent:    "indian",
+}
+
+var Tamil = Ethnicity{
+	Ethnicity: "tamil",
+	Parent:    "south asian",
+}
+
+var Uyghur = Ethnicity{
+	Ethnicity: "uyghur",
+	Parent:    "central asian",
+	Aliases:   []string{"uighur"},
+}
+
+// Regions are the broadest groups of Asian and Pacific Islander ethnicities. A human can be given
+// a region when it isn't known which ethnicity within it they are.
+
+var EastAsian = Ethnicity{
+	Ethnicity: "east asian",
+}
+
+var SoutheastAsian = Ethnicity{
+	Ethnicity: "southeast asian",
+}
+
+var SouthAsian = Ethnicity{
+	Ethnicity: "south asian",
+}
+
+var CentralAsian = Ethnicity{
+	Ethnicity: "central asian",
+}
+
+var PacificIslander = Ethnicity{
+	Ethnicity: "pacific islander",
+	Aliases:   []string{"native hawaiian and pacific islander", "nhpi"},
+}
+
+var Regions = []Ethnicity{
+	EastAsian,
+	SoutheastAsian,
+	SouthAsian,
+	CentralAsian,
+	PacificIslander,
+}
+
+// Non-Asian heritage, for humans of mixed descent. It is kept out of the regions.
+
+var Canadian = Ethnicity{
+	Ethnicity: "canadian",
+}
+
+var Dutch = Ethnicity{
+	Ethnicity: "dutch",
 }
 
 var Jewish = Ethnicity{
@@ -180,40 +408,208 @@ var Mixed = Ethnicity{
 	Ethnicity: "mixed",
 }
 
-var All = []Ethnicity{
-	Afghan,
-	Bengalis,
-	Bhutanese,
-	Bruneian,
-	Burmese,
-	Cambodian,
-	Chinese,
-	Filipino,
-	HongKong,
-	Indian,
-	Indonesian,
-	Japanese,
-	Korean,
-	Laotian,
-	Macanese,
-	Malaysian,
-	Maldivian,
-	Mongolian,
-	Nepali,
-	Singaporean,
-	SriLankan,
-	Taiwanese,
-	Thai,
-	Vietnamese,
+var Heritage = []Ethnicity{
 	Canadian,
 	Dutch,
-	Hawaiian,
 	Jewish,
 	Russian,
 	White,
 	Mixed,
 }
 
+// All lists every ethnicity, region by region: the region, then the ethnicities within it, each
+// followed by its subgroups. Non-Asian heritage comes last.
+var All = slices.Concat(
+	[]Ethnicity{
+		EastAsian,
+		Chinese,
+		Cantonese,
+		Hakka,
+		Taishanese,
+		HongKong,
+		Japanese,
+		Okinawan,
+		Korean,
+		Macanese,
+		Mongolian,
+		Taiwanese,
+		Tibetan,
+	},
+	[]Ethnicity{
+		SoutheastAsian,
+		Bruneian,
+		Burmese,
+		Karen,
+		Cambodian,
+		Filipino,
+		Hmong,
+		Indonesian,
+		Laotian,
+		Malaysian,
+		Mien,
+		Singaporean,
+		Thai,
+		Timorese,
+		Vietnamese,
+	},
+	[]Ethnicity{
+		SouthAsian,
+		Afghan,
+		Bangladeshi,
+		Bhutanese,
+		Indian,
+		Gujarati,
+		Maldivian,
+		Nepali,
+		Pakistani,
+		Punjabi,
+		SriLankan,
+		Tamil,
+	},
+	[]Ethnicity{
+		CentralAsian,
+		Kazakh,
+		Kyrgyz,
+		Tajik,
+		Turkmen,
+		Uyghur,
+		Uzbek,
+	},
+	[]Ethnicity{
+		PacificIslander,
+		Chamorro,
+		Fijian,
+		Hawaiian,
+		Marshallese,
+		Samoan,
+		Tongan,
+	},
+	Heritage,
+)
+
+// Group is a region with every ethnicity within it, or the non-Asian heritage, for listing them
+// under a heading.
+type Group struct {
+	Name        string
+	Ethnicities []Ethnicity
+}
+
+// Groups returns the ethnicities of All grouped by region. The first ethnicity of a region's
+// group is the region itself.
+func Groups() []Group {
+	var groups []Group
+	for _, region := range Regions {
+		group := Group{Name: region.Ethnicity}
+		for _, e := range All {
+			if Is(e.Ethnicity, region.Ethnicity) {
+				group.Ethnicities = append(group.Ethnicities, e)
+			}
+		}
+		groups = append(groups, group)
+	}
+	return append(groups, Group{Name: "other heritage", Ethnicities: Heritage})
+}
+
+// key is how ethnicities are compared: case, hyphens, extra spaces and a trailing "american"
+// don't matter, so "Chinese-American" is "chinese".
+func key(s string) string {
+	s = strings.ToLower(strings.NewReplacer("-", " ", "_", " ").Replace(s))
+	fields := strings.Fields(s)
+	if len(fields) > 1 && fields[len(fields)-1] == "american" {
+		fields = fields[:len(fields)-1]
+	}
+	return strings.Join(fields, " ")
+}
+
+// Get finds an ethnicity by its name or one of its aliases.
+func Get(name string) (Ethnicity, bool) {
+	k := key(name)
+	for _, e := range All {
+		if e.Ethnicity == k || slices.Contains(e.Aliases, k) {
+			return e, true
+		}
+	}
+	return Ethnicity{}, false
+}
+
+// Ancestors returns the groups the ethnicity belongs to, from its parent up to its region.
+func Ancestors(name string) []Ethnicity {
+	var ancestors []Ethnicity
+	e, ok := Get(name)
+	for ok && e.Parent != "" {
+		e, ok = Get(e.Parent)
+		if ok {
+			ancestors = append(ancestors, e)
+		}
+	}
+	return ancestors
+}
+
+// Region returns the region the ethnicity is in, which is the ethnicity itself for a region.
+// Non-Asian heritage is in no region.
+func Region(name string) (Ethnicity, bool) {
+	e, ok := Get(name)
+	if !ok {
+		return Ethnicity{}, false
+	}
+	if ancestors := Ancestors(name); len(ancestors) > 0 {
+		e = ancestors[len(ancestors)-1]
+	}
+	if !slices.ContainsFunc(Regions, func(r Ethnicity) bool { return r.Ethnicity == e.Ethnicity }) {
+		return Ethnicity{}, false
+	}
+	return e, true
+}
+
+// Children returns the ethnicities directly within the group.
+func Children(name string) []Ethnicity {
+	group, ok := Get(name)
+	if !ok {
+		return nil
+	}
+	var children []Ethnicity
+	for _, e := range All {
+		if e.Parent == group.Ethnicity {
+			children = append(children, e)
+		}
+	}
+	return children
+}
+
+// Is reports whether the ethnicity is the group, or within it. "vietnamese" is "southeast asian",
+// and "okinawan" is both "japanese" and "east asian". Names that aren't known are compared as
+// they are written.
+func Is(name, group string) bool {
+	e, ok := Get(name)
+	g, gok := Get(group)
+	if !ok || !gok {
+		return key(name) == key(group)
+	}
+	if e.Ethnicity == g.Ethnicity {
+		return true
+	}
+	return slices.ContainsFunc(Ancestors(e.Ethnicity), func(a Ethnicity) bool { return a.Ethnicity == g.Ethnicity })
+}
+
+// Normalize replaces aliases of ethnicities with their names and drops duplicates. It returns an
+// error for ethnicities that aren't known.
+func Normalize(names []string) ([]string, error) {
+	if len(names) == 0 {
+		return names, nil
+	}
+	normalized := make([]string, 0, len(names))
+	for _, name := range names {
+		e, ok := Get(name)
+		if !ok {
+			return nil, fmt.Errorf("invalid ethnicity: %s", strings.ToLower(strings.TrimSpace(name)))
+		}
+		if !slices.Contains(normalized, e.Ethnicity) {
+			normalized = append(normalized, e.Ethnicity)
+		}
+	}
+	return normalized, nil
+}
+
 func Validate(ethnicities []string) error {
 	approved := make(map[string]struct{})
 	for _, e := range All {
@@ -7,7 +7,24 @@ import (
 )
 
 func TestEthnicity(t *testing.T) {
-	require.Len(t, All, 31)
+	require.Len(t, All, 60)
+
+	seen := make(map[string]bool)
+	for _, e := range All {
+		require.False(t, seen[e.Ethnicity], "%v is listed twice", e.Ethnicity)
+		seen[e.Ethnicity] = true
+		if e.Parent != "" {
+			_, ok := Get(e.Parent)
+			require.True(t, ok, "parent of %v is not an ethnicity", e.Ethnicity)
+		}
+		for _, alias := range e.Aliases {
+			require.Equal(t, key(alias), alias, "alias %q of %v is not in the form it is looked up by", alias, e.Ethnicity)
+		}
+	}
+	for _, e := range Heritage {
+		_, ok := Region(e.Ethnicity)
+		require.False(t, ok, "%v is in a region", e.Ethnicity)
+	}
 }
 
 func TestValidate(t *testing.T) {
@@ -22,3 +39,56 @@ func TestValidate(t *testing.T) {
 		require.Contains(t, err.Error(), "invalid ethnicity: martian")
 	})
 }
+
+func TestNormalize(t *testing.T) {
+	normalized, err := Normalize([]string{"Chinese-American", "Bengalis", " Khmer ", "chinese", "Southeast Asian"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"chinese", "bangladeshi", "cambodian", "southeast asian"}, normalized)
+
+	_, err = Normalize([]string{"Korean", "Martian"})
+	require.EqualError(t, err, "invalid ethnicity: martian")
+}
+
+func TestIs(t *testing.T) {
+	require.True(t, Is("vietnamese", "southeast asian"))
+	require.True(t, Is("Filipino", "Southeast-Asian"))
+	require.True(t, Is("okinawan", "japanese"))
+	require.True(t, Is("okinawan", "east asian"))
+	require.True(t, Is("korean", "korean"))
+	require.False(t, Is("japanese", "okinawan"))
+	require.False(t, Is("indian", "east asian"))
+	require.False(t, Is("white", "east asian"))
+	require.True(t, Is("martian", "Martian"))
+}
+
+func TestRegion(t *testing.T) {
+	region, ok := Region("karen")
+	require.True(t, ok)
+	require.Equal(t, SoutheastAsian, region)
+
+	region, ok = Region("pacific islander")
+	require.True(t, ok)
+	require.Equal(t, PacificIslander, region)
+
+	_, ok = Region("dutch")
+	require.False(t, ok)
+}
+
+func TestGroups(t *testing.T) {
+	groups := Groups()
+	require.Len(t, groups, len(Regions)+1)
+
+	var total int
+	for i, group := range groups[:len(Regions)] {
+		require.Equal(t, Regions[i], group.Ethnicities[0])
+		total += len(group.Ethnicities)
+	}
+	require.Equal(t, Heritage, groups[len(groups)-1].Ethnicities)
+	require.Equal(t, len(All), total+len(Heritage))
+
+	var children []string
+	for _, e := range Children("chinese") {
+		children = append(children, e.Ethnicity)
+	}
+	require.Equal(t, []string{"cantonese", "hakka", "taishanese"}, children)
+}
@@ -0,0 +1,169 @@
+package humandao
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrInvalidAward = errors.New("invalid award")
+
+type AwardResult string
+
+const (
+	AwardWon       AwardResult = "won"
+	AwardNominated AwardResult = "nominated"
+)
+
+var AwardResults = []AwardResult{AwardWon, AwardNominated}
+
+// Award is an honor a human won or was nominated for, like an Academy Award or an Olympic medal.
+type Award struct {
+	// Name is the award itself, e.g. "Academy Award" or "Pulitzer Prize". Humans with the same
+	// name are listed together on the page of the award.
+	Name string `firestore:"name"`
+	// Category is what the award was for, e.g. "Best Actress" or "Gold, Women's Singles".
+	Category string      `firestore:"category,omitempty"`
+	Year     int         `firestore:"year,omitempty"`
+	Result   AwardResult `firestore:"result"`
+	// Source links to where the award is recorded.
+	Source string `firestore:"source,omitempty"`
+}
+
+func (a Award) String() string {
+	s := a.Name
+	if a.Category != "" {
+		s = fmt.Sprintf("%v, %v", s, a.Category)
+	}
+	if a.Year != 0 {
+		s = fmt.Sprintf("%v (%d)", s, a.Year)
+	}
+	return fmt.Sprintf("%v %v", a.Result, s)
+}
+
+// Slug is the path of the page of the award.
+func (a Award) Slug() string {
+	return Slug(a.Name)
+}
+
+func sameAward(a, b Award) bool {
+	return strings.EqualFold(a.Name, b.Name) && strings.EqualFold(a.Category, b.Category) && a.Year == b.Year
+}
+
+// compareAwards orders awards chronologically, with awards of an unknown year last.
+func compareAwards(a, b Award) int {
+	if (a.Year == 0) != (b.Year == 0) {
+		if a.Year == 0 {
+			return 1
+		}
+		return -1
+	}
+	if c := cmp.Compare(a.Year, b.Year); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+		return c
+	}
+	return cmp.Compare(strings.ToLower(a.Category), strings.ToLower(b.Category))
+}
+
+// normalizeAwards trims the awards, checks them, drops duplicates and puts them in order.
+func normalizeAwards(awards []Award) ([]Award, error) {
+	if len(awards) == 0 {
+		return nil, nil
+	}
+	latestYear := time.Now().Year() + 1
+	normalized := make([]Award, 0, len(awards))
+	for _, award := range awards {
+		award.Name = strings.TrimSpace(award.Name)
+		award.Category = strings.TrimSpace(award.Category)
+		award.Result = AwardResult(strings.ToLower(strings.TrimSpace(string(award.Result))))
+		award.Source = strings.TrimSpace(award.Source)
+		if award.Name == "" {
+			return nil, fmt.Errorf("%w: name must be provided", ErrInvalidAward)
+		}
+		if !slices.Contains(AwardResults, award.Result) {
+			return nil, fmt.Errorf("%w: unknown result %q for %v", ErrInvalidAward, award.Result, award.Name)
+		}
+		if award.Year != 0 && (award.Year < 1000 || award.Year > latestYear) {
+			return nil, fmt.Errorf("%w: year %d of %v", ErrInvalidAward, award.Year, award.Name)
+		}
+		if award.Source != "" {
+			u, err := url.Parse(award.Source)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("%w: %q is not a http(s) url", ErrInvalidAward, award.Source)
+			}
+		}
+		if i := slices.IndexFunc(normalized, func(a Award) bool { return sameAward(a, award) }); i >= 0 {
+			// winning an award means having been nominated for it too
+			if award.Result == AwardWon {
+				normalized[i] = award
+			}
+			continue
+		}
+		normalized = append(normalized, award)
+	}
+	slices.SortStableFunc(normalized, compareAwards)
+	return normalized, nil
+}
+
+// mergeAwards returns the awards of both humans. An award on both that only one of them won is kept as won.
+func mergeAwards(winner, loser []Award) []Award {
+	merged := slices.Clone(winner)
+	for _, award := range loser {
+		i := slices.IndexFunc(merged, func(a Award) bool { return sameAward(a, award) })
+		switch {
+		case i < 0:
+			merged = append(merged, award)
+		case award.Result == AwardWon:
+			merged[i].Result = AwardWon
+		}
+	}
+	slices.SortStableFunc(merged, compareAwards)
+	return merged
+}
+
+// ByAward keeps the humans who won or were nominated for the award, given by name or by its slug.
+func ByAward(award string) FilterOpt {
+	slug := Slug(award)
+	return func(f Filterable) Filterable {
+		filtered := make([]Human, 0, len(f))
+		for _, human := range f {
+			if slices.ContainsFunc(human.Awards, func(a Award) bool { return a.Slug() == slug }) {
+				filtered = append(filtered, human)
+			}
+		}
+		return filtered
+	}
+}
+
+// Laureate is a human along with one of their awards.
+type Laureate struct {
+	Human Human
+	Award Award
+}
+
+// Laureates returns everyone who won or was nominated for the award, given by name or by its slug,
+// in chronological order. A human who got the award more than once is listed every time.
+func Laureates(humans []Human, award string) []Laureate {
+	slug := Slug(award)
+	var laureates []Laureate
+	for _, human := range humans {
+		for _, a := range human.Awards {
+			if a.Slug() == slug {
+				laureates = append(laureates, Laureate{Human: human, Award: a})
+			}
+		}
+	}
+	slices.SortStableFunc(laureates, func(a, b Laureate) int {
+		if c := compareAwards(a.Award, b.Award); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Human.Name, b.Human.Name)
+	})
+	return laureates
+}
@@ -0,0 +1,86 @@
+package humandao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeAwards(t *testing.T) {
+	awards, err := normalizeAwards([]Award{
+		{Name: " Academy Award ", Category: "Best Actress", Year: 2023, Result: "Won"},
+		{Name: "Golden Globe Award", Category: "Best Actress", Result: AwardNominated},
+		{Name: "Academy Award", Category: "Best Actress", Year: 2023, Result: AwardNominated},
+		{Name: "BAFTA Award", Category: "Best Actress", Year: 2023, Result: AwardNominated},
+		{Name: "Golden Globe Award", Category: "Best Actress", Result: AwardWon},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Award{
+		{Name: "Academy Award", Category: "Best Actress", Year: 2023, Result: AwardWon},
+		{Name: "BAFTA Award", Category: "Best Actress", Year: 2023, Result: AwardNominated},
+		{Name: "Golden Globe Award", Category: "Best Actress", Result: AwardWon},
+	}, awards)
+
+	for name, award := range map[string]Award{
+		"no-name": {Result: AwardWon},
+		"result":  {Name: "Academy Award", Result: "lost"},
+		"year":    {Name: "Academy Award", Result: AwardWon, Year: 3000},
+		"source":  {Name: "Academy Award", Result: AwardWon, Source: "oscars.org"},
+	} {
+		_, err := normalizeAwards([]Award{award})
+		require.ErrorIs(t, err, ErrInvalidAward, name)
+	}
+}
+
+func TestFilterable_ByAward(t *testing.T) {
+	f := Filterable{
+		{Name: "Michelle Yeoh", Awards: []Award{{Name: "Academy Award", Year: 2023, Result: AwardWon}}},
+		{Name: "Chloe Kim", Awards: []Award{{Name: "Olympic Gold Medal", Year: 2018, Result: AwardWon}}},
+		{Name: "Ke Huy Quan", Awards: []Award{{Name: "Academy Award", Year: 2023, Result: AwardWon}}},
+	}
+
+	require.Equal(t, Filterable{f[0], f[2]}, ByAward("academy award")(f))
+	require.Equal(t, Filterable{f[1]}, ByAward("olympic-gold-medal")(f))
+}
+
+func TestLaureates(t *testing.T) {
+	humans := []Human{
+		{Name: "Ang Lee", Awards: []Award{
+			{Name: "Academy Award", Category: "Best Director", Year: 2013, Result: AwardWon},
+			{Name: "Academy Award", Category: "Best Director", Year: 2006, Result: AwardWon},
+		}},
+		{Name: "Michelle Yeoh", Awards: []Award{{Name: "Academy Award", Category: "Best Actress", Year: 2023, Result: AwardWon}}},
+		{Name: "Ali Wong", Awards: []Award{{Name: "Emmy Award", Year: 2023, Result: AwardWon}}},
+	}
+
+	var got []string
+	for _, laureate := range Laureates(humans, "academy-award") {
+		got = append(got, laureate.Award.String()+" "+laureate.Human.Name)
+	}
+	require.Equal(t, []string{
+		"won Academy Award, Best Director (2006) Ang Lee",
+		"won Academy Award, Best Director (2013) Ang Lee",
+		"won Academy Award, Best Actress (2023) Michelle Yeoh",
+	}, got)
+}
+
+func TestMemoryDAO_Awards(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	_, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderMale, Awards: []Award{{Name: "Academy Award"}}})
+	require.ErrorIs(t, err, ErrInvalidAward)
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderMale})
+	require.NoError(t, err)
+
+	awards := []Award{{Name: "Academy Award", Year: 2023, Result: AwardNominated}}
+	patched, err := dao.PatchHuman(ctx, human.ID, HumanPatch{Awards: &awards})
+	require.NoError(t, err)
+	require.Equal(t, awards, patched.Awards)
+
+	merged := MergeHumans(patched, Human{Awards: []Award{{Name: "Academy Award", Year: 2023, Result: AwardWon}}}, nil)
+	require.Equal(t, []Award{{Name: "Academy Award", Year: 2023, Result: AwardWon}}, merged.Awards)
+	require.Equal(t, AwardNominated, patched.Awards[0].Result)
+}
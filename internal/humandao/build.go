@@ -3,8 +3,10 @@ package humandao
 import "cloud.google.com/go/firestore"
 
 type DAO struct {
-	client          *firestore.Client
-	humanCollection string
+	client                 *firestore.Client
+	humanCollection        string
+	relationshipCollection string
+	tagCollection          string
 }
 
 type Option func(d *DAO)
@@ -15,10 +17,24 @@ func WithHumanCollectionName(name string) Option {
 	}
 }
 
+func WithRelationshipCollectionName(name string) Option {
+	return func(d *DAO) {
+		d.relationshipCollection = name
+	}
+}
+
+func WithTagCollectionName(name string) Option {
+	return func(d *DAO) {
+		d.tagCollection = name
+	}
+}
+
 func NewDAO(client *firestore.Client, options ...Option) *DAO {
 	dao := &DAO{
-		client:          client,
-		humanCollection: "humans",
+		client:                 client,
+		humanCollection:        "humans",
+		relationshipCollection: "relationships",
+		tagCollection:          "tags",
 	}
 
 	for _, opt := range options {
@@ -12,6 +12,7 @@ import (
 	"cloud.google.com/go/firestore"
 	"github.com/go-chi/httplog"
 	"github.com/raymonstah/asianamericanswiki/internal/ethnicity"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
 	"github.com/segmentio/ksuid"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/api/iterator"
@@ -25,20 +26,69 @@ var (
 	ErrInvalidOrderBy     = errors.New("orderBy must be one of: created_at, views")
 	ErrInvalidGender      = errors.New("invalid gender")
 	ErrInvalidEthnicity   = errors.New("invalid ethnicity")
+	ErrConflict           = errors.New("human was modified since it was read")
+	ErrHumanNotDeleted    = errors.New("human must be deleted before it can be purged")
+	ErrHumanMerged        = errors.New("human was merged into another human")
 )
 
+// IsInvalidHuman reports whether err is from a human, or a change to one, failing validation, as
+// opposed to from storing it.
+func IsInvalidHuman(err error) bool {
+	for _, invalid := range []error{
+		ErrInvalidName,
+		ErrInvalidGender,
+		ErrInvalidEthnicity,
+		ErrInvalidDate,
+		ErrInvalidSource,
+		ErrInvalidPlace,
+		ErrInvalidWork,
+		ErrInvalidAward,
+		ErrInvalidTag,
+		ErrInvalidImage,
+	} {
+		if errors.Is(err, invalid) {
+			return true
+		}
+	}
+	return false
+}
+
+// ConflictError is returned by UpdateHuman when the stored human is at a different version
+// than the one the update was based on. It matches ErrConflict with errors.Is.
+type ConflictError struct {
+	HumanID  string
+	Expected int64
+	// Current is the human as it is stored now.
+	Current Human
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%v: %v (expected version %v, found %v)", ErrConflict, e.HumanID, e.Expected, e.Current.Version)
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
 type Human struct {
-	ID            string   `firestore:"-"`
-	Name          string   `firestore:"name"`
-	Aliases       []string `firestore:"aliases,omitempty"`
-	Path          string   `firestore:"urn_path"`
-	DOB           string   `firestore:"dob,omitempty"`
-	DOD           string   `firestore:"dod,omitempty"`
-	Tags          []string `firestore:"tags,omitempty"`
-	Ethnicity     []string `firestore:"ethnicity,omitempty"`
-	BirthLocation string   `firestore:"birth_location,omitempty"`
-	Location      []string `firestore:"location,omitempty"`
-	InfluencedBy  []string `firestore:"influenced_by,omitempty"`
+	ID            string        `firestore:"-"`
+	Name          string        `firestore:"name"`
+	Aliases       []string      `firestore:"aliases,omitempty"`
+	Names         []NameVariant `firestore:"names,omitempty"` // the name in other scripts, or romanized
+	Path          string        `firestore:"urn_path"`
+	PreviousPaths []string      `firestore:"previous_paths,omitempty"` // old paths that redirect to Path
+	DOB           PartialDate   `firestore:"dob,omitempty"`
+	DOD           PartialDate   `firestore:"dod,omitempty"`
+	Tags          []string      `firestore:"tags,omitempty"`
+	Ethnicity     []string      `firestore:"ethnicity,omitempty"`
+	BirthPlace    *place.Place  `firestore:"birth_place,omitempty"`
+	BasedIn       []place.Place `firestore:"based_in,omitempty"` // where they live or work
+	// deprecated: use BirthPlace instead.
+	BirthLocation string `firestore:"birth_location,omitempty"`
+	// deprecated: use BasedIn instead.
+	Location []string `firestore:"location,omitempty"`
+	// deprecated: use relationships of type RelationshipInfluencedBy instead.
+	InfluencedBy []string `firestore:"influenced_by,omitempty"`
 	// deprecated: use Images instead.
 	FeaturedImage string `firestore:"featured_image,omitempty"`
 	Draft         bool   `firestore:"draft"`
@@ -48,7 +98,9 @@ type Human struct {
 	CreatedAt time.Time `firestore:"created_at"`
 	CreatedBy string    `firestore:"created_by,omitempty"`
 
-	UpdatedAt   time.Time `firestore:"updated_at"`
+	UpdatedAt time.Time `firestore:"updated_at"`
+	// Version is bumped on every write. UpdateHuman only succeeds if it matches the stored version.
+	Version     int64     `firestore:"version"`
 	PublishedBy string    `firestore:"published_by,omitempty"`
 	PublishedAt time.Time `firestore:"published_at,omitempty"`
 	Socials     Socials   `firestore:"socials,omitempty"`
@@ -59,13 +111,31 @@ type Human struct {
 	Similar []string `firestore:"similar,omitempty"`
 
 	Images Images `firestore:"images,omitempty"`
+
+	// Works are the films, albums, books and so on the human made or took part in, oldest first.
+	Works []Work `firestore:"works,omitempty"`
+	// Awards are the honors the human won or was nominated for, in chronological order.
+	Awards []Award `firestore:"awards,omitempty"`
+
+	// Sources back up the facts above. See Unsourced and Footnotes.
+	Sources []Source `firestore:"sources,omitempty"`
+
+	// DeletedAt is set when the human is moved to the trash. Deleted humans are hidden
+	// everywhere except the trash until they are restored or purged.
+	DeletedAt time.Time `firestore:"deleted_at,omitempty"`
+	DeletedBy string    `firestore:"deleted_by,omitempty"`
+	// MergedInto is set on the loser of a merge, which is moved to the trash. Its paths redirect
+	// to the winner, so it can only be purged, not restored.
+	MergedInto string `firestore:"merged_into,omitempty"`
 }
 
-type Images struct {
-	Featured  string `firestore:"featured,omitempty"`
-	Thumbnail string `firestore:"thumbnail,omitempty"`
+// Deleted reports whether the human is in the trash.
+func (h Human) Deleted() bool {
+	return !h.DeletedAt.IsZero()
 }
 
+// CurrentAge describes how old the human is, or was when they died. When a date is only known to
+// the year or month and the age could be either of two numbers, the older one is shown as "about".
 func (h Human) CurrentAge(inputTime ...time.Time) (string, error) {
 	now := time.Now()
 	if len(inputTime) > 0 {
@@ -74,88 +144,25 @@ func (h Human) CurrentAge(inputTime ...time.Time) (string, error) {
 	if h.DOB == "" {
 		return "", nil
 	}
-
-	born, err := parseDateString(h.DOB)
-	if err != nil {
+	if err := h.DOB.Validate(); err != nil {
 		return "", err
 	}
 
+	end := NewPartialDate(now.Date())
+	format := "%v y/o"
 	if h.DOD != "" {
-		died, err := parseDateString(h.DOD)
-		if err != nil {
+		if err := h.DOD.Validate(); err != nil {
 			return "", err
 		}
-		ageInYears, _, _, _, _, _ := diff(died, born)
-		return fmt.Sprintf("died at %v y/o", ageInYears), nil
-	}
-
-	ageInYears, _, _, _, _, _ := diff(now, born)
-	return fmt.Sprintf("%v y/o", ageInYears), nil
-}
-
-func parseDateString(date string) (time.Time, error) {
-	format := "2006-01-02"
-	if len(date) == 4 {
-		// only have the year
-		format = "2006"
-	} else if len(date) == 7 {
-		// only have the year and month
-		format = "2006-01"
-	}
-
-	res, err := time.Parse(format, date)
-	if err != nil {
-		return time.Time{}, err
-	}
-
-	return res, nil
-}
-
-func diff(a, b time.Time) (year, month, day, hour, min, sec int) { //nolint:unparam
-	if a.Location() != b.Location() {
-		b = b.In(a.Location())
+		end = h.DOD
+		format = "died at %v y/o"
 	}
-	if a.After(b) {
-		a, b = b, a
-	}
-	y1, M1, d1 := a.Date()
-	y2, M2, d2 := b.Date()
-
-	h1, m1, s1 := a.Clock()
-	h2, m2, s2 := b.Clock()
-
-	year = int(y2 - y1)
-	month = int(M2 - M1)
-	day = int(d2 - d1)
-	hour = int(h2 - h1)
-	min = int(m2 - m1)
-	sec = int(s2 - s1)
 
-	// Normalize negative values
-	if sec < 0 {
-		sec += 60
-		min--
+	youngest, oldest := ageRange(h.DOB, end)
+	if youngest != oldest {
+		return fmt.Sprintf(format, fmt.Sprintf("about %v", oldest)), nil
 	}
-	if min < 0 {
-		min += 60
-		hour--
-	}
-	if hour < 0 {
-		hour += 24
-		day--
-	}
-	if day < 0 {
-		// days in month:
-		t := time.Date(y1, M1, 32, 0, 0, 0, 0, time.UTC)
-		day += 32 - t.Day()
-		month--
-	}
-	if month < 0 {
-		month += 12
-		year--
-	}
-
-	return
+	return fmt.Sprintf(format, oldest), nil
 }
 
 type Gender string
@@ -183,6 +190,8 @@ type Socials struct {
 type HumanInput struct {
 	HumanID string
 	Path    string
+	// IncludeDeleted returns the human even if it is in the trash.
+	IncludeDeleted bool
 }
 
 func (d *DAO) Human(ctx context.Context, input HumanInput) (human Human, err error) {
@@ -193,6 +202,11 @@ func (d *DAO) Human(ctx context.Context, input HumanInput) (human Human, err err
 	} else if input.Path != "" {
 		doc, err = d.client.Collection(d.humanCollection).Where("urn_path", "==", input.Path).
 			Documents(ctx).Next()
+		if err == iterator.Done {
+			// the human may have been renamed since the path was handed out
+			doc, err = d.client.Collection(d.humanCollection).Where("previous_paths", "array-contains", input.Path).
+				Documents(ctx).Next()
+		}
 	}
 	if err != nil {
 		if status.Code(err) == codes.NotFound || err == iterator.Done {
@@ -214,6 +228,13 @@ func (d *DAO) Human(ctx context.Context, input HumanInput) (human Human, err err
 	if err != nil {
 		return Human{}, fmt.Errorf("unable to convert human: %w", err)
 	}
+	if human.Deleted() && !input.IncludeDeleted {
+		identifier := input.HumanID
+		if input.Path != "" {
+			identifier = input.Path
+		}
+		return Human{}, fmt.Errorf("%w: %v", ErrHumanNotFound, identifier)
+	}
 
 	human.ID = doc.Ref.ID
 	return human, nil
@@ -263,106 +284,267 @@ func (d *DAO) HumansByID(ctx context.Context, input HumansByIDInput) ([]Human, e
 	return orderedHumans, nil
 }
 
-func (d *DAO) UpdateHuman(ctx context.Context, human Human) error {
+// UpdateHuman replaces the human, and returns it as it was stored: with its new path, the paths
+// that now redirect to it, and its bumped version.
+func (d *DAO) UpdateHuman(ctx context.Context, human Human) (Human, error) {
+	human, err := prepareUpdate(human)
+	if err != nil {
+		return Human{}, err
+	}
+	taxonomy, err := d.taxonomyFor(ctx, human.Tags)
+	if err != nil {
+		return Human{}, err
+	}
+	var updated Human
+	ref := d.client.Collection(d.humanCollection).Doc(human.ID)
+	err = d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		var previous Human
+		next := human
+		if doc != nil && doc.Exists() {
+			previous, err = convertHumanDoc(doc)
+			if err != nil {
+				return err
+			}
+			next, err = checkVersion(previous, human)
+			if err != nil {
+				return err
+			}
+			next.PreviousPaths = renamedPaths(previous, next.Path)
+		}
+		next.Tags, err = taxonomy.normalizeKeeping(next.Tags, previous.Tags)
+		if err != nil {
+			return err
+		}
+		if next.Path != previous.Path {
+			if err := d.checkPathAvailable(tx, next.ID, next.Path); err != nil {
+				return err
+			}
+		}
+		next.Version++
+
+		if err := tx.Set(ref, next); err != nil {
+			return err
+		}
+		revision := newRevision(ctx, RevisionActionUpdate, "", previous, next)
+		if err := tx.Create(d.revisions(next.ID).Doc(revision.ID), revision); err != nil {
+			return err
+		}
+
+		updated = next
+		return nil
+	})
+	if err != nil {
+		return Human{}, fmt.Errorf("unable to update human: %v (%v): %w", human.Name, human.ID, err)
+	}
+
+	return updated, nil
+}
+
+// checkVersion makes sure human was based on the stored version of it. Views and deletion
+// happen outside of edits, so the stored values win over whatever the caller read.
+func checkVersion(stored, human Human) (Human, error) {
+	if stored.Version != human.Version {
+		return Human{}, &ConflictError{HumanID: human.ID, Expected: human.Version, Current: stored}
+	}
+	human.Views = stored.Views
+	human.DeletedAt = stored.DeletedAt
+	human.DeletedBy = stored.DeletedBy
+	return human, nil
+}
+
+// prepareUpdate normalizes a human before it is written by UpdateHuman.
+func prepareUpdate(human Human) (Human, error) {
 	human.UpdatedAt = time.Now()
 	human.Path = Slug(human.Name)
-	for i, eth := range human.Ethnicity {
-		human.Ethnicity[i] = strings.ToLower(eth)
+	ethnicities, err := ethnicity.Normalize(human.Ethnicity)
+	if err != nil {
+		return Human{}, fmt.Errorf("%w: %v", ErrInvalidEthnicity, err)
 	}
-	if err := ethnicity.Validate(human.Ethnicity); err != nil {
-		return fmt.Errorf("%w: %v", ErrInvalidEthnicity, err)
+	human.Ethnicity = ethnicities
+	if err := validateDates(human.DOB, human.DOD); err != nil {
+		return Human{}, err
 	}
-	_, err := d.client.Collection(d.humanCollection).
-		Doc(human.ID).
-		Set(ctx, human)
+	sources, err := normalizeSources(human.Sources)
 	if err != nil {
-		return fmt.Errorf("unable to update human: %v (%v): %w", human.Name, human.ID, err)
+		return Human{}, err
 	}
-
-	return nil
+	human.Sources = sources
+	works, err := normalizeWorks(human.Works)
+	if err != nil {
+		return Human{}, err
+	}
+	human.Works = works
+	names, err := normalizeNames(human.Names)
+	if err != nil {
+		return Human{}, err
+	}
+	human.Names = names
+	birthPlace, err := normalizePlace(human.BirthPlace)
+	if err != nil {
+		return Human{}, err
+	}
+	human.BirthPlace = birthPlace
+	basedIn, err := normalizePlaces(human.BasedIn)
+	if err != nil {
+		return Human{}, err
+	}
+	human.BasedIn = basedIn
+	awards, err := normalizeAwards(human.Awards)
+	if err != nil {
+		return Human{}, err
+	}
+	human.Awards = awards
+	images, err := normalizeImages(human.Images)
+	if err != nil {
+		return Human{}, err
+	}
+	human.Images = images
+	return human, nil
 }
 
 type AddHumanInput struct {
 	HumanID     string
 	Name        string
 	Aliases     []string
-	DOB         string
-	DOD         string
+	Names       []NameVariant
+	DOB         PartialDate
+	DOD         PartialDate
 	Ethnicity   []string
 	Description string
-	Location    []string
+	BirthPlace  *place.Place
+	BasedIn     []place.Place
 	Website     string
 	Twitter     string
 	Instagram   string
 	IMDB        string
+	YouTube     string
 	Tags        []string
 	Draft       bool
+	AIGenerated bool
 	CreatedBy   string
 	Gender      Gender
+	Sources     []Source
+	Works       []Work
+	Awards      []Award
 }
 
 func (d *DAO) AddHuman(ctx context.Context, input AddHumanInput) (Human, error) {
 	path := Slug(input.Name)
 	if input.Name == "" {
-		return Human{}, fmt.Errorf("name must be provided")
+		return Human{}, fmt.Errorf("%w: name must be provided", ErrInvalidName)
 	}
 
-	_, err := d.Human(ctx, HumanInput{Path: path})
+	human, err := newHuman(input)
 	if err != nil {
-		if !errors.Is(err, ErrHumanNotFound) {
-			return Human{}, fmt.Errorf("error checking if human (%v) exists: %w", path, err)
-		}
+		return Human{}, err
+	}
+	taxonomy, err := d.taxonomyFor(ctx, human.Tags)
+	if err != nil {
+		return Human{}, err
+	}
+	if human.Tags, err = taxonomy.Normalize(human.Tags); err != nil {
+		return Human{}, err
 	}
-	if err == nil {
-		return Human{}, ErrHumanAlreadyExists
+
+	if input.HumanID == "" {
+		input.HumanID = ksuid.New().String()
 	}
 
+	human.ID = input.HumanID
+	err = d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		// Trashed humans keep their paths, so they can be restored without taking someone else's.
+		if err := d.checkPathAvailable(tx, human.ID, path); err != nil {
+			return err
+		}
+		if err := tx.Create(d.client.Collection(d.humanCollection).Doc(human.ID), human); err != nil {
+			return err
+		}
+		revision := newRevision(ctx, RevisionActionCreate, input.CreatedBy, Human{}, human)
+		return tx.Create(d.revisions(human.ID).Doc(revision.ID), revision)
+	})
+	if err != nil {
+		return Human{}, fmt.Errorf("unable to create human: %w", err)
+	}
+
+	return human, nil
+}
+
+// newHuman validates the input and builds the human that AddHuman will create.
+func newHuman(input AddHumanInput) (Human, error) {
 	_, ok := ValidGenders[input.Gender]
 	if !ok {
 		return Human{}, ErrInvalidGender
 	}
 
-	for i, eth := range input.Ethnicity {
-		input.Ethnicity[i] = strings.ToLower(eth)
-	}
-	if err := ethnicity.Validate(input.Ethnicity); err != nil {
+	ethnicities, err := ethnicity.Normalize(input.Ethnicity)
+	if err != nil {
 		return Human{}, fmt.Errorf("%w: %v", ErrInvalidEthnicity, err)
 	}
+	input.Ethnicity = ethnicities
+	if err := validateDates(input.DOB, input.DOD); err != nil {
+		return Human{}, err
+	}
+	sources, err := normalizeSources(input.Sources)
+	if err != nil {
+		return Human{}, err
+	}
+	works, err := normalizeWorks(input.Works)
+	if err != nil {
+		return Human{}, err
+	}
+	awards, err := normalizeAwards(input.Awards)
+	if err != nil {
+		return Human{}, err
+	}
+	names, err := normalizeNames(input.Names)
+	if err != nil {
+		return Human{}, err
+	}
+	birthPlace, err := normalizePlace(input.BirthPlace)
+	if err != nil {
+		return Human{}, err
+	}
+	basedIn, err := normalizePlaces(input.BasedIn)
+	if err != nil {
+		return Human{}, err
+	}
 
 	now := time.Now().In(time.UTC)
 	human := Human{
 		Name:        input.Name,
 		Aliases:     input.Aliases,
+		Names:       names,
 		DOB:         input.DOB,
 		DOD:         input.DOD,
 		Ethnicity:   input.Ethnicity,
 		Description: input.Description,
-		Location:    input.Location,
+		BirthPlace:  birthPlace,
+		BasedIn:     basedIn,
 		Tags:        input.Tags,
 		Draft:       input.Draft,
 		CreatedAt:   now,
 		CreatedBy:   input.CreatedBy,
-		Path:        path,
+		Path:        Slug(input.Name),
 		UpdatedAt:   now,
 		Socials: Socials{
 			Website:   input.Website,
 			X:         input.Twitter,
 			IMDB:      input.IMDB,
 			Instagram: input.Instagram,
+			YouTube:   input.YouTube,
 		},
-		Gender: input.Gender,
-	}
-
-	if input.HumanID == "" {
-		input.HumanID = ksuid.New().String()
-	}
-
-	_, err = d.client.Collection(d.humanCollection).Doc(input.HumanID).Create(ctx, human)
-	if err != nil {
-		return Human{}, fmt.Errorf("unable to create human: %w", err)
+		AIGenerated: input.AIGenerated,
+		Gender:      input.Gender,
+		Sources:     sources,
+		Works:       works,
+		Awards:      awards,
 	}
 
-	human.ID = input.HumanID
 	return human, nil
 }
 
@@ -375,15 +557,20 @@ var (
 	OrderByViews     OrderBy = "views"
 )
 
+// ListHumansInput pages through humans. A zero Limit returns all of them in one go; to walk
+// everything a page at a time, use IterateHumans.
 type ListHumansInput struct {
-	Limit         int
-	Offset        int
+	Limit int
+	// PageToken is the next page token returned by the previous call with the same input.
+	PageToken     string
 	OrderBy       OrderBy
 	Direction     firestore.Direction
 	IncludeDrafts bool
 }
 
-func (d *DAO) ListHumans(ctx context.Context, input ListHumansInput) ([]Human, error) {
+// ListHumans returns a page of humans, along with the token for the page after it. The token is
+// empty on the last page.
+func (d *DAO) ListHumans(ctx context.Context, input ListHumansInput) ([]Human, string, error) {
 	allowedOrderBy := map[OrderBy]struct{}{
 		OrderByCreatedAt: {},
 		OrderByViews:     {},
@@ -393,87 +580,77 @@ func (d *DAO) ListHumans(ctx context.Context, input ListHumansInput) ([]Human, e
 	if !input.IncludeDrafts {
 		query = query.Where("draft", "==", false)
 	}
-	if input.OrderBy == "" {
-		query = query.OrderBy(string(OrderByCreatedAt), firestore.Desc)
-	} else {
-		if _, ok := allowedOrderBy[input.OrderBy]; !ok {
-			return nil, ErrInvalidOrderBy
-		}
-		query = query.OrderBy(string(input.OrderBy), input.Direction)
+	orderBy, direction := input.OrderBy, input.Direction
+	if orderBy == "" {
+		orderBy, direction = OrderByCreatedAt, firestore.Desc
+	}
+	if _, ok := allowedOrderBy[orderBy]; !ok {
+		return nil, "", ErrInvalidOrderBy
 	}
-	docs, err := query.
-		Offset(input.Offset).
-		Limit(input.Limit).
-		Documents(ctx).
-		GetAll()
+	humans, next, err := d.listHumans(ctx, query, orderBy, direction, input.PageToken, input.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get humans: %w", err)
+		return nil, "", fmt.Errorf("unable to get humans: %w", err)
 	}
 
-	return convertHumansDocs(docs)
+	return humans, next, nil
 }
 
 type CreatedByInput struct {
 	CreatedBy string
 	Limit     int
-	Offset    int
+	PageToken string
 }
 
-func (d *DAO) CreatedBy(ctx context.Context, input CreatedByInput) ([]Human, error) {
-	docs, err := d.client.Collection(d.humanCollection).
-		Where("created_by", "==", input.CreatedBy).
-		OrderBy("created_at", firestore.Desc).
-		Offset(input.Offset).
-		Limit(input.Limit).
-		Documents(ctx).
-		GetAll()
+func (d *DAO) CreatedBy(ctx context.Context, input CreatedByInput) ([]Human, string, error) {
+	query := d.client.Collection(d.humanCollection).
+		Where("created_by", "==", input.CreatedBy)
+	humans, next, err := d.listHumans(ctx, query, OrderByCreatedAt, firestore.Desc, input.PageToken, input.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get humans: %w", err)
+		return nil, "", fmt.Errorf("unable to get humans: %w", err)
 	}
 
-	return convertHumansDocs(docs)
+	return humans, next, nil
 }
 
 type UserDraftsInput struct {
-	Limit  int
-	Offset int
-	UserID string
+	Limit     int
+	PageToken string
+	UserID    string
 }
 
-func (d *DAO) UserDrafts(ctx context.Context, input UserDraftsInput) ([]Human, error) {
-	docs, err := d.client.Collection(d.humanCollection).
+func (d *DAO) UserDrafts(ctx context.Context, input UserDraftsInput) ([]Human, string, error) {
+	query := d.client.Collection(d.humanCollection).
 		Where("draft", "==", true).
-		Where("created_by", "==", input.UserID).
-		OrderBy("created_at", firestore.Desc).
-		Offset(input.Offset).
-		Limit(input.Limit).
-		Documents(ctx).
-		GetAll()
+		Where("created_by", "==", input.UserID)
+	humans, next, err := d.listHumans(ctx, query, OrderByCreatedAt, firestore.Desc, input.PageToken, input.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get humans: %w", err)
+		return nil, "", fmt.Errorf("unable to get humans: %w", err)
 	}
 
-	return convertHumansDocs(docs)
+	return humans, next, nil
 }
 
 type DraftsInput struct {
-	Limit  int
-	Offset int
+	Limit     int
+	PageToken string
 }
 
-func (d *DAO) Drafts(ctx context.Context, input DraftsInput) ([]Human, error) {
-	docs, err := d.client.Collection(d.humanCollection).
-		Where("draft", "==", true).
-		OrderBy("created_at", firestore.Asc).
-		Offset(input.Offset).
-		Limit(input.Limit).
-		Documents(ctx).
-		GetAll()
+func (d *DAO) Drafts(ctx context.Context, input DraftsInput) ([]Human, string, error) {
+	query := d.client.Collection(d.humanCollection).
+		Where("draft", "==", true)
+	humans, next, err := d.listHumans(ctx, query, OrderByCreatedAt, firestore.Asc, input.PageToken, input.Limit)
 	if err != nil {
-		return nil, fmt.Errorf("unable to get humans: %w", err)
+		return nil, "", fmt.Errorf("unable to get humans: %w", err)
 	}
 
-	return convertHumansDocs(docs)
+	return humans, next, nil
+}
+
+// listHumans returns a page of the humans query returns that aren't in the trash.
+func (d *DAO) listHumans(ctx context.Context, query firestore.Query, orderBy OrderBy, direction firestore.Direction, pageToken string, limit int) ([]Human, string, error) {
+	return listPage(ctx, query, orderBy, direction, pageToken, limit, collectHumans, func(last Human) string {
+		return newPageToken(orderBy, last)
+	})
 }
 
 type PublishInput struct {
@@ -481,17 +658,44 @@ type PublishInput struct {
 	UserID  string
 }
 
+// Publish takes a human out of drafts. Humans in the trash can't be published until they are
+// restored with Undelete.
 func (d *DAO) Publish(ctx context.Context, input PublishInput) error {
 	now := time.Now()
-	_, err := d.client.Collection(d.humanCollection).
-		Doc(input.HumanID).
-		Update(ctx, []firestore.Update{
+	ref := d.client.Collection(d.humanCollection).Doc(input.HumanID)
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		previous, err := convertHumanDoc(doc)
+		if err != nil {
+			return err
+		}
+		if previous.Deleted() {
+			return fmt.Errorf("%w: %v", ErrHumanNotFound, input.HumanID)
+		}
+
+		err = tx.Update(ref, []firestore.Update{
 			{Path: "draft", Value: false},
 			{Path: "published_by", Value: input.UserID},
 			{Path: "published_at", Value: now},
+			{Path: "version", Value: firestore.Increment(1)},
 		})
+		if err != nil {
+			return err
+		}
+
+		published := cloneHuman(previous)
+		published.Draft = false
+		published.PublishedBy = input.UserID
+		published.PublishedAt = now
+		published.Version++
+		revision := newRevision(ctx, RevisionActionPublish, input.UserID, previous, published)
+		return tx.Create(d.revisions(input.HumanID).Doc(revision.ID), revision)
+	})
 	if err != nil {
-		if status.Code(err) == codes.NotFound {
+		if status.Code(err) == codes.NotFound || errors.Is(err, ErrHumanNotFound) {
 			return ErrHumanNotFound
 		}
 		return fmt.Errorf("unable to update human: %v: %w", input.HumanID, err)
@@ -502,12 +706,46 @@ func (d *DAO) Publish(ctx context.Context, input PublishInput) error {
 
 type DeleteInput struct {
 	HumanID string
+	UserID  string
 }
 
+// Delete moves a human to the trash. It stays there, hidden, until it is restored with Undelete
+// or removed for good with Purge. Deleting a human that doesn't exist or is already deleted is a no-op.
 func (d *DAO) Delete(ctx context.Context, input DeleteInput) error {
-	_, err := d.client.Collection(d.humanCollection).
-		Doc(input.HumanID).
-		Delete(ctx)
+	now := time.Now()
+	ref := d.client.Collection(d.humanCollection).Doc(input.HumanID)
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return nil
+			}
+			return err
+		}
+		previous, err := convertHumanDoc(doc)
+		if err != nil {
+			return err
+		}
+		if previous.Deleted() {
+			return nil
+		}
+
+		err = tx.Update(ref, []firestore.Update{
+			{Path: "deleted_at", Value: now},
+			{Path: "deleted_by", Value: input.UserID},
+			{Path: "version", Value: firestore.Increment(1)},
+		})
+		if err != nil {
+			return err
+		}
+
+		deleted := cloneHuman(previous)
+		deleted.DeletedAt = now
+		deleted.DeletedBy = input.UserID
+		deleted.Version++
+		revision := newRevision(ctx, RevisionActionDelete, input.UserID, previous, deleted)
+		return tx.Create(d.revisions(input.HumanID).Doc(revision.ID), revision)
+	})
 	if err != nil {
 		return fmt.Errorf("unable to delete human: %v: %w", input.HumanID, err)
 	}
@@ -515,6 +753,7 @@ func (d *DAO) Delete(ctx context.Context, input DeleteInput) error {
 	return nil
 }
 
+// View bumps the view counter. Views aren't edits, so they don't record a revision.
 type ViewInput struct {
 	HumanID string
 }
@@ -532,8 +771,48 @@ func (d *DAO) View(ctx context.Context, input ViewInput) error {
 	return nil
 }
 
-func (d *DAO) Snapshots(ctx context.Context) *firestore.QuerySnapshotIterator {
-	return d.client.Collection(d.humanCollection).Snapshots(ctx)
+func (d *DAO) Snapshots(ctx context.Context) SnapshotIterator {
+	return &firestoreSnapshotIterator{
+		ctx: ctx,
+		it:  d.client.Collection(d.humanCollection).Snapshots(ctx),
+	}
+}
+
+type firestoreSnapshotIterator struct {
+	ctx context.Context
+	it  *firestore.QuerySnapshotIterator
+}
+
+func (f *firestoreSnapshotIterator) Next() (Snapshot, error) {
+	snap, err := f.it.Next()
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	changes := make([]Change, 0, len(snap.Changes))
+	for _, change := range snap.Changes {
+		human, err := convertHumanDoc(change.Doc)
+		if err != nil {
+			logger := httplog.LogEntry(f.ctx)
+			logger.Err(err).Str("id", change.Doc.Ref.ID).Msg("unable to decode human from firestore change")
+			continue
+		}
+
+		kind := ChangeAdded
+		switch change.Kind {
+		case firestore.DocumentModified:
+			kind = ChangeModified
+		case firestore.DocumentRemoved:
+			kind = ChangeRemoved
+		}
+		changes = append(changes, Change{Kind: kind, Human: human})
+	}
+
+	return Snapshot{Changes: changes}, nil
+}
+
+func (f *firestoreSnapshotIterator) Stop() {
+	f.it.Stop()
 }
 
 var (
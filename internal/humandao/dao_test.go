@@ -2,6 +2,7 @@ package humandao
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -32,15 +33,18 @@ func WithDAO(t *testing.T, do func(ctx context.Context, dao *DAO)) {
 	assert.NoError(t, err)
 
 	humanCollection := ksuid.New().String()
-	dao := NewDAO(client, WithHumanCollectionName(humanCollection))
+	relationshipCollection := ksuid.New().String()
+	dao := NewDAO(client, WithHumanCollectionName(humanCollection), WithRelationshipCollectionName(relationshipCollection))
 
 	t.Cleanup(func() {
 		ctx := context.Background()
-		humanDocs, err := client.Collection(humanCollection).DocumentRefs(ctx).GetAll()
-		assert.NoError(t, err)
-		for _, doc := range humanDocs {
-			_, err := doc.Delete(ctx)
+		for _, collection := range []string{humanCollection, relationshipCollection} {
+			docs, err := client.Collection(collection).DocumentRefs(ctx).GetAll()
 			assert.NoError(t, err)
+			for _, doc := range docs {
+				_, err := doc.Delete(ctx)
+				assert.NoError(t, err)
+			}
 		}
 	})
 
@@ -62,10 +66,9 @@ func TestDAO_CreatedBy(t *testing.T) {
 		assert.NoError(t, err)
 
 		t.Run("has-something-created", func(t *testing.T) {
-			humans, err := dao.CreatedBy(ctx, CreatedByInput{
+			humans, _, err := dao.CreatedBy(ctx, CreatedByInput{
 				CreatedBy: userID,
 				Limit:     10,
-				Offset:    0,
 			})
 			assert.NoError(t, err)
 			assert.Len(t, humans, 1)
@@ -73,10 +76,9 @@ func TestDAO_CreatedBy(t *testing.T) {
 			assert.Equal(t, human.ID, got.ID)
 		})
 		t.Run("has-nothing-created", func(t *testing.T) {
-			humans, err := dao.CreatedBy(ctx, CreatedByInput{
+			humans, _, err := dao.CreatedBy(ctx, CreatedByInput{
 				CreatedBy: "random-user",
 				Limit:     10,
-				Offset:    0,
 			})
 			assert.NoError(t, err)
 			assert.Empty(t, humans)
@@ -92,9 +94,8 @@ func TestDAO_Drafts(t *testing.T) {
 		_, err = dao.AddHuman(ctx, AddHumanInput{Name: "Foo", CreatedBy: userID, Draft: false, Gender: GenderFemale})
 		assert.NoError(t, err)
 
-		humans, err := dao.Drafts(ctx, DraftsInput{
-			Limit:  10,
-			Offset: 0,
+		humans, _, err := dao.Drafts(ctx, DraftsInput{
+			Limit: 10,
 		})
 		assert.NoError(t, err)
 		assert.Len(t, humans, 1)
@@ -111,10 +112,9 @@ func TestDAO_UserDrafts(t *testing.T) {
 		_, err = dao.AddHuman(ctx, AddHumanInput{Name: "Foo", CreatedBy: userID, Draft: false, Gender: GenderFemale})
 		assert.NoError(t, err)
 
-		humans, err := dao.UserDrafts(ctx, UserDraftsInput{
+		humans, _, err := dao.UserDrafts(ctx, UserDraftsInput{
 			UserID: userID,
 			Limit:  10,
-			Offset: 0,
 		})
 		assert.NoError(t, err)
 		assert.Len(t, humans, 1)
@@ -122,10 +122,9 @@ func TestDAO_UserDrafts(t *testing.T) {
 		got := humans[0]
 		assert.Equal(t, human.ID, got.ID)
 
-		humans, err = dao.UserDrafts(ctx, UserDraftsInput{
+		humans, _, err = dao.UserDrafts(ctx, UserDraftsInput{
 			UserID: "fake-user",
 			Limit:  10,
-			Offset: 0,
 		})
 		assert.NoError(t, err)
 		assert.Empty(t, humans)
@@ -180,9 +179,8 @@ func TestDAO_List(t *testing.T) {
 		assert.NoError(t, err)
 		n++
 
-		humans, err := dao.ListHumans(ctx, ListHumansInput{
-			Limit:  n,
-			Offset: 0,
+		humans, _, err := dao.ListHumans(ctx, ListHumansInput{
+			Limit: n,
 		})
 		assert.NoError(t, err)
 		assert.Len(t, humans, n-1)
@@ -205,10 +203,11 @@ func TestDAO_List_Paginate(t *testing.T) {
 			ids = append(ids, human.ID)
 		}
 
+		var pageToken string
 		for i := 0; i < n; i += 10 {
-			humans, err := dao.ListHumans(ctx, ListHumansInput{
-				Limit:  n / 10,
-				Offset: i,
+			humans, next, err := dao.ListHumans(ctx, ListHumansInput{
+				Limit:     n / 10,
+				PageToken: pageToken,
 			})
 
 			assert.NoError(t, err)
@@ -218,7 +217,17 @@ func TestDAO_List_Paginate(t *testing.T) {
 				reverseIdx := n - i - idx - 1
 				assert.Equal(t, ids[reverseIdx], human.ID)
 			}
+			// the last page doesn't point at an empty one
+			assert.Equal(t, i+10 == n, next == "")
+			pageToken = next
 		}
+
+		humans, err := IterateHumans(ctx, dao, ListHumansInput{Limit: 7}).GetAll()
+		assert.NoError(t, err)
+		assert.Len(t, humans, n)
+
+		_, _, err = dao.ListHumans(ctx, ListHumansInput{PageToken: "nonsense"})
+		assert.True(t, errors.Is(err, ErrInvalidPageToken))
 	})
 }
 
@@ -290,18 +299,20 @@ func TestDAO_MostViewed(t *testing.T) {
 		for i := 0; i < n; i++ {
 			human, err := dao.AddHuman(ctx, AddHumanInput{Name: fmt.Sprintf("Human %v", i), Gender: GenderFemale})
 			assert.NoError(t, err)
-			human.Views = int64(i)
-			err = dao.UpdateHuman(ctx, human)
-			assert.NoError(t, err)
+			// UpdateHuman keeps the stored views, so they can only be counted up
+			for j := 0; j < i; j++ {
+				err = dao.View(ctx, ViewInput{human.ID})
+				assert.NoError(t, err)
+			}
 		}
 
-		humans, err := dao.ListHumans(ctx, ListHumansInput{
+		humans, _, err := dao.ListHumans(ctx, ListHumansInput{
 			Limit:     10,
-			Offset:    0,
 			OrderBy:   "views",
 			Direction: firestore.Desc,
 		})
 		assert.NoError(t, err)
+		assert.Len(t, humans, 10)
 		for i, human := range humans {
 			assert.EqualValues(t, n-i-1, human.Views)
 		}
@@ -314,10 +325,12 @@ func TestHumanAge(t *testing.T) {
 		ExpectedAge string
 	}{
 		"no-dob":                        {ExpectedAge: ""},
-		"no-dod-partial-dob-year":       {Human: Human{DOB: "2000"}, ExpectedAge: "24 y/o"},
+		"no-dod-partial-dob-year":       {Human: Human{DOB: "2000"}, ExpectedAge: "about 24 y/o"},
 		"no-dod-partial-dob-year-month": {Human: Human{DOB: "1999-12"}, ExpectedAge: "24 y/o"},
 		"no-dod-full-dob":               {Human: Human{DOB: "2000-01-01"}, ExpectedAge: "24 y/o"},
 		"full-dod-full-dob":             {Human: Human{DOB: "1940-11-27", DOD: "1973-07-20"}, ExpectedAge: "died at 32 y/o"},
+		"full-dod-partial-dob-year":     {Human: Human{DOB: "1940", DOD: "1973-07-20"}, ExpectedAge: "died at about 33 y/o"},
+		"partial-dod-full-dob":          {Human: Human{DOB: "1940-11-27", DOD: "1973"}, ExpectedAge: "died at about 33 y/o"},
 	}
 
 	date20240315 := time.Date(2024, 3, 15, 0, 0, 0, 0, time.Local)
@@ -355,3 +368,65 @@ func TestSlug(t *testing.T) {
 		})
 	}
 }
+
+func TestDAO_UpdateHumanConflict(t *testing.T) {
+	WithDAO(t, func(ctx context.Context, dao *DAO) {
+		human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderFemale})
+		assert.NoError(t, err)
+
+		first := human
+		first.Description = "first"
+		_, err = dao.UpdateHuman(ctx, first)
+		assert.NoError(t, err)
+
+		// views don't bump the version, and aren't overwritten by an edit
+		assert.NoError(t, dao.View(ctx, ViewInput{HumanID: human.ID}))
+
+		stale := human
+		stale.Description = "stale"
+		_, err = dao.UpdateHuman(ctx, stale)
+		assert.True(t, errors.Is(err, ErrConflict))
+
+		got, err := dao.Human(ctx, HumanInput{HumanID: human.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, "first", got.Description)
+
+		got.Description = "second"
+		_, err = dao.UpdateHuman(ctx, got)
+		assert.NoError(t, err)
+
+		got, err = dao.Human(ctx, HumanInput{HumanID: human.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, "second", got.Description)
+		assert.Equal(t, int64(2), got.Version)
+		assert.Equal(t, int64(1), got.Views)
+	})
+}
+
+func TestDAO_Rename(t *testing.T) {
+	WithDAO(t, func(ctx context.Context, dao *DAO) {
+		human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Awkwafina", Gender: GenderFemale})
+		assert.NoError(t, err)
+		other, err := dao.AddHuman(ctx, AddHumanInput{Name: "Someone Else", Gender: GenderFemale})
+		assert.NoError(t, err)
+
+		human.Name = "Nora Lum"
+		renamed, err := dao.UpdateHuman(ctx, human)
+		assert.NoError(t, err)
+		assert.Equal(t, "nora-lum", renamed.Path)
+		assert.Equal(t, []string{"awkwafina"}, renamed.PreviousPaths)
+
+		got, err := dao.Human(ctx, HumanInput{Path: "awkwafina"})
+		assert.NoError(t, err)
+		assert.Equal(t, human.ID, got.ID)
+		assert.Equal(t, "nora-lum", got.Path)
+
+		name := "Awkwafina"
+		_, err = dao.PatchHuman(ctx, other.ID, HumanPatch{Name: &name})
+		assert.True(t, errors.Is(err, ErrHumanAlreadyExists))
+
+		other.Name = "Nora Lum"
+		_, err = dao.UpdateHuman(ctx, other)
+		assert.True(t, errors.Is(err, ErrHumanAlreadyExists))
+	})
+}
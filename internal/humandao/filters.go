@@ -4,6 +4,8 @@ import (
 	"slices"
 	"strings"
 	"time"
+
+	"github.com/raymonstah/asianamericanswiki/internal/ethnicity"
 )
 
 type FilterOpt func(f Filterable) Filterable
@@ -29,12 +31,14 @@ func ByGender(gender Gender) FilterOpt {
 	}
 }
 
-func ByEthnicity(ethnicity string) FilterOpt {
+// ByEthnicity keeps the humans of the ethnicity or of one within it, so "southeast asian" keeps
+// Vietnamese and Filipino humans too.
+func ByEthnicity(group string) FilterOpt {
 	return func(f Filterable) Filterable {
 		filtered := make([]Human, 0, len(f))
 		for _, human := range f {
 			if slices.ContainsFunc(human.Ethnicity, func(e string) bool {
-				return strings.EqualFold(e, ethnicity)
+				return ethnicity.Is(e, group)
 			}) {
 				filtered = append(filtered, human)
 			}
@@ -45,6 +49,8 @@ func ByEthnicity(ethnicity string) FilterOpt {
 	}
 }
 
+// ByAgeOlderThan keeps living humans who were born before age. A date of birth that is only known
+// to the year or month counts when all of it is before age.
 func ByAgeOlderThan(age time.Time) FilterOpt {
 	return func(f Filterable) Filterable {
 		filtered := make([]Human, 0, len(f))
@@ -52,11 +58,7 @@ func ByAgeOlderThan(age time.Time) FilterOpt {
 			if human.DOD != "" {
 				continue
 			}
-			dobTime, err := time.Parse("2006-01-02", human.DOB)
-			if err != nil {
-				continue
-			}
-			if dobTime.Before(age) {
+			if human.DOB.Before(age) {
 				filtered = append(filtered, human)
 			}
 		}
@@ -65,6 +67,8 @@ func ByAgeOlderThan(age time.Time) FilterOpt {
 	}
 }
 
+// ByAgeYoungerThan keeps living humans who were born after age. A date of birth that is only known
+// to the year or month counts when all of it is after age.
 func ByAgeYoungerThan(age time.Time) FilterOpt {
 	return func(f Filterable) Filterable {
 		filtered := make([]Human, 0, len(f))
@@ -72,11 +76,7 @@ func ByAgeYoungerThan(age time.Time) FilterOpt {
 			if human.DOD != "" {
 				continue
 			}
-			dobTime, err := time.Parse("2006-01-02", human.DOB)
-			if err != nil {
-				continue
-			}
-			if dobTime.After(age) {
+			if human.DOB.After(age) {
 				filtered = append(filtered, human)
 			}
 		}
@@ -109,6 +109,25 @@ func ByTags(tags ...string) FilterOpt {
 	}
 }
 
+// ByName keeps the humans whose name contains name, ignoring case. With includeAliases, their
+// aliases and the other names they go by count too.
+func ByName(name string, includeAliases bool) FilterOpt {
+	name = strings.ToLower(strings.TrimSpace(name))
+	matches := func(s string) bool { return strings.Contains(strings.ToLower(s), name) }
+	return func(f Filterable) Filterable {
+		filtered := make([]Human, 0, len(f))
+		for _, human := range f {
+			if matches(human.Name) ||
+				includeAliases && (slices.ContainsFunc(human.Aliases, matches) ||
+					slices.ContainsFunc(human.Names, func(n NameVariant) bool { return matches(n.Value) })) {
+				filtered = append(filtered, human)
+			}
+		}
+
+		return filtered
+	}
+}
+
 func ByIDs(ids ...string) FilterOpt {
 	return func(f Filterable) Filterable {
 		idToHuman := make(map[string]Human, len(f))
@@ -41,6 +41,22 @@ func TestFilterable_ByEthnicity(t *testing.T) {
 	require.Equal(t, expected, result)
 }
 
+func TestFilterable_ByEthnicity_Group(t *testing.T) {
+	f := Filterable{
+		{Name: "Vietnamese", Ethnicity: []string{"vietnamese"}},
+		{Name: "Filipino", Ethnicity: []string{"filipino"}},
+		{Name: "Okinawan", Ethnicity: []string{"okinawan"}},
+		{Name: "Hmong", Ethnicity: []string{"hmong"}},
+		{Name: "Indian", Ethnicity: []string{"indian", "white"}},
+	}
+
+	require.Equal(t, Filterable{f[0], f[1], f[3]}, ByEthnicity("southeast asian")(f))
+	require.Equal(t, Filterable{f[2]}, ByEthnicity("japanese")(f))
+	require.Equal(t, Filterable{f[2]}, ByEthnicity("east-asian")(f))
+	require.Equal(t, Filterable{f[4]}, ByEthnicity("white")(f))
+	require.Empty(t, ByEthnicity("okinawan")(Filterable{{Ethnicity: []string{"japanese"}}}))
+}
+
 func TestFilterable_ByAgeGreaterThan(t *testing.T) {
 	f := Filterable{
 		{DOB: "1994-01-01"},
@@ -73,6 +89,22 @@ func TestFilterable_ByAgeLessThan(t *testing.T) {
 	require.Equal(t, expected, result)
 }
 
+func TestFilterable_ByAgePartialDOB(t *testing.T) {
+	f := Filterable{
+		{DOB: "1994"},
+		{DOB: "1995-06"},
+		{DOB: "1996-01-01"},
+		{DOB: "unknown"},
+	}
+
+	age := time.Date(1995, time.June, 15, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, Filterable{{DOB: "1994"}}, ByAgeOlderThan(age)(f))
+	require.Equal(t, Filterable{{DOB: "1996-01-01"}}, ByAgeYoungerThan(age)(f))
+
+	age = time.Date(1995, time.July, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, Filterable{{DOB: "1994"}, {DOB: "1995-06"}}, ByAgeOlderThan(age)(f))
+}
+
 func TestFilterable_ByTags(t *testing.T) {
 	f := Filterable{
 		{Tags: []string{"tag1", "tag2"}},
@@ -90,6 +122,19 @@ func TestFilterable_ByTags(t *testing.T) {
 	require.Equal(t, expected, result)
 }
 
+func TestFilterable_ByName(t *testing.T) {
+	f := Filterable{
+		{Name: "Bruce Lee", Aliases: []string{"Little Dragon"}},
+		{Name: "Jackie Chan", Names: []NameVariant{{Value: "成龍", Lang: "zh-Hant"}}},
+		{Name: "Ali Wong"},
+	}
+
+	require.Equal(t, Filterable{f[0]}, ByName("bruce", false)(f))
+	require.Equal(t, Filterable{}, ByName("dragon", false)(f))
+	require.Equal(t, Filterable{f[0]}, ByName("Dragon", true)(f))
+	require.Equal(t, Filterable{f[1]}, ByName("成龍", true)(f))
+}
+
 func TestFilterable_Chained(t *testing.T) {
 	f := Filterable{
 		{DOB: "1994-01-01", Tags: []string{"tag1", "tag2"}, Ethnicity: []string{"Chinese"}},
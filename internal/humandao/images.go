@@ -0,0 +1,225 @@
+package humandao
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidImage  = errors.New("invalid image")
+	ErrImageNotFound = errors.New("image not found")
+)
+
+// ImageLicenses are the licenses images can be used under. An image without one is used with
+// the permission of whoever made it, or was generated.
+var ImageLicenses = []string{
+	"CC0",
+	"CC BY 2.0",
+	"CC BY 3.0",
+	"CC BY 4.0",
+	"CC BY-SA 2.0",
+	"CC BY-SA 3.0",
+	"CC BY-SA 4.0",
+	"Public domain",
+	"Fair use",
+	"Used with permission",
+}
+
+// Image is a picture of a human, uploaded along with a thumbnail of it.
+type Image struct {
+	ID string `firestore:"id"`
+	// Object and ThumbnailObject are where the image and its thumbnail are stored in the images
+	// bucket, and URL and ThumbnailURL where they are served from.
+	Object          string `firestore:"object,omitempty"`
+	ThumbnailObject string `firestore:"thumbnail_object,omitempty"`
+	URL             string `firestore:"url"`
+	ThumbnailURL    string `firestore:"thumbnail_url,omitempty"`
+	Width           int    `firestore:"width,omitempty"`
+	Height          int    `firestore:"height,omitempty"`
+	// SourceURL is where the image was found, or the image it was generated from.
+	SourceURL   string    `firestore:"source_url,omitempty"`
+	Author      string    `firestore:"author,omitempty"`
+	License     string    `firestore:"license,omitempty"`
+	AIGenerated bool      `firestore:"ai_generated,omitempty"`
+	Caption     string    `firestore:"caption,omitempty"`
+	UploadedAt  time.Time `firestore:"uploaded_at,omitempty"`
+	UploadedBy  string    `firestore:"uploaded_by,omitempty"`
+}
+
+// String is how an image shows up in the history of a human: where it is served from, with its
+// caption and attribution. Where it is stored and who uploaded it are left out, as they are from
+// the API.
+func (i Image) String() string {
+	var details []string
+	for _, detail := range []string{i.Caption, i.Attribution()} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if len(details) == 0 {
+		return i.URL
+	}
+	return fmt.Sprintf("%v (%v)", i.URL, strings.Join(details, "; "))
+}
+
+// Attribution credits whoever made the image, e.g. "Photo by Jane Doe, CC BY-SA 4.0".
+func (i Image) Attribution() string {
+	var parts []string
+	if i.AIGenerated {
+		parts = append(parts, "AI-generated image")
+	}
+	if i.Author != "" {
+		parts = append(parts, "Photo by "+i.Author)
+	}
+	if i.License != "" {
+		parts = append(parts, i.License)
+	}
+	return strings.Join(parts, ", ")
+}
+
+type Images struct {
+	// Featured and Thumbnail are the URLs of the featured image and its thumbnail, which are
+	// shown wherever the human is.
+	Featured  string `firestore:"featured,omitempty"`
+	Thumbnail string `firestore:"thumbnail,omitempty"`
+	// FeaturedID is the image of Gallery that is featured. Images uploaded before there was a
+	// gallery are only in Featured and Thumbnail.
+	FeaturedID string  `firestore:"featured_id,omitempty"`
+	Gallery    []Image `firestore:"gallery,omitempty"`
+}
+
+// All returns every image of the human, with the one featured before there was a gallery as an
+// image without any details.
+func (i Images) All() []Image {
+	if len(i.Gallery) == 0 && i.Featured != "" {
+		return []Image{{URL: i.Featured, ThumbnailURL: i.Thumbnail}}
+	}
+	return i.Gallery
+}
+
+// FeaturedImage returns the image that is featured, which has no URL when there is none.
+func (i Images) FeaturedImage() Image {
+	if image, ok := i.Get(i.FeaturedID); ok {
+		return image
+	}
+	return Image{URL: i.Featured, ThumbnailURL: i.Thumbnail}
+}
+
+// Others returns the images of the gallery that aren't featured.
+func (i Images) Others() []Image {
+	return slices.DeleteFunc(slices.Clone(i.Gallery), func(image Image) bool { return image.ID == i.FeaturedID })
+}
+
+// Get finds an image of the gallery by its ID.
+func (i Images) Get(id string) (Image, bool) {
+	index := slices.IndexFunc(i.Gallery, func(image Image) bool { return image.ID == id })
+	if index < 0 {
+		return Image{}, false
+	}
+	return i.Gallery[index], true
+}
+
+// WithImage returns the images with image added to the gallery. It is featured if featured is
+// set, or if nothing from the gallery is featured yet.
+func (i Images) WithImage(image Image, featured bool) Images {
+	i.Gallery = append(slices.Clone(i.Gallery), image)
+	if featured || i.FeaturedID == "" {
+		i.FeaturedID = image.ID
+		i.Featured, i.Thumbnail = image.URL, image.ThumbnailURL
+	}
+	return i
+}
+
+// Feature returns the images with the image of the gallery with the ID featured.
+func (i Images) Feature(id string) (Images, error) {
+	image, ok := i.Get(id)
+	if !ok {
+		return Images{}, fmt.Errorf("%w: %v", ErrImageNotFound, id)
+	}
+	i.FeaturedID = image.ID
+	i.Featured, i.Thumbnail = image.URL, image.ThumbnailURL
+	return i, nil
+}
+
+// Without returns the images with the image with the ID removed from the gallery, along with the
+// image. When it was featured, the first image left is featured instead.
+func (i Images) Without(id string) (Images, Image, error) {
+	image, ok := i.Get(id)
+	if !ok {
+		return Images{}, Image{}, fmt.Errorf("%w: %v", ErrImageNotFound, id)
+	}
+	i.Gallery = slices.DeleteFunc(slices.Clone(i.Gallery), func(other Image) bool { return other.ID == id })
+	if i.FeaturedID == id {
+		i.FeaturedID, i.Featured, i.Thumbnail = "", "", ""
+		if len(i.Gallery) > 0 {
+			i.FeaturedID = i.Gallery[0].ID
+			i.Featured, i.Thumbnail = i.Gallery[0].URL, i.Gallery[0].ThumbnailURL
+		}
+	}
+	return i, image, nil
+}
+
+// normalizeImage trims the details of an image and checks them.
+func normalizeImage(image Image) (Image, error) {
+	image.SourceURL = strings.TrimSpace(image.SourceURL)
+	image.Author = strings.TrimSpace(image.Author)
+	image.License = strings.TrimSpace(image.License)
+	image.Caption = strings.TrimSpace(image.Caption)
+	if image.ID == "" || image.URL == "" {
+		return Image{}, fmt.Errorf("%w: id and url must be provided", ErrInvalidImage)
+	}
+	if image.SourceURL != "" {
+		u, err := url.Parse(image.SourceURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return Image{}, fmt.Errorf("%w: %q is not a http(s) url", ErrInvalidImage, image.SourceURL)
+		}
+	}
+	if image.License != "" {
+		i := slices.IndexFunc(ImageLicenses, func(license string) bool { return strings.EqualFold(license, image.License) })
+		if i < 0 {
+			return Image{}, fmt.Errorf("%w: unknown license %q", ErrInvalidImage, image.License)
+		}
+		image.License = ImageLicenses[i]
+	}
+	return image, nil
+}
+
+// normalizeImages checks every image of the gallery, and makes Featured and Thumbnail those of
+// the featured image.
+func normalizeImages(images Images) (Images, error) {
+	gallery := make([]Image, 0, len(images.Gallery))
+	for _, image := range images.Gallery {
+		image, err := normalizeImage(image)
+		if err != nil {
+			return Images{}, err
+		}
+		if slices.ContainsFunc(gallery, func(other Image) bool { return other.ID == image.ID }) {
+			return Images{}, fmt.Errorf("%w: %v is in the gallery twice", ErrInvalidImage, image.ID)
+		}
+		gallery = append(gallery, image)
+	}
+	if len(gallery) == 0 {
+		gallery = nil
+	}
+	images.Gallery = gallery
+	if images.FeaturedID != "" {
+		return images.Feature(images.FeaturedID)
+	}
+	return images, nil
+}
+
+// mergeImages returns the images of both humans, with the one featured in chosen featured.
+func mergeImages(chosen, winner, loser Images) Images {
+	merged := chosen
+	merged.Gallery = slices.Clone(winner.Gallery)
+	for _, image := range loser.Gallery {
+		if _, ok := winner.Get(image.ID); !ok {
+			merged.Gallery = append(merged.Gallery, image)
+		}
+	}
+	return merged
+}
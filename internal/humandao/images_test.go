@@ -0,0 +1,110 @@
+package humandao
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestImages(t *testing.T) {
+	legacy := Images{Featured: "https://example.com/featured.webp", Thumbnail: "https://example.com/thumbnail.webp"}
+	require.Equal(t, []Image{{URL: legacy.Featured, ThumbnailURL: legacy.Thumbnail}}, legacy.All())
+	require.Equal(t, legacy.Featured, legacy.FeaturedImage().URL)
+	require.Equal(t, "", Images{}.FeaturedImage().URL)
+
+	var images Images
+	images = images.WithImage(Image{ID: "a", URL: "https://example.com/a.webp", ThumbnailURL: "https://example.com/a.jpg"}, false)
+	require.Equal(t, "a", images.FeaturedID)
+	require.Equal(t, "https://example.com/a.jpg", images.Thumbnail)
+
+	images = images.WithImage(Image{ID: "b", URL: "https://example.com/b.webp"}, false)
+	require.Equal(t, "a", images.FeaturedID)
+	require.Equal(t, []Image{images.Gallery[1]}, images.Others())
+
+	images, err := images.Feature("b")
+	require.NoError(t, err)
+	require.Equal(t, "https://example.com/b.webp", images.Featured)
+	require.Equal(t, "b", images.FeaturedImage().ID)
+	_, err = images.Feature("c")
+	require.ErrorIs(t, err, ErrImageNotFound)
+
+	images, removed, err := images.Without("b")
+	require.NoError(t, err)
+	require.Equal(t, "b", removed.ID)
+	require.Equal(t, "a", images.FeaturedID)
+	require.Equal(t, "https://example.com/a.webp", images.Featured)
+
+	images, _, err = images.Without("a")
+	require.NoError(t, err)
+	require.Equal(t, Images{Gallery: []Image{}}, images)
+	_, _, err = images.Without("a")
+	require.ErrorIs(t, err, ErrImageNotFound)
+}
+
+func TestImage_Attribution(t *testing.T) {
+	require.Equal(t, "", Image{}.Attribution())
+	require.Equal(t, "Photo by Jane Doe, CC BY-SA 4.0", Image{Author: "Jane Doe", License: "CC BY-SA 4.0"}.Attribution())
+	require.Equal(t, "AI-generated image", Image{AIGenerated: true}.Attribution())
+}
+
+func TestImage_String(t *testing.T) {
+	image := Image{
+		ID: "a", Object: "h/a/original.webp", URL: "https://example.com/a.webp", Caption: "In 1971", Author: "Jane Doe",
+		License: "CC BY 4.0", UploadedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), UploadedBy: "uid",
+	}
+	require.Equal(t, "https://example.com/a.webp", Image{URL: image.URL}.String())
+
+	before := Human{ID: "h", Name: "Foo"}
+	after := before
+	after.Images = Images{}.WithImage(image, true)
+	require.Contains(t, DiffHumans(before, after), FieldChange{Field: "images.gallery", After: "https://example.com/a.webp (In 1971; Photo by Jane Doe, CC BY 4.0)"})
+}
+
+func TestNormalizeImages(t *testing.T) {
+	images, err := normalizeImages(Images{
+		FeaturedID: "a",
+		Gallery:    []Image{{ID: "a", URL: "https://example.com/a.webp", Author: " Jane Doe ", License: "cc by 4.0", SourceURL: " https://commons.wikimedia.org/wiki/File:A.jpg "}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Jane Doe", images.Gallery[0].Author)
+	require.Equal(t, "CC BY 4.0", images.Gallery[0].License)
+	require.Equal(t, "https://commons.wikimedia.org/wiki/File:A.jpg", images.Gallery[0].SourceURL)
+	require.Equal(t, "https://example.com/a.webp", images.Featured)
+
+	for name, images := range map[string]Images{
+		"no-url":       {Gallery: []Image{{ID: "a"}}},
+		"bad-source":   {Gallery: []Image{{ID: "a", URL: "https://example.com/a.webp", SourceURL: "javascript:alert(1)"}}},
+		"bad-license":  {Gallery: []Image{{ID: "a", URL: "https://example.com/a.webp", License: "All rights reserved"}}},
+		"duplicate-id": {Gallery: []Image{{ID: "a", URL: "https://example.com/a.webp"}, {ID: "a", URL: "https://example.com/b.webp"}}},
+	} {
+		_, err := normalizeImages(images)
+		require.ErrorIs(t, err, ErrInvalidImage, name)
+	}
+	_, err = normalizeImages(Images{FeaturedID: "b", Gallery: []Image{{ID: "a", URL: "https://example.com/a.webp"}}})
+	require.ErrorIs(t, err, ErrImageNotFound)
+}
+
+func TestMemoryDAO_Images(t *testing.T) {
+	ctx := WithAuthor(context.Background(), "admin")
+	dao := NewMemoryDAO(
+		Human{ID: "winner", Name: "Winner", Gender: GenderFemale, Images: Images{}.WithImage(Image{ID: "a", URL: "https://example.com/a.webp"}, true)},
+		Human{ID: "loser", Name: "Loser", Gender: GenderFemale, Images: Images{}.WithImage(Image{ID: "b", URL: "https://example.com/b.webp"}, true)},
+	)
+
+	images := Images{}.WithImage(Image{ID: "a", URL: "https://example.com/a.webp", License: "Fair Use"}, true)
+	human, err := dao.PatchHuman(ctx, "winner", HumanPatch{Images: &images})
+	require.NoError(t, err)
+	require.Equal(t, "Fair use", human.Images.Gallery[0].License)
+
+	images.Gallery[0].License = "Mine"
+	_, err = dao.PatchHuman(ctx, "winner", HumanPatch{Images: &images})
+	require.ErrorIs(t, err, ErrInvalidImage)
+
+	// the images of both humans are kept, with the one of the winner featured
+	merged, err := dao.Merge(ctx, MergeInput{WinnerID: "winner", LoserID: "loser"})
+	require.NoError(t, err)
+	require.Equal(t, "a", merged.Images.FeaturedID)
+	require.Equal(t, []string{"a", "b"}, []string{merged.Images.Gallery[0].ID, merged.Images.Gallery[1].ID})
+}
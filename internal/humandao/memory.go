@@ -0,0 +1,815 @@
+package humandao
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/api/iterator"
+)
+
+// MemoryDAO is an in-memory HumanStore. It follows the same rules as the Firestore DAO,
+// and is meant for running the site locally and for tests that don't need the emulators.
+type MemoryDAO struct {
+	lock          sync.RWMutex
+	humans        map[string]Human
+	revisions     map[string][]Revision
+	relationships map[string]Relationship
+	tags          Taxonomy
+	subscribers   map[*memorySnapshotIterator]struct{}
+}
+
+func NewMemoryDAO(humans ...Human) *MemoryDAO {
+	dao := &MemoryDAO{
+		humans:        make(map[string]Human, len(humans)),
+		revisions:     make(map[string][]Revision),
+		relationships: make(map[string]Relationship),
+		tags:          slices.Clone(DefaultTags),
+		subscribers:   make(map[*memorySnapshotIterator]struct{}),
+	}
+
+	for _, human := range humans {
+		if human.ID == "" {
+			human.ID = ksuid.New().String()
+		}
+		if human.Path == "" {
+			human.Path = Slug(human.Name)
+		}
+		dao.humans[human.ID] = cloneHuman(human)
+	}
+
+	return dao
+}
+
+func (m *MemoryDAO) Human(ctx context.Context, input HumanInput) (Human, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	visible := func(human Human) bool {
+		return input.IncludeDeleted || !human.Deleted()
+	}
+
+	if input.HumanID != "" {
+		human, ok := m.humans[input.HumanID]
+		if !ok || !visible(human) {
+			return Human{}, fmt.Errorf("%w: %v", ErrHumanNotFound, input.HumanID)
+		}
+		return cloneHuman(human), nil
+	}
+
+	for _, human := range m.humans {
+		if input.Path != "" && human.Path == input.Path && visible(human) {
+			return cloneHuman(human), nil
+		}
+	}
+	for _, human := range m.humans {
+		if input.Path != "" && slices.Contains(human.PreviousPaths, input.Path) && visible(human) {
+			return cloneHuman(human), nil
+		}
+	}
+
+	return Human{}, fmt.Errorf("%w: %v", ErrHumanNotFound, input.Path)
+}
+
+func (m *MemoryDAO) HumansByID(ctx context.Context, input HumansByIDInput) ([]Human, error) {
+	humans := make([]Human, 0, len(input.HumanIDs))
+	for _, id := range input.HumanIDs {
+		human, err := m.Human(ctx, HumanInput{HumanID: id})
+		if err != nil {
+			return nil, err
+		}
+		humans = append(humans, human)
+	}
+
+	return humans, nil
+}
+
+func (m *MemoryDAO) UpdateHuman(ctx context.Context, human Human) (Human, error) {
+	human, err := prepareUpdate(human)
+	if err != nil {
+		return Human{}, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	kind := ChangeModified
+	previous, ok := m.humans[human.ID]
+	if ok {
+		checked, err := checkVersion(previous, human)
+		if err != nil {
+			return Human{}, fmt.Errorf("unable to update human: %v (%v): %w", human.Name, human.ID, err)
+		}
+		human = checked
+		human.PreviousPaths = renamedPaths(previous, human.Path)
+	} else {
+		kind = ChangeAdded
+	}
+	if human.Tags, err = m.tags.normalizeKeeping(human.Tags, previous.Tags); err != nil {
+		return Human{}, err
+	}
+	if human.Path != previous.Path && m.pathTaken(human.ID, human.Path) {
+		return Human{}, fmt.Errorf("unable to update human: %v (%v): %w", human.Name, human.ID, ErrHumanAlreadyExists)
+	}
+	human.Version++
+	m.humans[human.ID] = cloneHuman(human)
+	m.addRevision(newRevision(ctx, RevisionActionUpdate, "", previous, human))
+	m.notify(Change{Kind: kind, Human: cloneHuman(human)})
+
+	return human, nil
+}
+
+func (m *MemoryDAO) PatchHuman(ctx context.Context, id string, patch HumanPatch) (Human, error) {
+	patch, err := patch.validate()
+	if err != nil {
+		return Human{}, err
+	}
+	return m.patchHuman(ctx, id, staticPatch(patch), false)
+}
+
+func (m *MemoryDAO) PatchHumanFunc(ctx context.Context, id string, build func(current Human) (HumanPatch, error)) (Human, error) {
+	return m.patchHuman(ctx, id, build, false)
+}
+
+func (m *MemoryDAO) PatchDeletedHuman(ctx context.Context, id string, patch HumanPatch) (Human, error) {
+	patch, err := patch.validate()
+	if err != nil {
+		return Human{}, err
+	}
+	return m.patchHuman(ctx, id, staticPatch(patch), true)
+}
+
+func (m *MemoryDAO) patchHuman(ctx context.Context, id string, build func(current Human) (HumanPatch, error), includeDeleted bool) (Human, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	previous, ok := m.humans[id]
+	if !ok || previous.Deleted() && !includeDeleted {
+		return Human{}, fmt.Errorf("%w: %v", ErrHumanNotFound, id)
+	}
+	patch, err := build(cloneHuman(previous))
+	if err != nil {
+		return Human{}, fmt.Errorf("unable to patch human: %v: %w", id, err)
+	}
+	if patch, err = patch.validate(); err != nil {
+		return Human{}, err
+	}
+	if patch.Tags != nil {
+		tags, err := m.tags.normalizeKeeping(*patch.Tags, previous.Tags)
+		if err != nil {
+			return Human{}, err
+		}
+		patch.Tags = &tags
+	}
+
+	patched, _ := patch.apply(previous, time.Now())
+	if err := patch.validateApplied(patched); err != nil {
+		return Human{}, err
+	}
+	if patched.Path != previous.Path && m.pathTaken(id, patched.Path) {
+		return Human{}, fmt.Errorf("unable to patch human: %v: %w", id, ErrHumanAlreadyExists)
+	}
+	m.humans[id] = cloneHuman(patched)
+	m.addRevision(newRevision(ctx, RevisionActionUpdate, "", previous, patched))
+	m.notify(Change{Kind: ChangeModified, Human: cloneHuman(patched)})
+
+	return patched, nil
+}
+
+func (m *MemoryDAO) AddHuman(ctx context.Context, input AddHumanInput) (Human, error) {
+	path := Slug(input.Name)
+	if input.Name == "" {
+		return Human{}, fmt.Errorf("%w: name must be provided", ErrInvalidName)
+	}
+
+	human, err := newHuman(input)
+	if err != nil {
+		return Human{}, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if human.Tags, err = m.tags.Normalize(human.Tags); err != nil {
+		return Human{}, err
+	}
+	if m.pathTaken("", path) {
+		return Human{}, ErrHumanAlreadyExists
+	}
+
+	if input.HumanID == "" {
+		input.HumanID = ksuid.New().String()
+	}
+	if _, ok := m.humans[input.HumanID]; ok {
+		return Human{}, fmt.Errorf("unable to create human: %w", ErrHumanAlreadyExists)
+	}
+
+	human.ID = input.HumanID
+	m.humans[human.ID] = cloneHuman(human)
+	m.addRevision(newRevision(ctx, RevisionActionCreate, input.CreatedBy, Human{}, human))
+	m.notify(Change{Kind: ChangeAdded, Human: cloneHuman(human)})
+
+	return human, nil
+}
+
+// pathTaken reports whether a human other than humanID uses path, currently or as a previous path.
+// The caller must hold m.lock.
+func (m *MemoryDAO) pathTaken(humanID, path string) bool {
+	for _, h := range m.humans {
+		if h.ID != humanID && (h.Path == path || slices.Contains(h.PreviousPaths, path)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemoryDAO) ListHumans(ctx context.Context, input ListHumansInput) ([]Human, string, error) {
+	orderBy := input.OrderBy
+	direction := input.Direction
+	if orderBy == "" {
+		orderBy = OrderByCreatedAt
+		direction = firestore.Desc
+	}
+	if orderBy != OrderByCreatedAt && orderBy != OrderByViews {
+		return nil, "", ErrInvalidOrderBy
+	}
+
+	return m.query(func(h Human) bool {
+		return input.IncludeDrafts || !h.Draft
+	}, orderBy, direction, input.PageToken, input.Limit)
+}
+
+func (m *MemoryDAO) CreatedBy(ctx context.Context, input CreatedByInput) ([]Human, string, error) {
+	return m.query(func(h Human) bool {
+		return h.CreatedBy == input.CreatedBy
+	}, OrderByCreatedAt, firestore.Desc, input.PageToken, input.Limit)
+}
+
+func (m *MemoryDAO) UserDrafts(ctx context.Context, input UserDraftsInput) ([]Human, string, error) {
+	return m.query(func(h Human) bool {
+		return h.Draft && h.CreatedBy == input.UserID
+	}, OrderByCreatedAt, firestore.Desc, input.PageToken, input.Limit)
+}
+
+func (m *MemoryDAO) Drafts(ctx context.Context, input DraftsInput) ([]Human, string, error) {
+	return m.query(func(h Human) bool {
+		return h.Draft
+	}, OrderByCreatedAt, firestore.Asc, input.PageToken, input.Limit)
+}
+
+// query returns a page of the humans matching keep that aren't in the trash, sorted the same way
+// Firestore would sort them: by the order field first, then by document ID in the same direction.
+// Only the trash, which is ordered by deleted_at, lists the humans in it. Like Firestore, which
+// leaves out documents without the field a query is ordered by, humans that were never viewed are
+// left out when ordering by views, since views are only written once there are some.
+func (m *MemoryDAO) query(keep func(Human) bool, orderBy OrderBy, direction firestore.Direction, pageToken string, limit int) ([]Human, string, error) {
+	m.lock.RLock()
+	humans := make([]Human, 0, len(m.humans))
+	for _, human := range m.humans {
+		if orderBy == OrderByViews && human.Views == 0 {
+			continue
+		}
+		if (!human.Deleted() || orderBy == orderByDeletedAt) && keep(human) {
+			humans = append(humans, cloneHuman(human))
+		}
+	}
+	m.lock.RUnlock()
+
+	less := func(a, b Human) bool {
+		if direction == firestore.Desc {
+			a, b = b, a
+		}
+		switch orderBy {
+		case OrderByViews:
+			if a.Views != b.Views {
+				return a.Views < b.Views
+			}
+		case orderByDeletedAt:
+			if !a.DeletedAt.Equal(b.DeletedAt) {
+				return a.DeletedAt.Before(b.DeletedAt)
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		}
+		return a.ID < b.ID
+	}
+	sort.Slice(humans, func(i, j int) bool {
+		return less(humans[i], humans[j])
+	})
+
+	if pageToken != "" {
+		cursor, err := parsePageToken(pageToken, orderBy)
+		if err != nil {
+			return nil, "", err
+		}
+		start := sort.Search(len(humans), func(i int) bool {
+			return less(cursor.human(), humans[i])
+		})
+		humans = humans[start:]
+	}
+	if limit <= 0 || len(humans) <= limit {
+		return humans, "", nil
+	}
+
+	humans = humans[:limit]
+	return humans, newPageToken(orderBy, humans[limit-1]), nil
+}
+
+func (m *MemoryDAO) Publish(ctx context.Context, input PublishInput) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	human, ok := m.humans[input.HumanID]
+	if !ok || human.Deleted() {
+		return ErrHumanNotFound
+	}
+
+	previous := cloneHuman(human)
+	human.Draft = false
+	human.PublishedBy = input.UserID
+	human.PublishedAt = time.Now()
+	human.Version++
+	m.humans[human.ID] = human
+	m.addRevision(newRevision(ctx, RevisionActionPublish, input.UserID, previous, human))
+	m.notify(Change{Kind: ChangeModified, Human: cloneHuman(human)})
+
+	return nil
+}
+
+func (m *MemoryDAO) Delete(ctx context.Context, input DeleteInput) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	human, ok := m.humans[input.HumanID]
+	if !ok || human.Deleted() {
+		return nil
+	}
+
+	previous := cloneHuman(human)
+	human.DeletedAt = time.Now()
+	human.DeletedBy = input.UserID
+	human.Version++
+	m.humans[human.ID] = human
+	m.addRevision(newRevision(ctx, RevisionActionDelete, input.UserID, previous, human))
+	m.notify(Change{Kind: ChangeModified, Human: cloneHuman(human)})
+
+	return nil
+}
+
+func (m *MemoryDAO) Trash(ctx context.Context, input TrashInput) ([]Human, string, error) {
+	return m.query(func(h Human) bool {
+		return h.Deleted()
+	}, orderByDeletedAt, firestore.Desc, input.PageToken, input.Limit)
+}
+
+func (m *MemoryDAO) Undelete(ctx context.Context, input UndeleteInput) (Human, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	human, ok := m.humans[input.HumanID]
+	if !ok {
+		return Human{}, fmt.Errorf("%w: %v", ErrHumanNotFound, input.HumanID)
+	}
+	if !human.Deleted() {
+		return cloneHuman(human), nil
+	}
+	if human.MergedInto != "" {
+		return Human{}, fmt.Errorf("unable to undelete human: %v: %w: %v", human.ID, ErrHumanMerged, human.MergedInto)
+	}
+	if m.pathTaken(human.ID, human.Path) {
+		return Human{}, fmt.Errorf("unable to undelete human: %v: %w", human.ID, ErrHumanAlreadyExists)
+	}
+
+	previous := cloneHuman(human)
+	human.DeletedAt = time.Time{}
+	human.DeletedBy = ""
+	human.Version++
+	m.humans[human.ID] = human
+	m.addRevision(newRevision(ctx, RevisionActionUndelete, input.UserID, previous, human))
+	m.notify(Change{Kind: ChangeModified, Human: cloneHuman(human)})
+
+	return cloneHuman(human), nil
+}
+
+func (m *MemoryDAO) Purge(ctx context.Context, input PurgeInput) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	human, ok := m.humans[input.HumanID]
+	if !ok {
+		return fmt.Errorf("%w: %v", ErrHumanNotFound, input.HumanID)
+	}
+	if !human.Deleted() {
+		return fmt.Errorf("unable to purge human: %v: %w", input.HumanID, ErrHumanNotDeleted)
+	}
+
+	delete(m.humans, input.HumanID)
+	delete(m.revisions, input.HumanID)
+	for id, relationship := range m.relationships {
+		if slices.Contains(relationship.Humans, input.HumanID) {
+			delete(m.relationships, id)
+		}
+	}
+	m.notify(Change{Kind: ChangeRemoved, Human: human})
+
+	return nil
+}
+
+func (m *MemoryDAO) Merge(ctx context.Context, input MergeInput) (Human, error) {
+	if err := input.validate(); err != nil {
+		return Human{}, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	winner, ok := m.humans[input.WinnerID]
+	if !ok || winner.Deleted() {
+		return Human{}, fmt.Errorf("unable to merge %v into %v: %w: %v", input.LoserID, input.WinnerID, ErrHumanNotFound, input.WinnerID)
+	}
+	loser, ok := m.humans[input.LoserID]
+	if !ok || loser.Deleted() {
+		return Human{}, fmt.Errorf("unable to merge %v into %v: %w: %v", input.LoserID, input.WinnerID, ErrHumanNotFound, input.LoserID)
+	}
+
+	now := time.Now()
+	merged := MergeHumans(winner, loser, input.FromLoser)
+	merged.UpdatedAt = now
+	merged.Version++
+	m.humans[merged.ID] = cloneHuman(merged)
+	trashed := trashLoser(ctx, loser, winner.ID, now)
+	m.humans[loser.ID] = cloneHuman(trashed)
+	winnerRevision, loserRevision := mergeRevisions(ctx, winner, loser, merged, trashed)
+	m.addRevision(winnerRevision)
+	m.addRevision(loserRevision)
+	m.notify(Change{Kind: ChangeModified, Human: cloneHuman(merged)})
+	m.notify(Change{Kind: ChangeModified, Human: cloneHuman(trashed)})
+
+	for id, relationship := range m.relationships {
+		if !slices.Contains(relationship.Humans, loser.ID) {
+			continue
+		}
+		delete(m.relationships, id)
+		// the winner may already have the same relationship, which is kept as it is
+		if next, ok := moveRelationship(relationship, loser.ID, winner.ID); ok {
+			if _, exists := m.relationships[next.ID]; !exists {
+				m.relationships[next.ID] = next
+			}
+		}
+	}
+
+	for id, human := range m.humans {
+		rewritten, changed := rewriteReferences(human, loser.ID, winner.ID)
+		if id == merged.ID || id == loser.ID || !changed {
+			continue
+		}
+		rewritten.UpdatedAt = now
+		rewritten.Version++
+		m.humans[id] = rewritten
+		revision := newRevision(ctx, RevisionActionMerge, "", human, rewritten)
+		revision.MergedFrom = loser.ID
+		m.addRevision(revision)
+		m.notify(Change{Kind: ChangeModified, Human: cloneHuman(rewritten)})
+	}
+
+	return merged, nil
+}
+
+func (m *MemoryDAO) AddRelationship(ctx context.Context, input AddRelationshipInput) (Relationship, error) {
+	if err := input.validate(); err != nil {
+		return Relationship{}, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, id := range []string{input.From, input.To} {
+		human, ok := m.humans[id]
+		if !ok || human.Deleted() {
+			return Relationship{}, fmt.Errorf("unable to add relationship: %w: %v", ErrHumanNotFound, id)
+		}
+	}
+	relationship := newRelationship(input.Type, input.From, input.To, strings.TrimSpace(input.Note), authorFromContext(ctx, input.CreatedBy), time.Now())
+	if _, ok := m.relationships[relationship.ID]; ok {
+		return Relationship{}, fmt.Errorf("%w: %v", ErrRelationshipExists, relationship.ID)
+	}
+	m.relationships[relationship.ID] = relationship
+
+	return relationship, nil
+}
+
+func (m *MemoryDAO) RemoveRelationship(ctx context.Context, input RemoveRelationshipInput) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if _, ok := m.relationships[input.RelationshipID]; !ok {
+		return fmt.Errorf("%w: %v", ErrRelationshipNotFound, input.RelationshipID)
+	}
+	delete(m.relationships, input.RelationshipID)
+
+	return nil
+}
+
+func (m *MemoryDAO) Relationships(ctx context.Context, input RelationshipsInput) ([]Relationship, error) {
+	m.lock.RLock()
+	var relationships []Relationship
+	for _, relationship := range m.relationships {
+		if slices.Contains(relationship.Humans, input.HumanID) {
+			relationship.Humans = slices.Clone(relationship.Humans)
+			relationships = append(relationships, relationship)
+		}
+	}
+	m.lock.RUnlock()
+
+	sortRelationships(relationships)
+	return relationships, nil
+}
+
+func (m *MemoryDAO) Tags(ctx context.Context) (Taxonomy, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return slices.Clone(m.tags), nil
+}
+
+func (m *MemoryDAO) updateTags(ctx context.Context, change func(Taxonomy) (Taxonomy, Tag, error)) (Tag, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	next, tag, err := changeTags(ctx, m.tags, change)
+	if err != nil {
+		return Tag{}, fmt.Errorf("unable to update tags: %w", err)
+	}
+	m.tags = next
+	return tag, nil
+}
+
+// humansTagged returns every human with the tag, including the ones in the trash.
+func (m *MemoryDAO) humansTagged(tag string) ([]Human, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	var humans []Human
+	for _, human := range m.humans {
+		if slices.Contains(human.Tags, tag) {
+			humans = append(humans, cloneHuman(human))
+		}
+	}
+	sort.Slice(humans, func(i, j int) bool { return humans[i].ID < humans[j].ID })
+	return humans, nil
+}
+
+func (m *MemoryDAO) SaveTag(ctx context.Context, input SaveTagInput) (Tag, error) {
+	saved, err := m.updateTags(ctx, func(t Taxonomy) (Taxonomy, Tag, error) {
+		return t.saveTag(input.Tag)
+	})
+	if err != nil {
+		return Tag{}, err
+	}
+	return saved, retagSynonyms(ctx, m, saved, m.humansTagged)
+}
+
+func (m *MemoryDAO) RenameTag(ctx context.Context, input RenameTagInput) (Tag, error) {
+	renamed, err := m.updateTags(ctx, func(t Taxonomy) (Taxonomy, Tag, error) {
+		return t.renameTag(input.From, input.To)
+	})
+	if err != nil {
+		return Tag{}, err
+	}
+	return renamed, retagSynonyms(ctx, m, renamed, m.humansTagged)
+}
+
+func (m *MemoryDAO) MergeTags(ctx context.Context, input MergeTagsInput) (Tag, error) {
+	merged, err := m.updateTags(ctx, func(t Taxonomy) (Taxonomy, Tag, error) {
+		return t.mergeTag(input.From, input.Into)
+	})
+	if err != nil {
+		return Tag{}, err
+	}
+	return merged, retagSynonyms(ctx, m, merged, m.humansTagged)
+}
+
+func (m *MemoryDAO) View(ctx context.Context, input ViewInput) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	human, ok := m.humans[input.HumanID]
+	if !ok {
+		return fmt.Errorf("unable to view human: %v: %w", input.HumanID, ErrHumanNotFound)
+	}
+
+	human.Views++
+	m.humans[human.ID] = human
+	m.notify(Change{Kind: ChangeModified, Human: cloneHuman(human)})
+
+	return nil
+}
+
+// addRevision records a revision. The caller must hold m.lock.
+func (m *MemoryDAO) addRevision(revision Revision) {
+	m.revisions[revision.HumanID] = append(m.revisions[revision.HumanID], revision)
+}
+
+// Revisions pages through the revisions of a human the way the Firestore DAO does, newest first and
+// then by ID.
+func (m *MemoryDAO) Revisions(ctx context.Context, input RevisionsInput) ([]Revision, string, error) {
+	m.lock.RLock()
+	stored := m.revisions[input.HumanID]
+	revisions := make([]Revision, 0, len(stored))
+	for _, revision := range stored {
+		revision.Snapshot = cloneHuman(revision.Snapshot)
+		revisions = append(revisions, revision)
+	}
+	m.lock.RUnlock()
+
+	newer := func(a, b Revision) bool {
+		if !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.After(b.CreatedAt)
+		}
+		return a.ID > b.ID
+	}
+	sort.SliceStable(revisions, func(i, j int) bool {
+		return newer(revisions[i], revisions[j])
+	})
+
+	if input.PageToken != "" {
+		cursor, err := parsePageToken(input.PageToken, orderByRevisions)
+		if err != nil {
+			return nil, "", err
+		}
+		start := sort.Search(len(revisions), func(i int) bool {
+			return newer(cursor.revision(), revisions[i])
+		})
+		revisions = revisions[start:]
+	}
+	if input.Limit <= 0 || len(revisions) <= input.Limit {
+		return revisions, "", nil
+	}
+
+	revisions = revisions[:input.Limit]
+	return revisions, newRevisionPageToken(revisions[input.Limit-1]), nil
+}
+
+func (m *MemoryDAO) Revision(ctx context.Context, input RevisionInput) (Revision, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, revision := range m.revisions[input.HumanID] {
+		if revision.ID == input.RevisionID {
+			revision.Snapshot = cloneHuman(revision.Snapshot)
+			return revision, nil
+		}
+	}
+
+	return Revision{}, fmt.Errorf("%w: %v", ErrRevisionNotFound, input.RevisionID)
+}
+
+func (m *MemoryDAO) DiffRevisions(ctx context.Context, input DiffRevisionsInput) ([]FieldChange, error) {
+	return diffRevisions(ctx, m, input)
+}
+
+func (m *MemoryDAO) RestoreRevision(ctx context.Context, input RestoreRevisionInput) (Human, error) {
+	revision, err := m.Revision(ctx, RevisionInput{HumanID: input.HumanID, RevisionID: input.RevisionID})
+	if err != nil {
+		return Human{}, err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	kind := ChangeModified
+	current, ok := m.humans[input.HumanID]
+	if !ok {
+		kind = ChangeAdded
+		current = Human{ID: input.HumanID, CreatedAt: revision.Snapshot.CreatedAt, CreatedBy: revision.Snapshot.CreatedBy}
+	}
+
+	restored, err := prepareRestore(current, revision.Snapshot, ok, input.Version, m.tags)
+	if err != nil {
+		return Human{}, fmt.Errorf("unable to restore revision: %v: %w", input.RevisionID, err)
+	}
+	if restored.Path != current.Path && m.pathTaken(input.HumanID, restored.Path) {
+		return Human{}, fmt.Errorf("unable to restore revision: %v: %w", input.RevisionID, ErrHumanAlreadyExists)
+	}
+	m.humans[restored.ID] = cloneHuman(restored)
+
+	rev := newRevision(ctx, RevisionActionRestore, "", current, restored)
+	rev.RestoredFrom = revision.ID
+	m.addRevision(rev)
+	m.notify(Change{Kind: kind, Human: cloneHuman(restored)})
+
+	return restored, nil
+}
+
+func (m *MemoryDAO) Snapshots(ctx context.Context) SnapshotIterator {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	initial := make([]Change, 0, len(m.humans))
+	for _, human := range m.humans {
+		initial = append(initial, Change{Kind: ChangeAdded, Human: cloneHuman(human)})
+	}
+
+	it := &memorySnapshotIterator{
+		ctx:     ctx,
+		dao:     m,
+		pending: initial,
+		ready:   make(chan struct{}, 1),
+		stopped: make(chan struct{}),
+	}
+	it.ready <- struct{}{}
+	m.subscribers[it] = struct{}{}
+
+	return it
+}
+
+// notify fans a change out to every open snapshot iterator. The caller must hold m.lock.
+func (m *MemoryDAO) notify(change Change) {
+	for it := range m.subscribers {
+		it.push(change)
+	}
+}
+
+type memorySnapshotIterator struct {
+	ctx context.Context
+	dao *MemoryDAO
+
+	lock     sync.Mutex
+	pending  []Change
+	ready    chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func (it *memorySnapshotIterator) push(change Change) {
+	it.lock.Lock()
+	it.pending = append(it.pending, change)
+	it.lock.Unlock()
+
+	select {
+	case it.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (it *memorySnapshotIterator) Next() (Snapshot, error) {
+	select {
+	case <-it.stopped:
+		return Snapshot{}, iterator.Done
+	default:
+	}
+
+	select {
+	case <-it.stopped:
+		return Snapshot{}, iterator.Done
+	case <-it.ctx.Done():
+		return Snapshot{}, it.ctx.Err()
+	case <-it.ready:
+	}
+
+	it.lock.Lock()
+	defer it.lock.Unlock()
+	changes := it.pending
+	it.pending = nil
+
+	return Snapshot{Changes: changes}, nil
+}
+
+func (it *memorySnapshotIterator) Stop() {
+	it.stopOnce.Do(func() {
+		it.dao.lock.Lock()
+		delete(it.dao.subscribers, it)
+		it.dao.lock.Unlock()
+		close(it.stopped)
+	})
+}
+
+// cloneHuman copies the slices on a human so callers can't mutate what the store holds.
+func cloneHuman(h Human) Human {
+	h.Aliases = slices.Clone(h.Aliases)
+	h.Names = slices.Clone(h.Names)
+	h.PreviousPaths = slices.Clone(h.PreviousPaths)
+	h.Tags = slices.Clone(h.Tags)
+	h.Ethnicity = slices.Clone(h.Ethnicity)
+	h.Location = slices.Clone(h.Location)
+	h.BasedIn = slices.Clone(h.BasedIn)
+	if h.BirthPlace != nil {
+		birthPlace := *h.BirthPlace
+		h.BirthPlace = &birthPlace
+	}
+	h.InfluencedBy = slices.Clone(h.InfluencedBy)
+	h.Similar = slices.Clone(h.Similar)
+	h.Works = slices.Clone(h.Works)
+	h.Awards = slices.Clone(h.Awards)
+	h.Images.Gallery = slices.Clone(h.Images.Gallery)
+	h.Sources = slices.Clone(h.Sources)
+	for i := range h.Sources {
+		h.Sources[i].Fields = slices.Clone(h.Sources[i].Fields)
+	}
+	return h
+}
@@ -0,0 +1,215 @@
+package humandao
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/iterator"
+)
+
+func TestMemoryDAO_AddHuman(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderFemale, Ethnicity: []string{"Chinese"}})
+	require.NoError(t, err)
+	require.NotEmpty(t, human.ID)
+	require.Equal(t, "foo-bar", human.Path)
+	require.Equal(t, []string{"chinese"}, human.Ethnicity)
+
+	got, err := dao.Human(ctx, HumanInput{Path: "foo-bar"})
+	require.NoError(t, err)
+	require.Equal(t, human.ID, got.ID)
+
+	_, err = dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderFemale})
+	require.ErrorIs(t, err, ErrHumanAlreadyExists)
+
+	_, err = dao.AddHuman(ctx, AddHumanInput{Name: "Baz", Gender: "robot"})
+	require.ErrorIs(t, err, ErrInvalidGender)
+}
+
+func TestMemoryDAO_HumanNotFound(t *testing.T) {
+	dao := NewMemoryDAO()
+	human, err := dao.Human(context.Background(), HumanInput{HumanID: "human123"})
+	require.EqualError(t, err, "human not found: human123")
+	require.Zero(t, human)
+}
+
+func TestMemoryDAO_ListHumans(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	dao := NewMemoryDAO(
+		Human{ID: "a", Name: "A", CreatedAt: now.Add(-3 * time.Hour), Views: 5},
+		Human{ID: "b", Name: "B", CreatedAt: now.Add(-2 * time.Hour), Views: 1},
+		Human{ID: "c", Name: "C", CreatedAt: now.Add(-1 * time.Hour), Views: 9},
+		Human{ID: "d", Name: "D", CreatedAt: now, Draft: true},
+		Human{ID: "e", Name: "E", CreatedAt: now.Add(-4 * time.Hour)},
+	)
+
+	humans, _, err := dao.ListHumans(ctx, ListHumansInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "b", "a", "e"}, ids(humans))
+
+	humans, next, err := dao.ListHumans(ctx, ListHumansInput{IncludeDrafts: true, Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"d", "c"}, ids(humans))
+	humans, next, err = dao.ListHumans(ctx, ListHumansInput{IncludeDrafts: true, Limit: 3, PageToken: next})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a", "e"}, ids(humans))
+	require.Empty(t, next)
+
+	// a token only works for the order it was handed out for
+	_, next, err = dao.ListHumans(ctx, ListHumansInput{Limit: 1})
+	require.NoError(t, err)
+	_, _, err = dao.ListHumans(ctx, ListHumansInput{OrderBy: OrderByViews, PageToken: next})
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+
+	humans, err = IterateHumans(ctx, dao, ListHumansInput{IncludeDrafts: true, Limit: 1}).GetAll()
+	require.NoError(t, err)
+	require.Equal(t, []string{"d", "c", "b", "a", "e"}, ids(humans))
+
+	// like Firestore, ordering by views leaves out the humans without any
+	humans, _, err = dao.ListHumans(ctx, ListHumansInput{OrderBy: OrderByViews, Direction: firestore.Desc})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "a", "b"}, ids(humans))
+
+	_, _, err = dao.ListHumans(ctx, ListHumansInput{OrderBy: "name"})
+	require.ErrorIs(t, err, ErrInvalidOrderBy)
+}
+
+func TestMemoryDAO_PublishAndDelete(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderMale, Draft: true, CreatedBy: "user123"})
+	require.NoError(t, err)
+
+	drafts, _, err := dao.UserDrafts(ctx, UserDraftsInput{UserID: "user123"})
+	require.NoError(t, err)
+	require.Len(t, drafts, 1)
+
+	require.NoError(t, dao.Publish(ctx, PublishInput{HumanID: human.ID, UserID: "admin"}))
+	drafts, _, err = dao.Drafts(ctx, DraftsInput{})
+	require.NoError(t, err)
+	require.Empty(t, drafts)
+
+	require.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: human.ID}))
+	_, err = dao.Human(ctx, HumanInput{HumanID: human.ID})
+	require.EqualError(t, err, fmt.Sprintf("human not found: %v", human.ID))
+}
+
+func TestMemoryDAO_Snapshots(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	dao := NewMemoryDAO(Human{ID: "a", Name: "A"})
+
+	it := dao.Snapshots(ctx)
+	snap, err := it.Next()
+	require.NoError(t, err)
+	require.Equal(t, []Change{{Kind: ChangeAdded, Human: Human{ID: "a", Name: "A", Path: "a"}}}, snap.Changes)
+
+	require.NoError(t, dao.View(ctx, ViewInput{HumanID: "a"}))
+	snap, err = it.Next()
+	require.NoError(t, err)
+	require.Len(t, snap.Changes, 1)
+	require.Equal(t, ChangeModified, snap.Changes[0].Kind)
+	require.Equal(t, int64(1), snap.Changes[0].Human.Views)
+
+	require.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: "a"}))
+	snap, err = it.Next()
+	require.NoError(t, err)
+	require.Equal(t, ChangeModified, snap.Changes[0].Kind)
+	require.True(t, snap.Changes[0].Human.Deleted())
+
+	require.NoError(t, dao.Purge(ctx, PurgeInput{HumanID: "a"}))
+	snap, err = it.Next()
+	require.NoError(t, err)
+	require.Equal(t, ChangeRemoved, snap.Changes[0].Kind)
+
+	it.Stop()
+	_, err = it.Next()
+	require.ErrorIs(t, err, iterator.Done)
+}
+
+func ids(humans []Human) []string {
+	out := make([]string, 0, len(humans))
+	for _, h := range humans {
+		out = append(out, h.ID)
+	}
+	return out
+}
+
+func TestMemoryDAO_UpdateHumanConflict(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderMale})
+	require.NoError(t, err)
+
+	first := human
+	first.Description = "first"
+	_, err = dao.UpdateHuman(ctx, first)
+	require.NoError(t, err)
+
+	stale := human
+	stale.Description = "stale"
+	_, err = dao.UpdateHuman(ctx, stale)
+	require.ErrorIs(t, err, ErrConflict)
+
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	require.Equal(t, "first", conflict.Current.Description)
+	require.Equal(t, int64(1), conflict.Current.Version)
+
+	stale.Version = conflict.Current.Version
+	_, err = dao.UpdateHuman(ctx, stale)
+	require.NoError(t, err)
+
+	got, err := dao.Human(ctx, HumanInput{HumanID: human.ID})
+	require.NoError(t, err)
+	require.Equal(t, "stale", got.Description)
+	require.Equal(t, int64(2), got.Version)
+}
+
+func TestMemoryDAO_Rename(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Awkwafina", Gender: GenderFemale})
+	require.NoError(t, err)
+	other, err := dao.AddHuman(ctx, AddHumanInput{Name: "Someone Else", Gender: GenderFemale})
+	require.NoError(t, err)
+
+	human.Name = "Nora Lum"
+	renamed, err := dao.UpdateHuman(ctx, human)
+	require.NoError(t, err)
+	require.Equal(t, "nora-lum", renamed.Path)
+	require.Equal(t, []string{"awkwafina"}, renamed.PreviousPaths)
+	require.Equal(t, human.Version+1, renamed.Version)
+
+	got, err := dao.Human(ctx, HumanInput{Path: "awkwafina"})
+	require.NoError(t, err)
+	require.Equal(t, "nora-lum", got.Path)
+	require.Equal(t, []string{"awkwafina"}, got.PreviousPaths)
+
+	// the old slug still belongs to nora, so nobody else can take it
+	name := "Awkwafina"
+	_, err = dao.PatchHuman(ctx, other.ID, HumanPatch{Name: &name})
+	require.ErrorIs(t, err, ErrHumanAlreadyExists)
+	_, err = dao.AddHuman(ctx, AddHumanInput{Name: "Awkwafina", Gender: GenderFemale})
+	require.ErrorIs(t, err, ErrHumanAlreadyExists)
+
+	other.Name = "Nora Lum"
+	_, err = dao.UpdateHuman(ctx, other)
+	require.ErrorIs(t, err, ErrHumanAlreadyExists)
+
+	// renaming back drops the old path from the history
+	got, err = dao.PatchHuman(ctx, human.ID, HumanPatch{Name: &name})
+	require.NoError(t, err)
+	require.Equal(t, "awkwafina", got.Path)
+	require.Equal(t, []string{"nora-lum"}, got.PreviousPaths)
+}
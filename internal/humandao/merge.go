@@ -0,0 +1,321 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrInvalidMerge = errors.New("invalid merge")
+
+// MergeFields are the fields an admin can take from the loser of a merge. Everything else
+// is either kept from the winner, or combined from both humans.
+var MergeFields = []string{
+	"name",
+	"dob",
+	"dod",
+	"birth_place",
+	"description",
+	"gender",
+	"socials",
+	"images",
+}
+
+type MergeInput struct {
+	// WinnerID is the human that survives the merge.
+	WinnerID string
+	// LoserID is the duplicate that is folded into the winner and moved to the trash.
+	LoserID string
+	// FromLoser lists the MergeFields whose value is taken from the loser instead of the winner.
+	FromLoser []string
+}
+
+func (input MergeInput) validate() error {
+	if input.WinnerID == "" || input.LoserID == "" {
+		return fmt.Errorf("%w: both humans must be provided", ErrInvalidMerge)
+	}
+	if input.WinnerID == input.LoserID {
+		return fmt.Errorf("%w: a human can't be merged into itself", ErrInvalidMerge)
+	}
+	for _, field := range input.FromLoser {
+		if !slices.Contains(MergeFields, field) {
+			return fmt.Errorf("%w: unknown field %v", ErrInvalidMerge, field)
+		}
+	}
+	return nil
+}
+
+// MergeHumans returns winner with loser folded into it. The fields in fromLoser are taken from
+// the loser, while aliases, name variants, tags, locations, ethnicity, references, works, awards and sources are combined from both.
+// The name that doesn't survive becomes an alias, and the loser's paths redirect to the winner.
+func MergeHumans(winner, loser Human, fromLoser []string) Human {
+	merged := cloneHuman(winner)
+	for _, field := range fromLoser {
+		switch field {
+		case "name":
+			merged.Name = loser.Name
+			merged.Path = loser.Path
+		case "dob":
+			merged.DOB = loser.DOB
+		case "dod":
+			merged.DOD = loser.DOD
+		case "birth_place":
+			merged.BirthPlace = loser.BirthPlace
+			merged.BirthLocation = loser.BirthLocation
+		case "description":
+			merged.Description = loser.Description
+		case "gender":
+			merged.Gender = loser.Gender
+		case "socials":
+			merged.Socials = loser.Socials
+		case "images":
+			merged.Images = loser.Images
+			merged.FeaturedImage = loser.FeaturedImage
+		}
+	}
+
+	merged.Aliases = union(winner.Aliases, loser.Aliases, []string{winner.Name, loser.Name})
+	merged.Aliases = slices.DeleteFunc(merged.Aliases, func(alias string) bool { return alias == merged.Name })
+	merged.Names = mergeNames(winner.Names, loser.Names)
+	merged.Tags = union(winner.Tags, loser.Tags)
+	merged.BasedIn = mergePlaces(winner.BasedIn, loser.BasedIn)
+	merged.Location = union(winner.Location, loser.Location)
+	merged.Ethnicity = union(winner.Ethnicity, loser.Ethnicity)
+	merged.InfluencedBy = replaceReference(union(winner.InfluencedBy, loser.InfluencedBy), loser.ID, winner.ID)
+	merged.Similar = replaceReference(union(winner.Similar, loser.Similar), loser.ID, winner.ID)
+	merged.Works = mergeWorks(winner.Works, loser.Works)
+	merged.Awards = mergeAwards(winner.Awards, loser.Awards)
+	merged.Images = mergeImages(merged.Images, winner.Images, loser.Images)
+	for _, source := range loser.Sources {
+		if !slices.ContainsFunc(merged.Sources, func(s Source) bool { return s.URL == source.URL }) {
+			merged.Sources = append(merged.Sources, source)
+		}
+	}
+
+	merged.PreviousPaths = renamedPaths(winner, merged.Path)
+	merged.PreviousPaths = union(merged.PreviousPaths, loser.PreviousPaths, []string{loser.Path})
+	merged.PreviousPaths = slices.DeleteFunc(merged.PreviousPaths, func(p string) bool { return p == merged.Path || p == "" })
+
+	merged.Views = winner.Views + loser.Views
+	if !loser.CreatedAt.IsZero() && loser.CreatedAt.Before(winner.CreatedAt) {
+		merged.CreatedAt = loser.CreatedAt
+		merged.CreatedBy = loser.CreatedBy
+	}
+
+	return merged
+}
+
+// union returns the distinct, non-empty values of every list, in the order they first appear.
+func union(lists ...[]string) []string {
+	var out []string
+	for _, list := range lists {
+		for _, value := range list {
+			if value != "" && !slices.Contains(out, value) {
+				out = append(out, value)
+			}
+		}
+	}
+	return out
+}
+
+// replaceReference drops from and to out of the references of to, the surviving human of a
+// merge, since a human can't reference itself.
+func replaceReference(ids []string, from, to string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id == from || id == to {
+			continue
+		}
+		out = append(out, id)
+	}
+	return out
+}
+
+// rewriteReferences points h's references to from at to. It reports whether anything changed.
+func rewriteReferences(h Human, from, to string) (Human, bool) {
+	if !slices.Contains(h.Similar, from) && !slices.Contains(h.InfluencedBy, from) {
+		return h, false
+	}
+	rewrite := func(ids []string) []string {
+		out := make([]string, 0, len(ids))
+		for _, id := range ids {
+			if id == from {
+				id = to
+			}
+			if id != h.ID && !slices.Contains(out, id) {
+				out = append(out, id)
+			}
+		}
+		return out
+	}
+
+	h = cloneHuman(h)
+	h.Similar = rewrite(h.Similar)
+	h.InfluencedBy = rewrite(h.InfluencedBy)
+	return h, true
+}
+
+// trashLoser returns the loser of a merge as it's kept in the trash. Its paths now redirect to
+// the winner, so they are taken away from it.
+func trashLoser(ctx context.Context, loser Human, winnerID string, now time.Time) Human {
+	trashed := cloneHuman(loser)
+	trashed.Path = ""
+	trashed.PreviousPaths = nil
+	trashed.DeletedAt = now
+	trashed.DeletedBy = authorFromContext(ctx, "")
+	trashed.MergedInto = winnerID
+	trashed.UpdatedAt = now
+	trashed.Version++
+	return trashed
+}
+
+// mergeRevisions records a merge in the history of both humans.
+func mergeRevisions(ctx context.Context, winner, loser, merged, trashed Human) (Revision, Revision) {
+	winnerRevision := newRevision(ctx, RevisionActionMerge, "", winner, merged)
+	winnerRevision.MergedFrom = loser.ID
+
+	loserRevision := newRevision(ctx, RevisionActionMerge, "", loser, trashed)
+	loserRevision.MergedInto = winner.ID
+
+	return winnerRevision, loserRevision
+}
+
+// Merge folds the loser into the winner, points every human and relationship that referenced the
+// loser at the winner instead, and moves the loser to the trash. The merge is recorded in the history of every human it touched.
+func (d *DAO) Merge(ctx context.Context, input MergeInput) (Human, error) {
+	if err := input.validate(); err != nil {
+		return Human{}, err
+	}
+
+	var merged Human
+	collection := d.client.Collection(d.humanCollection)
+	winnerRef := collection.Doc(input.WinnerID)
+	loserRef := collection.Doc(input.LoserID)
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs, err := tx.GetAll([]*firestore.DocumentRef{winnerRef, loserRef})
+		if err != nil {
+			return err
+		}
+		humans := make([]Human, 0, len(docs))
+		for _, doc := range docs {
+			if !doc.Exists() {
+				return fmt.Errorf("%w: %v", ErrHumanNotFound, doc.Ref.ID)
+			}
+			human, err := convertHumanDoc(doc)
+			if err != nil {
+				return err
+			}
+			if human.Deleted() {
+				return fmt.Errorf("%w: %v", ErrHumanNotFound, human.ID)
+			}
+			humans = append(humans, human)
+		}
+		winner, loser := humans[0], humans[1]
+
+		referencing := make(map[string]Human)
+		for _, field := range []string{"similar", "influenced_by"} {
+			docs, err := tx.Documents(collection.Where(field, "array-contains", loser.ID)).GetAll()
+			if err != nil {
+				return err
+			}
+			for _, doc := range docs {
+				if doc.Ref.ID == winner.ID || doc.Ref.ID == loser.ID {
+					continue
+				}
+				human, err := convertHumanDoc(doc)
+				if err != nil {
+					return err
+				}
+				referencing[human.ID] = human
+			}
+		}
+
+		relationshipDocs, err := tx.Documents(d.relationships().Where("humans", "array-contains", loser.ID)).GetAll()
+		if err != nil {
+			return err
+		}
+		loserRelationships, err := convertRelationshipDocs(relationshipDocs)
+		if err != nil {
+			return err
+		}
+		moved := make(map[string]Relationship)
+		for _, relationship := range loserRelationships {
+			if next, ok := moveRelationship(relationship, loser.ID, winner.ID); ok {
+				moved[next.ID] = next
+			}
+		}
+		// the winner may already have the same relationship, which is kept as it is
+		for id := range moved {
+			doc, err := tx.Get(d.relationships().Doc(id))
+			if err != nil && status.Code(err) != codes.NotFound {
+				return err
+			}
+			if doc != nil && doc.Exists() {
+				delete(moved, id)
+			}
+		}
+
+		// the merged path belongs to either the winner or the loser, so it can't be taken by anyone else
+		next := MergeHumans(winner, loser, input.FromLoser)
+		next.UpdatedAt = time.Now()
+		next.Version++
+		if err := tx.Set(winnerRef, next); err != nil {
+			return err
+		}
+		trashed := trashLoser(ctx, loser, winner.ID, next.UpdatedAt)
+		if err := tx.Set(loserRef, trashed); err != nil {
+			return err
+		}
+		for _, relationship := range loserRelationships {
+			if err := tx.Delete(d.relationships().Doc(relationship.ID)); err != nil {
+				return err
+			}
+		}
+		for id, relationship := range moved {
+			if err := tx.Set(d.relationships().Doc(id), relationship); err != nil {
+				return err
+			}
+		}
+		winnerRevision, loserRevision := mergeRevisions(ctx, winner, loser, next, trashed)
+		if err := tx.Create(d.revisions(winner.ID).Doc(winnerRevision.ID), winnerRevision); err != nil {
+			return err
+		}
+		if err := tx.Create(d.revisions(loser.ID).Doc(loserRevision.ID), loserRevision); err != nil {
+			return err
+		}
+
+		for id, human := range referencing {
+			rewritten, _ := rewriteReferences(human, loser.ID, winner.ID)
+			rewritten.UpdatedAt = next.UpdatedAt
+			rewritten.Version++
+			err := tx.Update(collection.Doc(id), []firestore.Update{
+				{Path: "similar", Value: rewritten.Similar},
+				{Path: "influenced_by", Value: rewritten.InfluencedBy},
+				{Path: "updated_at", Value: rewritten.UpdatedAt},
+				{Path: "version", Value: firestore.Increment(1)},
+			})
+			if err != nil {
+				return err
+			}
+			revision := newRevision(ctx, RevisionActionMerge, "", human, rewritten)
+			revision.MergedFrom = loser.ID
+			if err := tx.Create(d.revisions(id).Doc(revision.ID), revision); err != nil {
+				return err
+			}
+		}
+
+		merged = next
+		return nil
+	})
+	if err != nil {
+		return Human{}, fmt.Errorf("unable to merge %v into %v: %w", input.LoserID, input.WinnerID, err)
+	}
+
+	return merged, nil
+}
@@ -0,0 +1,163 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestMergeHumans(t *testing.T) {
+	now := time.Now()
+	winner := Human{
+		ID:           "sung",
+		Name:         "Sung Kang",
+		Path:         "sung-kang",
+		DOB:          "1972",
+		Tags:         []string{"actor"},
+		Ethnicity:    []string{"korean"},
+		Similar:      []string{"sungho", "john"},
+		InfluencedBy: []string{"bruce"},
+		Views:        10,
+		CreatedAt:    now,
+	}
+	loser := Human{
+		ID:            "sungho",
+		Name:          "Sung-Ho Kang",
+		Path:          "sung-ho-kang",
+		PreviousPaths: []string{"sungho-kang"},
+		DOB:           "1972-04-08",
+		Aliases:       []string{"Sung Ho Kang"},
+		Tags:          []string{"actor", "director"},
+		Location:      []string{"Los Angeles"},
+		Similar:       []string{"sung", "justin"},
+		Views:         3,
+		CreatedAt:     now.Add(-time.Hour),
+		CreatedBy:     "discover",
+	}
+
+	merged := MergeHumans(winner, loser, []string{"dob"})
+	require.Equal(t, "Sung Kang", merged.Name)
+	require.Equal(t, "sung-kang", merged.Path)
+	require.Equal(t, PartialDate("1972-04-08"), merged.DOB)
+	require.Equal(t, []string{"Sung Ho Kang", "Sung-Ho Kang"}, merged.Aliases)
+	require.Equal(t, []string{"actor", "director"}, merged.Tags)
+	require.Equal(t, []string{"Los Angeles"}, merged.Location)
+	require.Equal(t, []string{"korean"}, merged.Ethnicity)
+	require.Equal(t, []string{"john", "justin"}, merged.Similar)
+	require.Equal(t, []string{"bruce"}, merged.InfluencedBy)
+	require.Equal(t, []string{"sungho-kang", "sung-ho-kang"}, merged.PreviousPaths)
+	require.Equal(t, int64(13), merged.Views)
+	require.Equal(t, loser.CreatedAt, merged.CreatedAt)
+	require.Equal(t, "discover", merged.CreatedBy)
+
+	// taking the loser's name moves the winner to the loser's path
+	merged = MergeHumans(winner, loser, []string{"name"})
+	require.Equal(t, "Sung-Ho Kang", merged.Name)
+	require.Equal(t, "sung-ho-kang", merged.Path)
+	require.Equal(t, []string{"Sung Ho Kang", "Sung Kang"}, merged.Aliases)
+	require.Equal(t, []string{"sung-kang", "sungho-kang"}, merged.PreviousPaths)
+}
+
+func TestMemoryDAO_Merge(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO(
+		Human{ID: "sung", Name: "Sung Kang", Tags: []string{"actor"}},
+		Human{ID: "sungho", Name: "Sung-Ho Kang", Tags: []string{"director"}},
+		Human{ID: "justin", Name: "Justin Lin", Similar: []string{"sungho", "sung"}, InfluencedBy: []string{"sungho"}},
+	)
+
+	_, err := dao.Merge(ctx, MergeInput{WinnerID: "sung", LoserID: "sung"})
+	require.ErrorIs(t, err, ErrInvalidMerge)
+	_, err = dao.Merge(ctx, MergeInput{WinnerID: "sung", LoserID: "sungho", FromLoser: []string{"views"}})
+	require.ErrorIs(t, err, ErrInvalidMerge)
+	_, err = dao.Merge(ctx, MergeInput{WinnerID: "sung", LoserID: "nobody"})
+	require.ErrorIs(t, err, ErrHumanNotFound)
+
+	merged, err := dao.Merge(ctx, MergeInput{WinnerID: "sung", LoserID: "sungho"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"actor", "director"}, merged.Tags)
+	require.Equal(t, int64(1), merged.Version)
+
+	_, err = dao.Human(ctx, HumanInput{HumanID: "sungho"})
+	require.ErrorIs(t, err, ErrHumanNotFound)
+	trashed, err := dao.Human(ctx, HumanInput{HumanID: "sungho", IncludeDeleted: true})
+	require.NoError(t, err)
+	require.True(t, trashed.Deleted())
+	require.Equal(t, "sung", trashed.MergedInto)
+	require.Empty(t, trashed.Path)
+	got, err := dao.Human(ctx, HumanInput{Path: "sung-ho-kang"})
+	require.NoError(t, err)
+	require.Equal(t, "sung", got.ID)
+
+	// the loser's paths redirect to the winner now, so it can't come back
+	_, err = dao.Undelete(ctx, UndeleteInput{HumanID: "sungho"})
+	require.ErrorIs(t, err, ErrHumanMerged)
+	trash, _, err := dao.Trash(ctx, TrashInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"sungho"}, ids(trash))
+
+	justin, err := dao.Human(ctx, HumanInput{HumanID: "justin"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"sung"}, justin.Similar)
+	require.Equal(t, []string{"sung"}, justin.InfluencedBy)
+
+	for id, mergedField := range map[string]string{"sung": "sungho", "sungho": "", "justin": "sungho"} {
+		revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: id})
+		require.NoError(t, err)
+		require.Len(t, revisions, 1, id)
+		require.Equal(t, RevisionActionMerge, revisions[0].Action)
+		require.Equal(t, mergedField, revisions[0].MergedFrom, id)
+	}
+	revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: "sungho"})
+	require.NoError(t, err)
+	require.Equal(t, "sung", revisions[0].MergedInto)
+	require.Equal(t, "Sung-Ho Kang", revisions[0].Snapshot.Name)
+
+	require.NoError(t, dao.Purge(ctx, PurgeInput{HumanID: "sungho"}))
+	_, err = dao.Human(ctx, HumanInput{HumanID: "sungho", IncludeDeleted: true})
+	require.ErrorIs(t, err, ErrHumanNotFound)
+}
+
+func TestDAO_Merge(t *testing.T) {
+	WithDAO(t, func(ctx context.Context, dao *DAO) {
+		winner, err := dao.AddHuman(ctx, AddHumanInput{Name: "Sung Kang", Gender: GenderMale, Tags: []string{"actor"}})
+		assert.NoError(t, err)
+		loser, err := dao.AddHuman(ctx, AddHumanInput{Name: "Sung-Ho Kang", Gender: GenderMale, DOB: "1972-04-08"})
+		assert.NoError(t, err)
+		other, err := dao.AddHuman(ctx, AddHumanInput{Name: "Justin Lin", Gender: GenderMale})
+		assert.NoError(t, err)
+		similar := []string{loser.ID}
+		_, err = dao.PatchHuman(ctx, other.ID, HumanPatch{Similar: &similar, InfluencedBy: &similar})
+		assert.NoError(t, err)
+
+		merged, err := dao.Merge(ctx, MergeInput{WinnerID: winner.ID, LoserID: loser.ID, FromLoser: []string{"dob"}})
+		assert.NoError(t, err)
+		assert.Equal(t, PartialDate("1972-04-08"), merged.DOB)
+
+		got, err := dao.Human(ctx, HumanInput{Path: loser.Path})
+		assert.NoError(t, err)
+		assert.Equal(t, winner.ID, got.ID)
+		assert.Equal(t, []string{"Sung-Ho Kang"}, got.Aliases)
+
+		trashed, err := dao.Human(ctx, HumanInput{HumanID: loser.ID, IncludeDeleted: true})
+		assert.NoError(t, err)
+		assert.True(t, trashed.Deleted())
+		assert.Equal(t, winner.ID, trashed.MergedInto)
+		_, err = dao.Undelete(ctx, UndeleteInput{HumanID: loser.ID})
+		assert.True(t, errors.Is(err, ErrHumanMerged))
+
+		got, err = dao.Human(ctx, HumanInput{HumanID: other.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{winner.ID}, got.Similar)
+		assert.Equal(t, []string{winner.ID}, got.InfluencedBy)
+
+		revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: loser.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, RevisionActionMerge, revisions[0].Action)
+		assert.Equal(t, winner.ID, revisions[0].MergedInto)
+	})
+}
@@ -0,0 +1,98 @@
+package humandao
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+var ErrInvalidName = errors.New("invalid name")
+
+type NameType string
+
+const (
+	NameBirth NameType = "birth"
+	NameLegal NameType = "legal"
+	NameStage NameType = "stage"
+	// NameRomanization is a name written in the Latin alphabet, like the Hanyu Pinyin of a Chinese name.
+	NameRomanization NameType = "romanization"
+)
+
+var NameTypes = []NameType{NameBirth, NameLegal, NameStage, NameRomanization}
+
+// NameVariant is another way the name of a human is written, such as in their native script.
+type NameVariant struct {
+	Value string `firestore:"value"`
+	// Lang is the BCP 47 tag of the language and script the name is written in, e.g. "zh-Hant",
+	// "ko" or "ja-Latn". Empty means it isn't known.
+	Lang string   `firestore:"lang,omitempty"`
+	Type NameType `firestore:"type,omitempty"`
+}
+
+func (n NameVariant) String() string {
+	var details []string
+	for _, detail := range []string{n.Lang, string(n.Type)} {
+		if detail != "" {
+			details = append(details, detail)
+		}
+	}
+	if len(details) == 0 {
+		return n.Value
+	}
+	return fmt.Sprintf("%v (%v)", n.Value, strings.Join(details, ", "))
+}
+
+// normalizeNames trims the name variants, puts their language tags in canonical form and drops duplicates.
+func normalizeNames(names []NameVariant) ([]NameVariant, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	normalized := make([]NameVariant, 0, len(names))
+	for _, name := range names {
+		name.Value = strings.TrimSpace(name.Value)
+		name.Lang = strings.TrimSpace(name.Lang)
+		name.Type = NameType(strings.ToLower(strings.TrimSpace(string(name.Type))))
+		if name.Value == "" {
+			return nil, fmt.Errorf("%w: value must be provided", ErrInvalidName)
+		}
+		if name.Type != "" && !slices.Contains(NameTypes, name.Type) {
+			return nil, fmt.Errorf("%w: unknown type %q for %v", ErrInvalidName, name.Type, name.Value)
+		}
+		if name.Lang != "" {
+			tag, err := language.Parse(name.Lang)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q is not a language tag: %v", ErrInvalidName, name.Lang, err)
+			}
+			name.Lang = tag.String()
+		}
+		if slices.ContainsFunc(normalized, func(n NameVariant) bool { return n.Value == name.Value && n.Lang == name.Lang }) {
+			continue
+		}
+		normalized = append(normalized, name)
+	}
+	return normalized, nil
+}
+
+// mergeNames returns the name variants of both humans, without the ones they share.
+func mergeNames(winner, loser []NameVariant) []NameVariant {
+	merged := slices.Clone(winner)
+	for _, name := range loser {
+		if !slices.ContainsFunc(merged, func(n NameVariant) bool { return n.Value == name.Value && n.Lang == name.Lang }) {
+			merged = append(merged, name)
+		}
+	}
+	return merged
+}
+
+// AllNames returns every name the human is known by: their name, aliases and name variants.
+func (h Human) AllNames() []string {
+	names := []string{h.Name}
+	names = append(names, h.Aliases...)
+	for _, name := range h.Names {
+		names = append(names, name.Value)
+	}
+	return union(names)
+}
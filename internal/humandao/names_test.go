@@ -0,0 +1,65 @@
+package humandao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeNames(t *testing.T) {
+	names, err := normalizeNames([]NameVariant{
+		{Value: " 楊紫瓊 ", Lang: "zh-hant", Type: "Birth"},
+		{Value: "Yeoh Choo Kheng", Lang: "ms", Type: NameRomanization},
+		{Value: "楊紫瓊", Lang: "zh-Hant", Type: NameLegal},
+		{Value: "杨紫琼", Lang: "zh-Hans"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []NameVariant{
+		{Value: "楊紫瓊", Lang: "zh-Hant", Type: NameBirth},
+		{Value: "Yeoh Choo Kheng", Lang: "ms", Type: NameRomanization},
+		{Value: "杨紫琼", Lang: "zh-Hans"},
+	}, names)
+
+	for name, variant := range map[string]NameVariant{
+		"no-value": {Lang: "zh-Hant"},
+		"type":     {Value: "楊紫瓊", Type: "nickname"},
+		"lang":     {Value: "楊紫瓊", Lang: "chinese traditional"},
+	} {
+		_, err := normalizeNames([]NameVariant{variant})
+		require.ErrorIs(t, err, ErrInvalidName, name)
+	}
+}
+
+func TestHuman_AllNames(t *testing.T) {
+	human := Human{
+		Name:    "Michelle Yeoh",
+		Aliases: []string{"Yeoh Choo Kheng", "Michelle Khan"},
+		Names:   []NameVariant{{Value: "楊紫瓊", Lang: "zh-Hant"}, {Value: "Yeoh Choo Kheng", Lang: "ms"}},
+	}
+	require.Equal(t, []string{"Michelle Yeoh", "Yeoh Choo Kheng", "Michelle Khan", "楊紫瓊"}, human.AllNames())
+}
+
+func TestMemoryDAO_Names(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	_, err := dao.AddHuman(ctx, AddHumanInput{Name: "Michelle Yeoh", Gender: GenderFemale, Names: []NameVariant{{Value: "楊紫瓊", Lang: "?"}}})
+	require.ErrorIs(t, err, ErrInvalidName)
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Michelle Yeoh", Gender: GenderFemale, Names: []NameVariant{{Value: "楊紫瓊", Lang: "zh-Hant"}}})
+	require.NoError(t, err)
+	require.Equal(t, []NameVariant{{Value: "楊紫瓊", Lang: "zh-Hant"}}, human.Names)
+
+	names := append(human.Names, NameVariant{Value: "杨紫琼", Lang: "zh-Hans"})
+	patched, err := dao.PatchHuman(ctx, human.ID, HumanPatch{Names: &names})
+	require.NoError(t, err)
+	require.Equal(t, names, patched.Names)
+
+	revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{"names"}, revisions[0].ChangedFields)
+
+	merged := MergeHumans(patched, Human{Names: []NameVariant{{Value: "楊紫瓊", Lang: "zh-Hant"}, {Value: "양자경", Lang: "ko"}}}, nil)
+	require.Equal(t, append(names, NameVariant{Value: "양자경", Lang: "ko"}), merged.Names)
+}
@@ -0,0 +1,192 @@
+package humandao
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// DefaultPageSize is how many humans a HumanIterator asks for at a time when the input has no Limit.
+const DefaultPageSize = 300
+
+// orderByDeletedAt orders the trash, and orderByRevisions the revisions of a human by when they
+// were made. Neither can be asked for by ListHumans.
+const (
+	orderByDeletedAt OrderBy = "deleted_at"
+	orderByRevisions OrderBy = "revisions"
+)
+
+// field is the Firestore field a list ordered by o is sorted on.
+func (o OrderBy) field() string {
+	if o == orderByRevisions {
+		return "created_at"
+	}
+	return string(o)
+}
+
+// pageCursor is what a page token points at: the last item of the previous page, by the field the
+// list is ordered by and then by ID, the same way Firestore breaks ties. Time is the time the list
+// is ordered by, when it is ordered by one.
+type pageCursor struct {
+	OrderBy OrderBy   `json:"o"`
+	Time    time.Time `json:"c"`
+	Views   int64     `json:"v,omitempty"`
+	ID      string    `json:"id"`
+}
+
+func newPageToken(orderBy OrderBy, last Human) string {
+	cursor := pageCursor{OrderBy: orderBy, Time: last.CreatedAt, Views: last.Views, ID: last.ID}
+	if orderBy == orderByDeletedAt {
+		cursor.Time = last.DeletedAt
+	}
+	return cursor.token()
+}
+
+func newRevisionPageToken(last Revision) string {
+	return pageCursor{OrderBy: orderByRevisions, Time: last.CreatedAt, ID: last.ID}.token()
+}
+
+func (c pageCursor) token() string {
+	// a cursor is a couple of strings, a time and a number, which always marshal
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// parsePageToken decodes a token handed out by a list ordered by orderBy. Tokens from a list with a
+// different order are rejected, since they would point at the wrong place.
+func parsePageToken(token string, orderBy OrderBy) (pageCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pageCursor{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	var cursor pageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return pageCursor{}, fmt.Errorf("%w: %v", ErrInvalidPageToken, err)
+	}
+	if cursor.ID == "" || cursor.OrderBy != orderBy {
+		return pageCursor{}, fmt.Errorf("%w: not a token for a list ordered by %v", ErrInvalidPageToken, orderBy)
+	}
+	return cursor, nil
+}
+
+// human returns the cursor as a human, so it can be compared with the humans of a list.
+func (c pageCursor) human() Human {
+	human := Human{ID: c.ID, CreatedAt: c.Time, Views: c.Views}
+	if c.OrderBy == orderByDeletedAt {
+		human.CreatedAt, human.DeletedAt = time.Time{}, c.Time
+	}
+	return human
+}
+
+// revision returns the cursor as a revision, so it can be compared with the revisions of a human.
+func (c pageCursor) revision() Revision {
+	return Revision{ID: c.ID, CreatedAt: c.Time}
+}
+
+// paginate orders query by orderBy and then by document ID, and starts it after pageToken.
+func paginate(query firestore.Query, orderBy OrderBy, direction firestore.Direction, pageToken string) (firestore.Query, error) {
+	query = query.OrderBy(orderBy.field(), direction).OrderBy(firestore.DocumentID, direction)
+	if pageToken == "" {
+		return query, nil
+	}
+	cursor, err := parsePageToken(pageToken, orderBy)
+	if err != nil {
+		return firestore.Query{}, err
+	}
+	var value any = cursor.Time
+	if orderBy == OrderByViews {
+		value = cursor.Views
+	}
+	return query.StartAfter(value, cursor.ID), nil
+}
+
+// listPage runs query ordered by orderBy, starting after pageToken, and returns up to limit items
+// read by collect, with the token for the next page. One more item than asked for is read, so the
+// last page doesn't hand out a token to an empty one. A zero limit returns all of them.
+func listPage[T any](ctx context.Context, query firestore.Query, orderBy OrderBy, direction firestore.Direction, pageToken string, limit int, collect func(ctx context.Context, query firestore.Query, limit int) ([]T, error), token func(last T) string) ([]T, string, error) {
+	query, err := paginate(query, orderBy, direction, pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	if limit <= 0 {
+		items, err := collect(ctx, query, 0)
+		return items, "", err
+	}
+	items, err := collect(ctx, query, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(items) <= limit {
+		return items, "", nil
+	}
+
+	items = items[:limit]
+	return items, token(items[limit-1]), nil
+}
+
+// HumanIterator walks every page of a list of humans. Use it instead of a large Limit whenever
+// everything is needed, so nothing is silently left out as the wiki grows.
+type HumanIterator struct {
+	list  func(pageToken string) ([]Human, string, error)
+	page  []Human
+	token string
+	done  bool
+}
+
+// NewHumanIterator returns an iterator that calls list for every page, starting with an empty
+// page token, until list returns no next page token.
+func NewHumanIterator(list func(pageToken string) ([]Human, string, error)) *HumanIterator {
+	return &HumanIterator{list: list}
+}
+
+// IterateHumans walks every human that store.ListHumans returns for input, a page at a time.
+func IterateHumans(ctx context.Context, store HumanStore, input ListHumansInput) *HumanIterator {
+	if input.Limit <= 0 {
+		input.Limit = DefaultPageSize
+	}
+	return NewHumanIterator(func(pageToken string) ([]Human, string, error) {
+		input.PageToken = pageToken
+		return store.ListHumans(ctx, input)
+	})
+}
+
+// Next returns the next human, or iterator.Done once every page has been read.
+func (it *HumanIterator) Next() (Human, error) {
+	for len(it.page) == 0 {
+		if it.done {
+			return Human{}, iterator.Done
+		}
+		page, next, err := it.list(it.token)
+		if err != nil {
+			return Human{}, err
+		}
+		it.page, it.token, it.done = page, next, next == ""
+	}
+
+	human := it.page[0]
+	it.page = it.page[1:]
+	return human, nil
+}
+
+// GetAll returns the rest of the humans.
+func (it *HumanIterator) GetAll() ([]Human, error) {
+	var humans []Human
+	for {
+		human, err := it.Next()
+		if err == iterator.Done {
+			return humans, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		humans = append(humans, human)
+	}
+}
@@ -0,0 +1,219 @@
+package humandao
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDate = errors.New("invalid date")
+
+// DatePrecision is how much of a PartialDate is known.
+type DatePrecision int
+
+const (
+	PrecisionNone DatePrecision = iota
+	PrecisionYear
+	PrecisionMonth
+	PrecisionDay
+)
+
+// PartialDate is a date that may only be known to the year or the month. It is kept in its canonical
+// form, YYYY, YYYY-MM or YYYY-MM-DD, which is also how it's stored in Firestore and encoded as JSON,
+// so dates written before the type existed read back as-is.
+type PartialDate string
+
+// NewPartialDate returns the date for the given parts. A zero month means only the year is known,
+// and a zero day means only the year and month are known.
+func NewPartialDate(year int, month time.Month, day int) PartialDate {
+	switch {
+	case month == 0:
+		return PartialDate(fmt.Sprintf("%04d", year))
+	case day == 0:
+		return PartialDate(fmt.Sprintf("%04d-%02d", year, month))
+	default:
+		return PartialDate(fmt.Sprintf("%04d-%02d-%02d", year, month, day))
+	}
+}
+
+// ParsePartialDate parses YYYY, YYYY-MM or YYYY-MM-DD. An empty string is the zero date.
+func ParsePartialDate(s string) (PartialDate, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", nil
+	}
+
+	parts := strings.Split(s, "-")
+	if len(parts) > 3 || len(parts[0]) != 4 {
+		return "", fmt.Errorf("%w: %q is not YYYY, YYYY-MM or YYYY-MM-DD", ErrInvalidDate, s)
+	}
+	values := make([]int, 3)
+	for i, part := range parts {
+		if i > 0 && len(part) != 2 {
+			return "", fmt.Errorf("%w: %q is not YYYY, YYYY-MM or YYYY-MM-DD", ErrInvalidDate, s)
+		}
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return "", fmt.Errorf("%w: %q is not YYYY, YYYY-MM or YYYY-MM-DD", ErrInvalidDate, s)
+		}
+		values[i] = value
+	}
+
+	year, month, day := values[0], time.Month(values[1]), values[2]
+	if year == 0 {
+		return "", fmt.Errorf("%w: %q has no year", ErrInvalidDate, s)
+	}
+	if len(parts) > 1 && (month < time.January || month > time.December) {
+		return "", fmt.Errorf("%w: %q has no month %d", ErrInvalidDate, s, month)
+	}
+	if len(parts) > 2 && (day < 1 || day > daysIn(year, month)) {
+		return "", fmt.Errorf("%w: %q has no day %d", ErrInvalidDate, s, day)
+	}
+	return NewPartialDate(year, month, day), nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// Validate returns ErrInvalidDate unless the date is empty or in its canonical form.
+func (d PartialDate) Validate() error {
+	parsed, err := ParsePartialDate(string(d))
+	if err != nil {
+		return err
+	}
+	if parsed != d {
+		return fmt.Errorf("%w: %q is not YYYY, YYYY-MM or YYYY-MM-DD", ErrInvalidDate, string(d))
+	}
+	return nil
+}
+
+func (d PartialDate) IsZero() bool {
+	return d == ""
+}
+
+func (d PartialDate) String() string {
+	return string(d)
+}
+
+// Parts returns the year, month and day of the date. Parts that aren't known, or dates that
+// don't parse, are zero.
+func (d PartialDate) Parts() (year int, month time.Month, day int) {
+	parsed, err := ParsePartialDate(string(d))
+	if err != nil || parsed == "" {
+		return 0, 0, 0
+	}
+	parts := strings.Split(string(parsed), "-")
+	values := make([]int, 3)
+	for i, part := range parts {
+		values[i], _ = strconv.Atoi(part)
+	}
+	return values[0], time.Month(values[1]), values[2]
+}
+
+func (d PartialDate) Precision() DatePrecision {
+	year, month, day := d.Parts()
+	switch {
+	case day != 0:
+		return PrecisionDay
+	case month != 0:
+		return PrecisionMonth
+	case year != 0:
+		return PrecisionYear
+	default:
+		return PrecisionNone
+	}
+}
+
+// Earliest returns the first day the date could be, at midnight UTC.
+func (d PartialDate) Earliest() time.Time {
+	year, month, day := d.Parts()
+	switch d.Precision() {
+	case PrecisionNone:
+		return time.Time{}
+	case PrecisionYear:
+		month, day = time.January, 1
+	case PrecisionMonth:
+		day = 1
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Latest returns the last day the date could be, at midnight UTC.
+func (d PartialDate) Latest() time.Time {
+	year, month, day := d.Parts()
+	switch d.Precision() {
+	case PrecisionNone:
+		return time.Time{}
+	case PrecisionYear:
+		month, day = time.December, 31
+	case PrecisionMonth:
+		day = daysIn(year, month)
+	}
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Before reports whether every day the date could be is before t.
+func (d PartialDate) Before(t time.Time) bool {
+	return d.Precision() != PrecisionNone && d.Latest().Before(t)
+}
+
+// After reports whether every day the date could be is after t.
+func (d PartialDate) After(t time.Time) bool {
+	return d.Precision() != PrecisionNone && d.Earliest().After(t)
+}
+
+// Compare orders dates by the first day they could be, with the less precise date first when
+// they start on the same day, so 1990 < 1990-01 < 1990-01-01 < 1990-01-02. Dates that aren't known sort first.
+func (d PartialDate) Compare(other PartialDate) int {
+	if c := d.Earliest().Compare(other.Earliest()); c != 0 {
+		return c
+	}
+	return int(d.Precision()) - int(other.Precision())
+}
+
+func (d PartialDate) MarshalText() ([]byte, error) {
+	return []byte(d), nil
+}
+
+func (d *PartialDate) UnmarshalText(text []byte) error {
+	parsed, err := ParsePartialDate(string(text))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// ageRange returns the youngest and oldest someone born on born could be on end.
+func ageRange(born, end PartialDate) (youngest, oldest int) {
+	return yearsBetween(born.Latest(), end.Earliest()), yearsBetween(born.Earliest(), end.Latest())
+}
+
+// yearsBetween returns the number of full years from a to b, or 0 if b is before a.
+func yearsBetween(a, b time.Time) int {
+	if b.Before(a) {
+		return 0
+	}
+	years := b.Year() - a.Year()
+	if b.Month() < a.Month() || (b.Month() == a.Month() && b.Day() < a.Day()) {
+		years--
+	}
+	return years
+}
+
+// validateDates makes sure the dates of birth and death are valid, and that nobody died before they were born.
+func validateDates(dob, dod PartialDate) error {
+	if err := dob.Validate(); err != nil {
+		return fmt.Errorf("dob: %w", err)
+	}
+	if err := dod.Validate(); err != nil {
+		return fmt.Errorf("dod: %w", err)
+	}
+	if !dob.IsZero() && dod.Before(dob.Earliest()) {
+		return fmt.Errorf("%w: died on %v, before being born on %v", ErrInvalidDate, dod, dob)
+	}
+	return nil
+}
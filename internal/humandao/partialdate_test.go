@@ -0,0 +1,120 @@
+package humandao
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePartialDate(t *testing.T) {
+	tcs := map[string]struct {
+		Input     string
+		Expected  PartialDate
+		Precision DatePrecision
+		Invalid   bool
+	}{
+		"empty":           {Input: "", Expected: "", Precision: PrecisionNone},
+		"year":            {Input: "1990", Expected: "1990", Precision: PrecisionYear},
+		"year-month":      {Input: " 1990-03 ", Expected: "1990-03", Precision: PrecisionMonth},
+		"full":            {Input: "1990-03-04", Expected: "1990-03-04", Precision: PrecisionDay},
+		"leap-day":        {Input: "2000-02-29", Expected: "2000-02-29", Precision: PrecisionDay},
+		"not-a-leap-day":  {Input: "1900-02-29", Invalid: true},
+		"month-13":        {Input: "1990-13", Invalid: true},
+		"day-32":          {Input: "1990-01-32", Invalid: true},
+		"short-year":      {Input: "90", Invalid: true},
+		"year-0":          {Input: "0000", Invalid: true},
+		"short-month":     {Input: "1990-3", Invalid: true},
+		"words":           {Input: "March 1990", Invalid: true},
+		"too-many-parts":  {Input: "1990-03-04-05", Invalid: true},
+		"negative-month":  {Input: "1990--3", Invalid: true},
+		"trailing-dashes": {Input: "1990-", Invalid: true},
+	}
+
+	for name, tc := range tcs {
+		t.Run(name, func(t *testing.T) {
+			date, err := ParsePartialDate(tc.Input)
+			if tc.Invalid {
+				require.True(t, errors.Is(err, ErrInvalidDate), err)
+				require.Error(t, PartialDate(tc.Input).Validate())
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.Expected, date)
+			require.Equal(t, tc.Precision, date.Precision())
+			require.NoError(t, date.Validate())
+		})
+	}
+}
+
+func TestPartialDate_Range(t *testing.T) {
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	}
+
+	require.Equal(t, day(1990, time.January, 1), PartialDate("1990").Earliest())
+	require.Equal(t, day(1990, time.December, 31), PartialDate("1990").Latest())
+	require.Equal(t, day(2000, time.February, 1), PartialDate("2000-02").Earliest())
+	require.Equal(t, day(2000, time.February, 29), PartialDate("2000-02").Latest())
+	require.Equal(t, day(2000, time.February, 3), PartialDate("2000-02-03").Latest())
+
+	require.True(t, PartialDate("1990").Before(day(1991, time.January, 1)))
+	require.False(t, PartialDate("1990").Before(day(1990, time.December, 31)))
+	require.True(t, PartialDate("1990").After(day(1989, time.December, 31)))
+	require.False(t, PartialDate("1990").After(day(1990, time.January, 1)))
+	require.False(t, PartialDate("").Before(day(3000, time.January, 1)))
+	require.False(t, PartialDate("").After(time.Time{}))
+}
+
+func TestPartialDate_Compare(t *testing.T) {
+	dates := []PartialDate{"1990-01-02", "1990-01", "", "1989-12-31", "1990-01-01", "1990"}
+	slices.SortFunc(dates, PartialDate.Compare)
+	require.Equal(t, []PartialDate{"", "1989-12-31", "1990", "1990-01", "1990-01-01", "1990-01-02"}, dates)
+}
+
+func TestPartialDate_JSON(t *testing.T) {
+	type wrapper struct {
+		DOB PartialDate `json:"dob,omitempty"`
+	}
+
+	raw, err := json.Marshal(wrapper{DOB: "1990-03"})
+	require.NoError(t, err)
+	require.JSONEq(t, `{"dob":"1990-03"}`, string(raw))
+
+	var decoded wrapper
+	require.NoError(t, json.Unmarshal([]byte(`{"dob":"1990-03-04"}`), &decoded))
+	require.Equal(t, PartialDate("1990-03-04"), decoded.DOB)
+
+	err = json.Unmarshal([]byte(`{"dob":"04/03/1990"}`), &decoded)
+	require.True(t, errors.Is(err, ErrInvalidDate), err)
+}
+
+func TestMemoryDAO_Dates(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	_, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderFemale, DOB: "1990-13"})
+	require.True(t, errors.Is(err, ErrInvalidDate), err)
+	_, err = dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderFemale, DOB: "1990", DOD: "1989"})
+	require.True(t, errors.Is(err, ErrInvalidDate), err)
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderFemale, DOB: "1990"})
+	require.NoError(t, err)
+
+	dod := PartialDate("1985-05")
+	_, err = dao.PatchHuman(ctx, human.ID, HumanPatch{DOD: &dod})
+	require.True(t, errors.Is(err, ErrInvalidDate), err)
+
+	dod = "2020-05"
+	patched, err := dao.PatchHuman(ctx, human.ID, HumanPatch{DOD: &dod})
+	require.NoError(t, err)
+	require.Equal(t, dod, patched.DOD)
+
+	patched.DOB = "1990-02-30"
+	_, err = dao.UpdateHuman(ctx, patched)
+	require.True(t, errors.Is(err, ErrInvalidDate))
+}
@@ -0,0 +1,346 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/raymonstah/asianamericanswiki/internal/ethnicity"
+	"github.com/raymonstah/asianamericanswiki/internal/place"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// HumanPatch is a set of fields to change on a human. Nil fields are left untouched,
+// so a patch never overwrites data it doesn't know about.
+type HumanPatch struct {
+	Name          *string
+	Aliases       *[]string
+	Names         *[]NameVariant
+	DOB           *PartialDate
+	DOD           *PartialDate
+	Tags          *[]string
+	Ethnicity     *[]string
+	BirthPlace    *place.Place // an empty place clears it
+	BasedIn       *[]place.Place
+	BirthLocation *string
+	Location      *[]string
+	InfluencedBy  *[]string
+	FeaturedImage *string
+	Draft         *bool
+	AIGenerated   *bool
+	Description   *string
+	Socials       *Socials
+	Gender        *Gender
+	Similar       *[]string
+	Images        *Images
+	Sources       *[]Source
+	Works         *[]Work
+	Awards        *[]Award
+}
+
+// validate checks and normalizes only the fields the patch touches.
+func (p HumanPatch) validate() (HumanPatch, error) {
+	if p.Name != nil && strings.TrimSpace(*p.Name) == "" {
+		return HumanPatch{}, fmt.Errorf("%w: name must be provided", ErrInvalidName)
+	}
+	if p.Gender != nil {
+		if _, ok := ValidGenders[*p.Gender]; !ok {
+			return HumanPatch{}, ErrInvalidGender
+		}
+	}
+	if p.Ethnicity != nil {
+		ethnicities, err := ethnicity.Normalize(*p.Ethnicity)
+		if err != nil {
+			return HumanPatch{}, fmt.Errorf("%w: %v", ErrInvalidEthnicity, err)
+		}
+		p.Ethnicity = &ethnicities
+	}
+	if p.DOB != nil {
+		if err := p.DOB.Validate(); err != nil {
+			return HumanPatch{}, fmt.Errorf("dob: %w", err)
+		}
+	}
+	if p.DOD != nil {
+		if err := p.DOD.Validate(); err != nil {
+			return HumanPatch{}, fmt.Errorf("dod: %w", err)
+		}
+	}
+	if p.Sources != nil {
+		sources, err := normalizeSources(*p.Sources)
+		if err != nil {
+			return HumanPatch{}, err
+		}
+		p.Sources = &sources
+	}
+	if p.Names != nil {
+		names, err := normalizeNames(*p.Names)
+		if err != nil {
+			return HumanPatch{}, err
+		}
+		p.Names = &names
+	}
+	if p.BirthPlace != nil {
+		birthPlace, err := normalizePlace(p.BirthPlace)
+		if err != nil {
+			return HumanPatch{}, err
+		}
+		if birthPlace == nil {
+			birthPlace = &place.Place{}
+		}
+		p.BirthPlace = birthPlace
+	}
+	if p.BasedIn != nil {
+		basedIn, err := normalizePlaces(*p.BasedIn)
+		if err != nil {
+			return HumanPatch{}, err
+		}
+		p.BasedIn = &basedIn
+	}
+	if p.Works != nil {
+		works, err := normalizeWorks(*p.Works)
+		if err != nil {
+			return HumanPatch{}, err
+		}
+		p.Works = &works
+	}
+	if p.Awards != nil {
+		awards, err := normalizeAwards(*p.Awards)
+		if err != nil {
+			return HumanPatch{}, err
+		}
+		p.Awards = &awards
+	}
+	if p.Images != nil {
+		images, err := normalizeImages(*p.Images)
+		if err != nil {
+			return HumanPatch{}, err
+		}
+		p.Images = &images
+	}
+	return p, nil
+}
+
+// validateApplied checks the rules that span fields the patch touches and fields it leaves alone.
+func (p HumanPatch) validateApplied(next Human) error {
+	if p.DOB != nil || p.DOD != nil {
+		return validateDates(next.DOB, next.DOD)
+	}
+	return nil
+}
+
+type patchField struct {
+	path  string
+	value any
+	apply func(h *Human)
+}
+
+func (p HumanPatch) fields() []patchField {
+	var fields []patchField
+	add := func(path string, value any, apply func(h *Human)) {
+		fields = append(fields, patchField{path: path, value: value, apply: apply})
+	}
+
+	if p.Name != nil {
+		add("name", *p.Name, func(h *Human) { h.Name = *p.Name })
+		add("urn_path", Slug(*p.Name), func(h *Human) { h.Path = Slug(*p.Name) })
+	}
+	if p.Aliases != nil {
+		add("aliases", *p.Aliases, func(h *Human) { h.Aliases = *p.Aliases })
+	}
+	if p.Names != nil {
+		add("names", *p.Names, func(h *Human) { h.Names = *p.Names })
+	}
+	if p.DOB != nil {
+		add("dob", *p.DOB, func(h *Human) { h.DOB = *p.DOB })
+	}
+	if p.DOD != nil {
+		add("dod", *p.DOD, func(h *Human) { h.DOD = *p.DOD })
+	}
+	if p.Tags != nil {
+		add("tags", *p.Tags, func(h *Human) { h.Tags = *p.Tags })
+	}
+	if p.Ethnicity != nil {
+		add("ethnicity", *p.Ethnicity, func(h *Human) { h.Ethnicity = *p.Ethnicity })
+	}
+	if p.BirthPlace != nil {
+		var birthPlace *place.Place
+		if !p.BirthPlace.IsZero() {
+			birthPlace = p.BirthPlace
+		}
+		add("birth_place", birthPlace, func(h *Human) { h.BirthPlace = birthPlace })
+	}
+	if p.BasedIn != nil {
+		add("based_in", *p.BasedIn, func(h *Human) { h.BasedIn = *p.BasedIn })
+	}
+	if p.BirthLocation != nil {
+		add("birth_location", *p.BirthLocation, func(h *Human) { h.BirthLocation = *p.BirthLocation })
+	}
+	if p.Location != nil {
+		add("location", *p.Location, func(h *Human) { h.Location = *p.Location })
+	}
+	if p.InfluencedBy != nil {
+		add("influenced_by", *p.InfluencedBy, func(h *Human) { h.InfluencedBy = *p.InfluencedBy })
+	}
+	if p.FeaturedImage != nil {
+		add("featured_image", *p.FeaturedImage, func(h *Human) { h.FeaturedImage = *p.FeaturedImage })
+	}
+	if p.Draft != nil {
+		add("draft", *p.Draft, func(h *Human) { h.Draft = *p.Draft })
+	}
+	if p.AIGenerated != nil {
+		add("ai_generated", *p.AIGenerated, func(h *Human) { h.AIGenerated = *p.AIGenerated })
+	}
+	if p.Description != nil {
+		add("description", *p.Description, func(h *Human) { h.Description = *p.Description })
+	}
+	if p.Socials != nil {
+		add("socials", *p.Socials, func(h *Human) { h.Socials = *p.Socials })
+	}
+	if p.Gender != nil {
+		add("gender", *p.Gender, func(h *Human) { h.Gender = *p.Gender })
+	}
+	if p.Similar != nil {
+		add("similar", *p.Similar, func(h *Human) { h.Similar = *p.Similar })
+	}
+	if p.Images != nil {
+		add("images", *p.Images, func(h *Human) { h.Images = *p.Images })
+	}
+	if p.Sources != nil {
+		add("sources", *p.Sources, func(h *Human) { h.Sources = *p.Sources })
+	}
+	if p.Works != nil {
+		add("works", *p.Works, func(h *Human) { h.Works = *p.Works })
+	}
+	if p.Awards != nil {
+		add("awards", *p.Awards, func(h *Human) { h.Awards = *p.Awards })
+	}
+
+	return fields
+}
+
+// apply returns h with the patch applied, along with the firestore updates that do the same.
+func (p HumanPatch) apply(h Human, now time.Time) (Human, []firestore.Update) {
+	previous := h
+	h = cloneHuman(h)
+	fields := p.fields()
+	updates := make([]firestore.Update, 0, len(fields)+3)
+	for _, field := range fields {
+		field.apply(&h)
+		updates = append(updates, firestore.Update{Path: field.path, Value: field.value})
+	}
+	if h.Path != previous.Path {
+		h.PreviousPaths = renamedPaths(previous, h.Path)
+		updates = append(updates, firestore.Update{Path: "previous_paths", Value: h.PreviousPaths})
+	}
+
+	h.UpdatedAt = now
+	h.Version++
+	updates = append(updates,
+		firestore.Update{Path: "updated_at", Value: now},
+		firestore.Update{Path: "version", Value: firestore.Increment(1)},
+	)
+
+	return h, updates
+}
+
+// PatchHuman updates only the fields set on patch, and returns the human as it is after the patch.
+// Unlike UpdateHuman it doesn't check the version, since it can't clobber fields it doesn't set.
+// Humans in the trash can't be patched until they are restored with Undelete.
+func (d *DAO) PatchHuman(ctx context.Context, id string, patch HumanPatch) (Human, error) {
+	patch, err := patch.validate()
+	if err != nil {
+		return Human{}, err
+	}
+	return d.patchHuman(ctx, id, staticPatch(patch), false)
+}
+
+// PatchHumanFunc is PatchHuman with a patch made from the human as it is stored, in the same
+// transaction, so changes to a field that depend on its current value aren't lost to a concurrent
+// change. build may be called more than once.
+func (d *DAO) PatchHumanFunc(ctx context.Context, id string, build func(current Human) (HumanPatch, error)) (Human, error) {
+	return d.patchHuman(ctx, id, build, false)
+}
+
+// PatchDeletedHuman is PatchHuman for maintenance, like the migrations of cmd/normalize, which
+// also patches humans in the trash so they can still be restored once the rules change.
+func (d *DAO) PatchDeletedHuman(ctx context.Context, id string, patch HumanPatch) (Human, error) {
+	patch, err := patch.validate()
+	if err != nil {
+		return Human{}, err
+	}
+	return d.patchHuman(ctx, id, staticPatch(patch), true)
+}
+
+// staticPatch builds the same patch whatever the human is.
+func staticPatch(patch HumanPatch) func(Human) (HumanPatch, error) {
+	return func(Human) (HumanPatch, error) { return patch, nil }
+}
+
+// patchHuman is PatchHumanFunc, and patches humans in the trash too when includeDeleted is set.
+func (d *DAO) patchHuman(ctx context.Context, id string, build func(current Human) (HumanPatch, error), includeDeleted bool) (Human, error) {
+	var patched Human
+	ref := d.client.Collection(d.humanCollection).Doc(id)
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		previous, err := convertHumanDoc(doc)
+		if err != nil {
+			return err
+		}
+		if previous.Deleted() && !includeDeleted {
+			return fmt.Errorf("%w: %v", ErrHumanNotFound, id)
+		}
+		patch, err := build(previous)
+		if err != nil {
+			return err
+		}
+		if patch, err = patch.validate(); err != nil {
+			return err
+		}
+		if patch.Tags != nil {
+			taxonomy, err := d.Tags(ctx)
+			if err != nil {
+				return err
+			}
+			tags, err := taxonomy.normalizeKeeping(*patch.Tags, previous.Tags)
+			if err != nil {
+				return err
+			}
+			patch.Tags = &tags
+		}
+
+		next, updates := patch.apply(previous, time.Now())
+		if err := patch.validateApplied(next); err != nil {
+			return err
+		}
+		if next.Path != previous.Path {
+			if err := d.checkPathAvailable(tx, id, next.Path); err != nil {
+				return err
+			}
+		}
+		if err := tx.Update(ref, updates); err != nil {
+			return err
+		}
+		revision := newRevision(ctx, RevisionActionUpdate, "", previous, next)
+		if err := tx.Create(d.revisions(id).Doc(revision.ID), revision); err != nil {
+			return err
+		}
+
+		patched = next
+		return nil
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound || errors.Is(err, ErrHumanNotFound) {
+			return Human{}, fmt.Errorf("%w: %v", ErrHumanNotFound, id)
+		}
+		return Human{}, fmt.Errorf("unable to patch human: %v: %w", id, err)
+	}
+
+	return patched, nil
+}
@@ -0,0 +1,144 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestMemoryDAO_PatchHuman(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderMale, Description: "keep me"})
+	require.NoError(t, err)
+
+	similar := []string{"a", "b"}
+	patched, err := dao.PatchHuman(ctx, human.ID, HumanPatch{Similar: &similar})
+	require.NoError(t, err)
+	require.Equal(t, similar, patched.Similar)
+	require.Equal(t, "keep me", patched.Description)
+	require.Equal(t, "foo-bar", patched.Path)
+	require.Equal(t, int64(1), patched.Version)
+
+	// the patch bumped the version, so a stale full update conflicts
+	human.Description = "stale"
+	_, err = dao.UpdateHuman(ctx, human)
+	require.ErrorIs(t, err, ErrConflict)
+
+	name := "Baz Qux"
+	ethnicities := []string{"Korean"}
+	patched, err = dao.PatchHuman(ctx, human.ID, HumanPatch{Name: &name, Ethnicity: &ethnicities})
+	require.NoError(t, err)
+	require.Equal(t, "baz-qux", patched.Path)
+	require.Equal(t, []string{"korean"}, patched.Ethnicity)
+
+	revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID, Limit: 1})
+	require.NoError(t, err)
+	require.Equal(t, []string{"name", "urn_path", "previous_paths", "ethnicity"}, revisions[0].ChangedFields)
+}
+
+func TestMemoryDAO_PatchHumanValidation(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderMale})
+	require.NoError(t, err)
+
+	gender := Gender("robot")
+	_, err = dao.PatchHuman(ctx, human.ID, HumanPatch{Gender: &gender})
+	require.ErrorIs(t, err, ErrInvalidGender)
+
+	ethnicities := []string{"martian"}
+	_, err = dao.PatchHuman(ctx, human.ID, HumanPatch{Ethnicity: &ethnicities})
+	require.ErrorIs(t, err, ErrInvalidEthnicity)
+
+	empty := " "
+	_, err = dao.PatchHuman(ctx, human.ID, HumanPatch{Name: &empty})
+	require.ErrorIs(t, err, ErrInvalidName)
+	require.True(t, IsInvalidHuman(err))
+
+	_, err = dao.PatchHuman(ctx, "nope", HumanPatch{})
+	require.ErrorIs(t, err, ErrHumanNotFound)
+	require.False(t, IsInvalidHuman(err))
+}
+
+func TestMemoryDAO_PatchHumanInTrash(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderMale, Description: "trashed"})
+	require.NoError(t, err)
+	require.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: human.ID}))
+
+	description := "back from the trash"
+	_, err = dao.PatchHuman(ctx, human.ID, HumanPatch{Description: &description})
+	require.ErrorIs(t, err, ErrHumanNotFound)
+
+	stored, err := dao.Human(ctx, HumanInput{HumanID: human.ID, IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Equal(t, "trashed", stored.Description)
+	require.True(t, stored.Deleted())
+
+	// migrations still get to it, and it stays in the trash
+	patched, err := dao.PatchDeletedHuman(ctx, human.ID, HumanPatch{Description: &description})
+	require.NoError(t, err)
+	require.Equal(t, description, patched.Description)
+	require.True(t, patched.Deleted())
+}
+
+func TestMemoryDAO_PatchHumanFunc(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderMale})
+	require.NoError(t, err)
+	aliases := []string{"Foo"}
+	_, err = dao.PatchHuman(ctx, human.ID, HumanPatch{Aliases: &aliases})
+	require.NoError(t, err)
+
+	// the patch is made from the stored human, not from the copy read before the first patch
+	patched, err := dao.PatchHumanFunc(ctx, human.ID, func(current Human) (HumanPatch, error) {
+		aliases := append(current.Aliases, "Bar")
+		return HumanPatch{Aliases: &aliases}, nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Foo", "Bar"}, patched.Aliases)
+	require.Equal(t, int64(2), patched.Version)
+
+	_, err = dao.PatchHumanFunc(ctx, human.ID, func(current Human) (HumanPatch, error) {
+		return HumanPatch{}, ErrImageNotFound
+	})
+	require.ErrorIs(t, err, ErrImageNotFound)
+	_, err = dao.PatchHumanFunc(ctx, human.ID, func(current Human) (HumanPatch, error) {
+		gender := Gender("robot")
+		return HumanPatch{Gender: &gender}, nil
+	})
+	require.ErrorIs(t, err, ErrInvalidGender)
+}
+
+func TestDAO_PatchHuman(t *testing.T) {
+	WithDAO(t, func(ctx context.Context, dao *DAO) {
+		human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderFemale, Description: "keep me"})
+		assert.NoError(t, err)
+
+		similar := []string{"a", "b"}
+		images := Images{Featured: "featured.webp", Thumbnail: "thumbnail.webp"}
+		patched, err := dao.PatchHuman(ctx, human.ID, HumanPatch{Similar: &similar, Images: &images})
+		assert.NoError(t, err)
+		assert.Equal(t, similar, patched.Similar)
+
+		got, err := dao.Human(ctx, HumanInput{HumanID: human.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, similar, got.Similar)
+		assert.Equal(t, images, got.Images)
+		assert.Equal(t, "keep me", got.Description)
+		assert.Equal(t, int64(1), got.Version)
+
+		_, err = dao.PatchHuman(ctx, "nope", HumanPatch{Similar: &similar})
+		assert.True(t, errors.Is(err, ErrHumanNotFound))
+	})
+}
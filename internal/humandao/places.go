@@ -0,0 +1,103 @@
+package humandao
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/raymonstah/asianamericanswiki/internal/place"
+)
+
+var ErrInvalidPlace = errors.New("invalid place")
+
+// normalizePlace fills in the place from the gazetteer and checks its coordinates. An empty place
+// becomes nil, so it isn't stored.
+func normalizePlace(p *place.Place) (*place.Place, error) {
+	if p == nil {
+		return nil, nil
+	}
+	resolved := place.Resolve(*p)
+	if resolved.IsZero() {
+		if resolved.HasCoordinates() {
+			return nil, fmt.Errorf("%w: coordinates without a city, region or country", ErrInvalidPlace)
+		}
+		return nil, nil
+	}
+	if resolved.Lat < -90 || resolved.Lat > 90 || resolved.Lng < -180 || resolved.Lng > 180 {
+		return nil, fmt.Errorf("%w: %v is not on the map at %v, %v", ErrInvalidPlace, resolved, resolved.Lat, resolved.Lng)
+	}
+	return &resolved, nil
+}
+
+// normalizePlaces normalizes every place, leaving out empty ones and duplicates.
+func normalizePlaces(places []place.Place) ([]place.Place, error) {
+	if len(places) == 0 {
+		return nil, nil
+	}
+	normalized := make([]place.Place, 0, len(places))
+	for _, p := range places {
+		resolved, err := normalizePlace(&p)
+		if err != nil {
+			return nil, err
+		}
+		if resolved == nil || slices.ContainsFunc(normalized, func(n place.Place) bool { return samePlace(n, *resolved) }) {
+			continue
+		}
+		normalized = append(normalized, *resolved)
+	}
+	return normalized, nil
+}
+
+func samePlace(a, b place.Place) bool {
+	return strings.EqualFold(a.String(), b.String())
+}
+
+// mergePlaces returns the places of both humans, without the ones they share.
+func mergePlaces(winner, loser []place.Place) []place.Place {
+	merged := slices.Clone(winner)
+	for _, p := range loser {
+		if !slices.ContainsFunc(merged, func(m place.Place) bool { return samePlace(m, p) }) {
+			merged = append(merged, p)
+		}
+	}
+	return merged
+}
+
+// placeMatches reports whether the city, region or country of the place is the one given by name or slug.
+func placeMatches(p place.Place, slug string) bool {
+	for _, part := range []string{p.City, p.Region, p.Country} {
+		if part != "" && Slug(part) == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// ByBirthPlace keeps the humans born in the city, region or country, given by name or by its slug.
+func ByBirthPlace(where string) FilterOpt {
+	slug := Slug(where)
+	return func(f Filterable) Filterable {
+		filtered := make([]Human, 0, len(f))
+		for _, human := range f {
+			if human.BirthPlace != nil && placeMatches(*human.BirthPlace, slug) {
+				filtered = append(filtered, human)
+			}
+		}
+		return filtered
+	}
+}
+
+// ByBasedIn keeps the humans based in the city, region or country, given by name or by its slug.
+func ByBasedIn(where string) FilterOpt {
+	slug := Slug(where)
+	return func(f Filterable) Filterable {
+		filtered := make([]Human, 0, len(f))
+		for _, human := range f {
+			if slices.ContainsFunc(human.BasedIn, func(p place.Place) bool { return placeMatches(p, slug) }) {
+				filtered = append(filtered, human)
+			}
+		}
+		return filtered
+	}
+}
@@ -0,0 +1,75 @@
+package humandao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/raymonstah/asianamericanswiki/internal/place"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizePlaces(t *testing.T) {
+	places, err := normalizePlaces([]place.Place{
+		{City: "Los Angeles", Region: "CA"},
+		{},
+		{City: "los angeles", Region: "California", Country: "USA"},
+		{City: "Springfield", Region: "IL"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []place.Place{
+		{City: "Los Angeles", Region: "California", Country: "United States", Lat: 34.05, Lng: -118.24},
+		{City: "Springfield", Region: "Illinois", Country: "United States"},
+	}, places)
+
+	_, err = normalizePlaces([]place.Place{{City: "Atlantis", Lat: 120}})
+	require.ErrorIs(t, err, ErrInvalidPlace)
+	_, err = normalizePlace(&place.Place{Lat: 10, Lng: 10})
+	require.ErrorIs(t, err, ErrInvalidPlace)
+
+	birthPlace, err := normalizePlace(&place.Place{})
+	require.NoError(t, err)
+	require.Nil(t, birthPlace)
+}
+
+func TestFilterable_ByPlace(t *testing.T) {
+	honolulu := place.Parse("Honolulu")
+	seoul := place.Parse("Seoul")
+	f := Filterable{
+		{Name: "Bruno Mars", BirthPlace: &honolulu, BasedIn: []place.Place{place.Parse("Los Angeles")}},
+		{Name: "Steven Yeun", BirthPlace: &seoul, BasedIn: []place.Place{place.Parse("Los Angeles"), place.Parse("Seoul")}},
+		{Name: "Ali Wong", BasedIn: []place.Place{place.Parse("San Francisco")}},
+	}
+
+	require.Equal(t, Filterable{f[0]}, ByBirthPlace("hawaii")(f))
+	require.Equal(t, Filterable{f[1]}, ByBirthPlace("South Korea")(f))
+	require.Equal(t, Filterable{f[0], f[1]}, ByBasedIn("los-angeles")(f))
+	require.Equal(t, Filterable{f[0], f[1], f[2]}, ByBasedIn("california")(f))
+	require.Empty(t, ByBirthPlace("california")(f))
+}
+
+func TestMemoryDAO_Places(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{
+		Name:       "Steven Yeun",
+		Gender:     GenderMale,
+		BirthPlace: &place.Place{City: "Seoul"},
+		BasedIn:    []place.Place{{City: "LA"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, place.Parse("Seoul, South Korea"), *human.BirthPlace)
+	require.Equal(t, []place.Place{place.Parse("Los Angeles")}, human.BasedIn)
+
+	patched, err := dao.PatchHuman(ctx, human.ID, HumanPatch{BirthPlace: &place.Place{}})
+	require.NoError(t, err)
+	require.Nil(t, patched.BirthPlace)
+
+	revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{"birth_place"}, revisions[0].ChangedFields)
+
+	merged := MergeHumans(patched, human, []string{"birth_place"})
+	require.Equal(t, human.BirthPlace, merged.BirthPlace)
+	require.Equal(t, human.BasedIn, merged.BasedIn)
+}
@@ -0,0 +1,309 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidRelationship  = errors.New("invalid relationship")
+	ErrRelationshipExists   = errors.New("relationship already exists")
+	ErrRelationshipNotFound = errors.New("relationship not found")
+)
+
+type RelationshipType string
+
+const (
+	// RelationshipInfluencedBy means From was influenced by To.
+	RelationshipInfluencedBy RelationshipType = "influenced_by"
+	RelationshipFamily       RelationshipType = "family"
+	RelationshipCollaborator RelationshipType = "collaborator"
+	// RelationshipMentor means From mentored To.
+	RelationshipMentor RelationshipType = "mentor"
+	// RelationshipMemberOf means From is a member of To, e.g. a band or a comedy troupe.
+	RelationshipMemberOf RelationshipType = "member_of"
+)
+
+// RelationshipTypes lists every type, in the order connections are shown.
+var RelationshipTypes = []RelationshipType{
+	RelationshipFamily,
+	RelationshipCollaborator,
+	RelationshipMemberOf,
+	RelationshipMentor,
+	RelationshipInfluencedBy,
+}
+
+// relationshipLabels describes a relationship as seen from its From and from its To human.
+var relationshipLabels = map[RelationshipType][2]string{
+	RelationshipInfluencedBy: {"Influenced by", "Influenced"},
+	RelationshipFamily:       {"Family", "Family"},
+	RelationshipCollaborator: {"Collaborators", "Collaborators"},
+	RelationshipMentor:       {"Mentored", "Mentored by"},
+	RelationshipMemberOf:     {"Member of", "Members"},
+}
+
+// Symmetric reports whether the relationship reads the same from both humans.
+func (t RelationshipType) Symmetric() bool {
+	return t == RelationshipFamily || t == RelationshipCollaborator
+}
+
+// Label describes the relationship as seen from its From human, or from its To human if fromSide is false.
+func (t RelationshipType) Label(fromSide bool) string {
+	labels := relationshipLabels[t]
+	if fromSide {
+		return labels[0]
+	}
+	return labels[1]
+}
+
+// Relationship links two humans. It is stored once and shows up on both of them.
+type Relationship struct {
+	ID   string           `firestore:"-"`
+	Type RelationshipType `firestore:"type"`
+	From string           `firestore:"from"`
+	To   string           `firestore:"to"`
+	// Humans is From and To, so the relationships of a human can be found with a single query.
+	Humans []string `firestore:"humans"`
+	// Note says more about the relationship, e.g. "siblings" or "co-starred in Fresh Off the Boat".
+	Note      string    `firestore:"note,omitempty"`
+	CreatedAt time.Time `firestore:"created_at"`
+	CreatedBy string    `firestore:"created_by,omitempty"`
+}
+
+// Other returns the human on the other side of the relationship from humanID.
+func (r Relationship) Other(humanID string) string {
+	if r.From == humanID {
+		return r.To
+	}
+	return r.From
+}
+
+// LabelFor describes the relationship as seen from humanID.
+func (r Relationship) LabelFor(humanID string) string {
+	return r.Type.Label(r.From == humanID)
+}
+
+// relationshipID is the document ID of a relationship. There is only ever one relationship of a
+// type between the same two humans, and symmetric ones don't depend on which side was given first.
+func relationshipID(t RelationshipType, from, to string) string {
+	if t.Symmetric() && to < from {
+		from, to = to, from
+	}
+	return strings.Join([]string{string(t), from, to}, ":")
+}
+
+// newRelationship builds the relationship of type t between from and to, with the humans of a
+// symmetric relationship in a stable order.
+func newRelationship(t RelationshipType, from, to, note, createdBy string, now time.Time) Relationship {
+	if t.Symmetric() && to < from {
+		from, to = to, from
+	}
+	return Relationship{
+		ID:        relationshipID(t, from, to),
+		Type:      t,
+		From:      from,
+		To:        to,
+		Humans:    []string{from, to},
+		Note:      note,
+		CreatedAt: now,
+		CreatedBy: createdBy,
+	}
+}
+
+// moveRelationship returns r with from replaced by to, as when from is merged into to. It returns
+// false when r is between from and to, which leaves nothing to link.
+func moveRelationship(r Relationship, from, to string) (Relationship, bool) {
+	other := r.Other(from)
+	if other == to {
+		return Relationship{}, false
+	}
+	if r.From == from {
+		return newRelationship(r.Type, to, other, r.Note, r.CreatedBy, r.CreatedAt), true
+	}
+	return newRelationship(r.Type, other, to, r.Note, r.CreatedBy, r.CreatedAt), true
+}
+
+// sortRelationships orders relationships the way connections are shown: by type, then oldest first.
+func sortRelationships(relationships []Relationship) {
+	slices.SortFunc(relationships, func(a, b Relationship) int {
+		if c := slices.Index(RelationshipTypes, a.Type) - slices.Index(RelationshipTypes, b.Type); c != 0 {
+			return c
+		}
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+}
+
+type AddRelationshipInput struct {
+	Type      RelationshipType
+	From      string
+	To        string
+	Note      string
+	CreatedBy string
+}
+
+func (input AddRelationshipInput) validate() error {
+	if _, ok := relationshipLabels[input.Type]; !ok {
+		return fmt.Errorf("%w: unknown type %q", ErrInvalidRelationship, input.Type)
+	}
+	if input.From == "" || input.To == "" {
+		return fmt.Errorf("%w: both humans must be provided", ErrInvalidRelationship)
+	}
+	if input.From == input.To {
+		return fmt.Errorf("%w: a human can't be related to itself", ErrInvalidRelationship)
+	}
+	return nil
+}
+
+type RemoveRelationshipInput struct {
+	RelationshipID string
+}
+
+type RelationshipsInput struct {
+	HumanID string
+}
+
+func (d *DAO) relationships() *firestore.CollectionRef {
+	return d.client.Collection(d.relationshipCollection)
+}
+
+// AddRelationship links two humans that exist and aren't in the trash.
+func (d *DAO) AddRelationship(ctx context.Context, input AddRelationshipInput) (Relationship, error) {
+	if err := input.validate(); err != nil {
+		return Relationship{}, err
+	}
+
+	relationship := newRelationship(input.Type, input.From, input.To, strings.TrimSpace(input.Note), authorFromContext(ctx, input.CreatedBy), time.Now())
+	humans := d.client.Collection(d.humanCollection)
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs, err := tx.GetAll([]*firestore.DocumentRef{humans.Doc(input.From), humans.Doc(input.To)})
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
+			if !doc.Exists() {
+				return fmt.Errorf("%w: %v", ErrHumanNotFound, doc.Ref.ID)
+			}
+			human, err := convertHumanDoc(doc)
+			if err != nil {
+				return err
+			}
+			if human.Deleted() {
+				return fmt.Errorf("%w: %v", ErrHumanNotFound, human.ID)
+			}
+		}
+
+		return tx.Create(d.relationships().Doc(relationship.ID), relationship)
+	})
+	if err != nil {
+		if status.Code(err) == codes.AlreadyExists {
+			return Relationship{}, fmt.Errorf("%w: %v", ErrRelationshipExists, relationship.ID)
+		}
+		return Relationship{}, fmt.Errorf("unable to add relationship: %w", err)
+	}
+
+	return relationship, nil
+}
+
+func (d *DAO) RemoveRelationship(ctx context.Context, input RemoveRelationshipInput) error {
+	ref := d.relationships().Doc(input.RelationshipID)
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if _, err := tx.Get(ref); err != nil {
+			return err
+		}
+		return tx.Delete(ref)
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return fmt.Errorf("%w: %v", ErrRelationshipNotFound, input.RelationshipID)
+		}
+		return fmt.Errorf("unable to remove relationship: %v: %w", input.RelationshipID, err)
+	}
+
+	return nil
+}
+
+// Relationships returns every relationship of a human, whichever side of it they are on. The other
+// humans may be drafts or in the trash, so callers decide which of them to show.
+func (d *DAO) Relationships(ctx context.Context, input RelationshipsInput) ([]Relationship, error) {
+	docs, err := d.relationships().Where("humans", "array-contains", input.HumanID).Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get relationships of %v: %w", input.HumanID, err)
+	}
+
+	relationships, err := convertRelationshipDocs(docs)
+	if err != nil {
+		return nil, err
+	}
+	sortRelationships(relationships)
+	return relationships, nil
+}
+
+func convertRelationshipDocs(docs []*firestore.DocumentSnapshot) ([]Relationship, error) {
+	relationships := make([]Relationship, 0, len(docs))
+	for _, doc := range docs {
+		var relationship Relationship
+		if err := doc.DataTo(&relationship); err != nil {
+			return nil, fmt.Errorf("unable to convert relationship %v: %w", doc.Ref.ID, err)
+		}
+		relationship.ID = doc.Ref.ID
+		relationships = append(relationships, relationship)
+	}
+	return relationships, nil
+}
+
+// Connection is a relationship as seen from one of its humans.
+type Connection struct {
+	Relationship Relationship
+	Human        Human
+}
+
+// ConnectionGroup is the connections of a human that share a label, like "Family" or "Influenced by".
+type ConnectionGroup struct {
+	Label       string
+	Connections []Connection
+}
+
+// Connections groups the relationships of humanID by how they read from its side, in the order of
+// RelationshipTypes. Relationships with a human that lookup can't find, like one in the trash, are left out.
+func Connections(humanID string, relationships []Relationship, lookup func(id string) (Human, bool)) []ConnectionGroup {
+	relationships = slices.Clone(relationships)
+	sortRelationships(relationships)
+
+	var groups []ConnectionGroup
+	for _, t := range RelationshipTypes {
+		for _, fromSide := range []bool{true, false} {
+			if t.Symmetric() && !fromSide {
+				continue
+			}
+			group := ConnectionGroup{Label: t.Label(fromSide)}
+			for _, relationship := range relationships {
+				if relationship.Type != t || !slices.Contains(relationship.Humans, humanID) {
+					continue
+				}
+				if !t.Symmetric() && (relationship.From == humanID) != fromSide {
+					continue
+				}
+				other, ok := lookup(relationship.Other(humanID))
+				if !ok {
+					continue
+				}
+				group.Connections = append(group.Connections, Connection{Relationship: relationship, Human: other})
+			}
+			if len(group.Connections) > 0 {
+				groups = append(groups, group)
+			}
+		}
+	}
+	return groups
+}
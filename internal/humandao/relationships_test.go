@@ -0,0 +1,149 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestConnections(t *testing.T) {
+	at := func(hour int) time.Time {
+		return time.Date(2024, time.March, 15, hour, 0, 0, 0, time.UTC)
+	}
+	humans := map[string]Human{
+		"ali":     {ID: "ali", Name: "Ali Wong"},
+		"randall": {ID: "randall", Name: "Randall Park"},
+		"bruce":   {ID: "bruce", Name: "Bruce Lee"},
+		"shannon": {ID: "shannon", Name: "Shannon Lee"},
+	}
+	relationships := []Relationship{
+		newRelationship(RelationshipInfluencedBy, "ali", "bruce", "", "", at(3)),
+		newRelationship(RelationshipCollaborator, "randall", "ali", "Always Be My Maybe", "", at(1)),
+		newRelationship(RelationshipMentor, "ali", "shannon", "", "", at(2)),
+		newRelationship(RelationshipMentor, "randall", "ali", "", "", at(4)),
+		// trashed, so it isn't shown
+		newRelationship(RelationshipFamily, "ali", "nobody", "", "", at(5)),
+	}
+	lookup := func(id string) (Human, bool) {
+		human, ok := humans[id]
+		return human, ok
+	}
+
+	groups := Connections("ali", relationships, lookup)
+	var labels []string
+	for _, group := range groups {
+		labels = append(labels, group.Label)
+		require.Len(t, group.Connections, 1, group.Label)
+	}
+	require.Equal(t, []string{"Collaborators", "Mentored", "Mentored by", "Influenced by"}, labels)
+	require.Equal(t, "randall", groups[0].Connections[0].Human.ID)
+	require.Equal(t, "Always Be My Maybe", groups[0].Connections[0].Relationship.Note)
+
+	groups = Connections("bruce", relationships, lookup)
+	require.Len(t, groups, 1)
+	require.Equal(t, "Influenced", groups[0].Label)
+}
+
+func TestMemoryDAO_Relationships(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO(
+		Human{ID: "ali", Name: "Ali Wong"},
+		Human{ID: "randall", Name: "Randall Park"},
+		Human{ID: "randall2", Name: "Randall Park Jr"},
+		Human{ID: "justin", Name: "Justin Lin"},
+	)
+
+	_, err := dao.AddRelationship(ctx, AddRelationshipInput{Type: "enemies", From: "ali", To: "randall"})
+	require.ErrorIs(t, err, ErrInvalidRelationship)
+	_, err = dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipFamily, From: "ali", To: "ali"})
+	require.ErrorIs(t, err, ErrInvalidRelationship)
+	_, err = dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipFamily, From: "ali", To: "nobody"})
+	require.ErrorIs(t, err, ErrHumanNotFound)
+
+	collaborators, err := dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipCollaborator, From: "ali", To: "randall", Note: " Always Be My Maybe "})
+	require.NoError(t, err)
+	require.Equal(t, "Always Be My Maybe", collaborators.Note)
+	// symmetric relationships are the same whichever side they are added from
+	_, err = dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipCollaborator, From: "randall", To: "ali"})
+	require.ErrorIs(t, err, ErrRelationshipExists)
+	_, err = dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipMentor, From: "randall2", To: "ali"})
+	require.NoError(t, err)
+	_, err = dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipCollaborator, From: "randall2", To: "justin"})
+	require.NoError(t, err)
+
+	relationships, err := dao.Relationships(ctx, RelationshipsInput{HumanID: "randall"})
+	require.NoError(t, err)
+	require.Len(t, relationships, 1)
+	require.Equal(t, "ali", relationships[0].Other("randall"))
+
+	// merging moves the loser's relationships over, dropping the ones the winner already has
+	_, err = dao.Merge(ctx, MergeInput{WinnerID: "randall", LoserID: "randall2"})
+	require.NoError(t, err)
+	relationships, err = dao.Relationships(ctx, RelationshipsInput{HumanID: "randall"})
+	require.NoError(t, err)
+	require.Len(t, relationships, 3)
+	relationships, err = dao.Relationships(ctx, RelationshipsInput{HumanID: "randall2"})
+	require.NoError(t, err)
+	require.Empty(t, relationships)
+	relationships, err = dao.Relationships(ctx, RelationshipsInput{HumanID: "ali"})
+	require.NoError(t, err)
+	require.Equal(t, []RelationshipType{RelationshipCollaborator, RelationshipMentor}, []RelationshipType{relationships[0].Type, relationships[1].Type})
+	require.Equal(t, "randall", relationships[1].From)
+
+	// relationships with a deleted human stay until it is purged, so a restore brings them back
+	require.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: "justin"}))
+	relationships, err = dao.Relationships(ctx, RelationshipsInput{HumanID: "justin"})
+	require.NoError(t, err)
+	require.Len(t, relationships, 1)
+	require.NoError(t, dao.Purge(ctx, PurgeInput{HumanID: "justin"}))
+	relationships, err = dao.Relationships(ctx, RelationshipsInput{HumanID: "randall"})
+	require.NoError(t, err)
+	require.Len(t, relationships, 2)
+
+	require.NoError(t, dao.RemoveRelationship(ctx, RemoveRelationshipInput{RelationshipID: collaborators.ID}))
+	require.ErrorIs(t, dao.RemoveRelationship(ctx, RemoveRelationshipInput{RelationshipID: collaborators.ID}), ErrRelationshipNotFound)
+}
+
+func TestDAO_Relationships(t *testing.T) {
+	WithDAO(t, func(ctx context.Context, dao *DAO) {
+		ali, err := dao.AddHuman(ctx, AddHumanInput{Name: "Ali Wong", Gender: GenderFemale})
+		assert.NoError(t, err)
+		randall, err := dao.AddHuman(ctx, AddHumanInput{Name: "Randall Park", Gender: GenderMale})
+		assert.NoError(t, err)
+		duplicate, err := dao.AddHuman(ctx, AddHumanInput{Name: "Randall Park Jr", Gender: GenderMale})
+		assert.NoError(t, err)
+
+		collaborators, err := dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipCollaborator, From: ali.ID, To: randall.ID})
+		assert.NoError(t, err)
+		_, err = dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipCollaborator, From: randall.ID, To: ali.ID})
+		assert.True(t, errors.Is(err, ErrRelationshipExists))
+		_, err = dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipMentor, From: duplicate.ID, To: ali.ID})
+		assert.NoError(t, err)
+
+		relationships, err := dao.Relationships(ctx, RelationshipsInput{HumanID: ali.ID})
+		assert.NoError(t, err)
+		assert.Len(t, relationships, 2)
+		assert.Equal(t, collaborators.ID, relationships[0].ID)
+
+		_, err = dao.Merge(ctx, MergeInput{WinnerID: randall.ID, LoserID: duplicate.ID})
+		assert.NoError(t, err)
+		relationships, err = dao.Relationships(ctx, RelationshipsInput{HumanID: randall.ID})
+		assert.NoError(t, err)
+		assert.Len(t, relationships, 2)
+		assert.Equal(t, randall.ID, relationships[1].From)
+
+		assert.NoError(t, dao.RemoveRelationship(ctx, RemoveRelationshipInput{RelationshipID: collaborators.ID}))
+		err = dao.RemoveRelationship(ctx, RemoveRelationshipInput{RelationshipID: collaborators.ID})
+		assert.True(t, errors.Is(err, ErrRelationshipNotFound))
+
+		assert.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: randall.ID}))
+		assert.NoError(t, dao.Purge(ctx, PurgeInput{HumanID: randall.ID}))
+		relationships, err = dao.Relationships(ctx, RelationshipsInput{HumanID: ali.ID})
+		assert.NoError(t, err)
+		assert.Empty(t, relationships)
+	})
+}
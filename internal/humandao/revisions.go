@@ -0,0 +1,385 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+const revisionCollection = "revisions"
+
+type RevisionAction string
+
+const (
+	RevisionActionCreate  RevisionAction = "create"
+	RevisionActionUpdate  RevisionAction = "update"
+	RevisionActionPublish RevisionAction = "publish"
+	RevisionActionDelete  RevisionAction = "delete"
+	RevisionActionRestore RevisionAction = "restore"
+	// RevisionActionUndelete is a human being taken back out of the trash.
+	RevisionActionUndelete RevisionAction = "undelete"
+	// RevisionActionMerge is recorded on both humans of a merge, and on every human
+	// whose references were pointed at the winner.
+	RevisionActionMerge RevisionAction = "merge"
+)
+
+// Revision is an immutable record of a write to a human. Snapshot is the full human as it was
+// after the write.
+type Revision struct {
+	ID            string         `firestore:"-"`
+	HumanID       string         `firestore:"human_id"`
+	Action        RevisionAction `firestore:"action"`
+	Author        string         `firestore:"author,omitempty"`
+	CreatedAt     time.Time      `firestore:"created_at"`
+	ChangedFields []string       `firestore:"changed_fields,omitempty"`
+	Snapshot      Human          `firestore:"snapshot"`
+	// RestoredFrom is set when Action is RevisionActionRestore.
+	RestoredFrom string `firestore:"restored_from,omitempty"`
+	// MergedFrom and MergedInto are set when Action is RevisionActionMerge.
+	MergedFrom string `firestore:"merged_from,omitempty"`
+	MergedInto string `firestore:"merged_into,omitempty"`
+}
+
+type authorKey struct{}
+
+// WithAuthor attributes every write made with the returned context to the given user ID.
+func WithAuthor(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, authorKey{}, userID)
+}
+
+// WithDefaultAuthor is WithAuthor, unless ctx already attributes its writes to someone.
+func WithDefaultAuthor(ctx context.Context, userID string) context.Context {
+	if authorFromContext(ctx, "") != "" {
+		return ctx
+	}
+	return WithAuthor(ctx, userID)
+}
+
+// AuthorFromContext returns who ctx attributes its writes to, if anyone.
+func AuthorFromContext(ctx context.Context) string {
+	return authorFromContext(ctx, "")
+}
+
+func authorFromContext(ctx context.Context, fallback string) string {
+	if author, ok := ctx.Value(authorKey{}).(string); ok && author != "" {
+		return author
+	}
+	return fallback
+}
+
+func newRevision(ctx context.Context, action RevisionAction, fallbackAuthor string, before, after Human) Revision {
+	changes := DiffHumans(before, after)
+	fields := make([]string, 0, len(changes))
+	for _, change := range changes {
+		fields = append(fields, change.Field)
+	}
+
+	return Revision{
+		ID:            ksuid.New().String(),
+		HumanID:       after.ID,
+		Action:        action,
+		Author:        authorFromContext(ctx, fallbackAuthor),
+		CreatedAt:     time.Now().In(time.UTC),
+		ChangedFields: fields,
+		Snapshot:      cloneHuman(after),
+	}
+}
+
+// FieldChange is a single field that differs between two versions of a human.
+// Field is the firestore field name, with nested fields joined by a dot (e.g. socials.x).
+type FieldChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// ignoredDiffFields change on every write, or outside of edits, so they are left out of diffs.
+var ignoredDiffFields = map[string]struct{}{
+	"updated_at": {},
+	"version":    {},
+	"views":      {},
+}
+
+// DiffHumans returns the fields that differ between before and after.
+func DiffHumans(before, after Human) []FieldChange {
+	var changes []FieldChange
+	diffStruct("", reflect.ValueOf(before), reflect.ValueOf(after), &changes)
+	return changes
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func diffStruct(prefix string, before, after reflect.Value, changes *[]FieldChange) {
+	t := before.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("firestore"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		name = prefix + name
+		if _, ok := ignoredDiffFields[name]; ok {
+			continue
+		}
+
+		a, b := before.Field(i), after.Field(i)
+		if field.Type.Kind() == reflect.Struct && field.Type != timeType {
+			diffStruct(name+".", a, b, changes)
+			continue
+		}
+		if equalValues(a, b) {
+			continue
+		}
+		*changes = append(*changes, FieldChange{Field: name, Before: formatValue(a), After: formatValue(b)})
+	}
+}
+
+func equalValues(a, b reflect.Value) bool {
+	switch {
+	case a.Type() == timeType:
+		return a.Interface().(time.Time).Equal(b.Interface().(time.Time))
+	case a.Kind() == reflect.Slice && a.Len() == 0 && b.Len() == 0:
+		return true
+	default:
+		return reflect.DeepEqual(a.Interface(), b.Interface())
+	}
+}
+
+func formatValue(v reflect.Value) string {
+	switch {
+	case v.Type() == timeType:
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	case v.Kind() == reflect.Slice:
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			parts = append(parts, fmt.Sprint(v.Index(i).Interface()))
+		}
+		return strings.Join(parts, ", ")
+	default:
+		if v.IsZero() {
+			return ""
+		}
+		return fmt.Sprint(v.Interface())
+	}
+}
+
+// restoreFrom returns current with every edited field taken from snapshot.
+// Bookkeeping that doesn't belong to an edit (id, views, creation, deletion) is kept from current.
+func restoreFrom(current, snapshot Human) Human {
+	restored := cloneHuman(snapshot)
+	restored.ID = current.ID
+	restored.Views = current.Views
+	restored.CreatedAt = current.CreatedAt
+	restored.CreatedBy = current.CreatedBy
+	restored.DeletedAt = current.DeletedAt
+	restored.DeletedBy = current.DeletedBy
+	restored.MergedInto = current.MergedInto
+	restored.Version = current.Version
+	return restored
+}
+
+// prepareRestore returns the human that restoring snapshot over current writes. It goes through the
+// same checks as UpdateHuman, so a restore can't bring back tags, ethnicities or dates that are no
+// longer allowed. version is checked against current when the human exists.
+func prepareRestore(current, snapshot Human, exists bool, version int64, taxonomy Taxonomy) (Human, error) {
+	restored := restoreFrom(current, snapshot)
+	if exists {
+		restored.Version = version
+		checked, err := checkVersion(current, restored)
+		if err != nil {
+			return Human{}, err
+		}
+		restored = checked
+	}
+	restored, err := prepareUpdate(restored)
+	if err != nil {
+		return Human{}, err
+	}
+	restored.PreviousPaths = renamedPaths(current, restored.Path)
+	restored.Tags, err = taxonomy.normalizeKeeping(restored.Tags, current.Tags)
+	if err != nil {
+		return Human{}, err
+	}
+	restored.Version++
+	return restored, nil
+}
+
+func (d *DAO) revisions(humanID string) *firestore.CollectionRef {
+	return d.client.Collection(d.humanCollection).Doc(humanID).Collection(revisionCollection)
+}
+
+type RevisionsInput struct {
+	HumanID string
+	Limit   int
+	// PageToken is the next page token returned by the previous call with the same input.
+	PageToken string
+}
+
+// Revisions returns a page of the revisions of a human, newest first, along with the token for the
+// page after it. A zero Limit returns all of them.
+func (d *DAO) Revisions(ctx context.Context, input RevisionsInput) ([]Revision, string, error) {
+	revisions, next, err := listPage(ctx, d.revisions(input.HumanID).Query, orderByRevisions, firestore.Desc, input.PageToken, input.Limit, collectRevisions, newRevisionPageToken)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to get revisions: %w", err)
+	}
+
+	return revisions, next, nil
+}
+
+// collectRevisions runs query, stopping after limit.
+func collectRevisions(ctx context.Context, query firestore.Query, limit int) ([]Revision, error) {
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]Revision, 0, len(docs))
+	for _, doc := range docs {
+		revision, err := convertRevisionDoc(doc)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+type RevisionInput struct {
+	HumanID    string
+	RevisionID string
+}
+
+func (d *DAO) Revision(ctx context.Context, input RevisionInput) (Revision, error) {
+	doc, err := d.revisions(input.HumanID).Doc(input.RevisionID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return Revision{}, fmt.Errorf("%w: %v", ErrRevisionNotFound, input.RevisionID)
+		}
+		return Revision{}, fmt.Errorf("unable to get revision: %w", err)
+	}
+
+	return convertRevisionDoc(doc)
+}
+
+type DiffRevisionsInput struct {
+	HumanID string
+	// From is the older revision. When empty, To is compared against an empty human.
+	From string
+	To   string
+}
+
+func (d *DAO) DiffRevisions(ctx context.Context, input DiffRevisionsInput) ([]FieldChange, error) {
+	return diffRevisions(ctx, d, input)
+}
+
+type RestoreRevisionInput struct {
+	HumanID    string
+	RevisionID string
+	// Version is the version of the human the restore was based on. Like with UpdateHuman, the
+	// restore only succeeds if it matches the stored version. It is ignored for purged humans.
+	Version int64
+}
+
+// RestoreRevision writes the snapshot of a prior revision back to the human, and records the
+// restore as a new revision.
+func (d *DAO) RestoreRevision(ctx context.Context, input RestoreRevisionInput) (Human, error) {
+	revision, err := d.Revision(ctx, RevisionInput{HumanID: input.HumanID, RevisionID: input.RevisionID})
+	if err != nil {
+		return Human{}, err
+	}
+	taxonomy, err := d.taxonomyFor(ctx, revision.Snapshot.Tags)
+	if err != nil {
+		return Human{}, err
+	}
+
+	var restored Human
+	ref := d.client.Collection(d.humanCollection).Doc(input.HumanID)
+	err = d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+
+		var current Human
+		exists := doc != nil && doc.Exists()
+		if exists {
+			current, err = convertHumanDoc(doc)
+			if err != nil {
+				return err
+			}
+		} else {
+			current = Human{ID: input.HumanID, CreatedAt: revision.Snapshot.CreatedAt, CreatedBy: revision.Snapshot.CreatedBy}
+		}
+
+		restored, err = prepareRestore(current, revision.Snapshot, exists, input.Version, taxonomy)
+		if err != nil {
+			return err
+		}
+		if restored.Path != current.Path {
+			if err := d.checkPathAvailable(tx, input.HumanID, restored.Path); err != nil {
+				return err
+			}
+		}
+		if err := tx.Set(ref, restored); err != nil {
+			return err
+		}
+
+		rev := newRevision(ctx, RevisionActionRestore, "", current, restored)
+		rev.RestoredFrom = revision.ID
+		return tx.Create(d.revisions(input.HumanID).Doc(rev.ID), rev)
+	})
+	if err != nil {
+		return Human{}, fmt.Errorf("unable to restore revision: %v: %w", input.RevisionID, err)
+	}
+
+	return restored, nil
+}
+
+type revisionGetter interface {
+	Revision(ctx context.Context, input RevisionInput) (Revision, error)
+}
+
+func diffRevisions(ctx context.Context, getter revisionGetter, input DiffRevisionsInput) ([]FieldChange, error) {
+	to, err := getter.Revision(ctx, RevisionInput{HumanID: input.HumanID, RevisionID: input.To})
+	if err != nil {
+		return nil, err
+	}
+
+	var from Revision
+	if input.From != "" {
+		from, err = getter.Revision(ctx, RevisionInput{HumanID: input.HumanID, RevisionID: input.From})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return DiffHumans(from.Snapshot, to.Snapshot), nil
+}
+
+func convertRevisionDoc(doc *firestore.DocumentSnapshot) (Revision, error) {
+	var revision Revision
+	if err := doc.DataTo(&revision); err != nil {
+		return Revision{}, fmt.Errorf("unable to convert document to revision: %w", err)
+	}
+	revision.ID = doc.Ref.ID
+	revision.Snapshot.ID = revision.HumanID
+	return revision, nil
+}
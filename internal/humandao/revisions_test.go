@@ -0,0 +1,159 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestDiffHumans(t *testing.T) {
+	before := Human{
+		Name:      "Foo Bar",
+		Tags:      []string{"actor"},
+		UpdatedAt: time.Now(),
+		Views:     3,
+	}
+	after := Human{
+		Name:      "Foo Bar",
+		Tags:      []string{"actor", "comedian"},
+		Aliases:   []string{},
+		Socials:   Socials{X: "https://x.com/foobar"},
+		UpdatedAt: time.Now().Add(time.Hour),
+		Views:     10,
+	}
+
+	changes := DiffHumans(before, after)
+	require.Equal(t, []FieldChange{
+		{Field: "tags", Before: "actor", After: "actor, comedian"},
+		{Field: "socials.x", Before: "", After: "https://x.com/foobar"},
+	}, changes)
+	require.Empty(t, DiffHumans(after, after))
+}
+
+func TestWithDefaultAuthor(t *testing.T) {
+	ctx := WithDefaultAuthor(context.Background(), "image-upload")
+	require.Equal(t, "image-upload", authorFromContext(ctx, ""))
+
+	ctx = WithDefaultAuthor(WithAuthor(context.Background(), "admin123"), "image-upload")
+	require.Equal(t, "admin123", authorFromContext(ctx, ""))
+}
+
+func TestMemoryDAO_Revisions(t *testing.T) {
+	ctx := WithAuthor(context.Background(), "admin123")
+	dao := NewMemoryDAO()
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderMale, Description: "first"})
+	require.NoError(t, err)
+
+	human.Description = "second"
+	human, err = dao.UpdateHuman(ctx, human)
+	require.NoError(t, err)
+
+	revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, RevisionActionUpdate, revisions[0].Action)
+	require.Equal(t, []string{"description"}, revisions[0].ChangedFields)
+	require.Equal(t, "admin123", revisions[0].Author)
+	require.Equal(t, RevisionActionCreate, revisions[1].Action)
+
+	changes, err := dao.DiffRevisions(ctx, DiffRevisionsInput{HumanID: human.ID, From: revisions[1].ID, To: revisions[0].ID})
+	require.NoError(t, err)
+	require.Equal(t, []FieldChange{{Field: "description", Before: "first", After: "second"}}, changes)
+
+	_, err = dao.RestoreRevision(ctx, RestoreRevisionInput{HumanID: human.ID, RevisionID: revisions[1].ID, Version: human.Version - 1})
+	require.ErrorIs(t, err, ErrConflict)
+
+	restored, err := dao.RestoreRevision(ctx, RestoreRevisionInput{HumanID: human.ID, RevisionID: revisions[1].ID, Version: human.Version})
+	require.NoError(t, err)
+	require.Equal(t, "first", restored.Description)
+	require.Equal(t, human.Version+1, restored.Version)
+
+	revisions, next, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, RevisionActionRestore, revisions[0].Action)
+	require.Equal(t, RevisionActionUpdate, revisions[1].Action)
+	revisions, next, err = dao.Revisions(ctx, RevisionsInput{HumanID: human.ID, Limit: 2, PageToken: next})
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.Equal(t, RevisionActionCreate, revisions[0].Action)
+	require.Empty(t, next)
+
+	_, err = dao.Revision(ctx, RevisionInput{HumanID: human.ID, RevisionID: "nope"})
+	require.ErrorIs(t, err, ErrRevisionNotFound)
+}
+
+func TestDAO_Revisions(t *testing.T) {
+	WithDAO(t, func(ctx context.Context, dao *DAO) {
+		ctx = WithAuthor(ctx, "admin123")
+		human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderFemale, Description: "first"})
+		assert.NoError(t, err)
+
+		human.Description = "second"
+		human, err = dao.UpdateHuman(ctx, human)
+		assert.NoError(t, err)
+		assert.NoError(t, dao.Publish(ctx, PublishInput{HumanID: human.ID, UserID: "admin123"}))
+		human, err = dao.Human(ctx, HumanInput{HumanID: human.ID})
+		assert.NoError(t, err)
+
+		revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID})
+		assert.NoError(t, err)
+		assert.Len(t, revisions, 3)
+		assert.Equal(t, RevisionActionPublish, revisions[0].Action)
+		assert.Equal(t, RevisionActionUpdate, revisions[1].Action)
+		assert.Equal(t, []string{"description"}, revisions[1].ChangedFields)
+		assert.Equal(t, "second", revisions[1].Snapshot.Description)
+
+		page, next, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID, Limit: 2})
+		assert.NoError(t, err)
+		assert.Equal(t, revisions[:2], page)
+		page, next, err = dao.Revisions(ctx, RevisionsInput{HumanID: human.ID, Limit: 2, PageToken: next})
+		assert.NoError(t, err)
+		assert.Equal(t, revisions[2:], page)
+		assert.Equal(t, "", next)
+
+		_, err = dao.RestoreRevision(ctx, RestoreRevisionInput{HumanID: human.ID, RevisionID: revisions[2].ID, Version: human.Version - 1})
+		assert.True(t, errors.Is(err, ErrConflict))
+
+		restored, err := dao.RestoreRevision(ctx, RestoreRevisionInput{HumanID: human.ID, RevisionID: revisions[2].ID, Version: human.Version})
+		assert.NoError(t, err)
+		assert.Equal(t, "first", restored.Description)
+
+		got, err := dao.Human(ctx, HumanInput{HumanID: human.ID})
+		assert.NoError(t, err)
+		assert.Equal(t, "first", got.Description)
+	})
+}
+
+func TestMemoryDAO_RestoreRevisionRetiredTag(t *testing.T) {
+	ctx := WithAuthor(context.Background(), "admin123")
+	// ballerina was retired from the tags after the human was tagged with it
+	dao := NewMemoryDAO(Human{ID: "legacy", Name: "Legacy", Gender: GenderFemale, Tags: []string{"ballerina", "actor"}, Ethnicity: []string{"Chinese"}})
+
+	description := "first"
+	_, err := dao.PatchHuman(ctx, "legacy", HumanPatch{Description: &description})
+	require.NoError(t, err)
+	tagged, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: "legacy", Limit: 1})
+	require.NoError(t, err)
+	human, err := dao.PatchHuman(ctx, "legacy", HumanPatch{Tags: &[]string{"actor"}})
+	require.NoError(t, err)
+
+	_, err = dao.RestoreRevision(ctx, RestoreRevisionInput{HumanID: "legacy", RevisionID: tagged[0].ID, Version: human.Version})
+	require.ErrorIs(t, err, ErrInvalidTag)
+	human, err = dao.Human(ctx, HumanInput{HumanID: "legacy"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"actor"}, human.Tags)
+
+	// the restore goes through once the tag is back
+	_, err = dao.SaveTag(ctx, SaveTagInput{Tag: Tag{Name: "ballerina", Parent: "entertainer"}})
+	require.NoError(t, err)
+	restored, err := dao.RestoreRevision(ctx, RestoreRevisionInput{HumanID: "legacy", RevisionID: tagged[0].ID, Version: human.Version})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ballerina", "actor"}, restored.Tags)
+	require.Equal(t, []string{"chinese"}, restored.Ethnicity)
+}
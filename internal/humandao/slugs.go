@@ -0,0 +1,42 @@
+package humandao
+
+import (
+	"fmt"
+	"slices"
+
+	"cloud.google.com/go/firestore"
+)
+
+// renamedPaths returns the previous paths of a human that moves from previous.Path to path.
+// The old path is kept so links to it keep working, and path is dropped in case the human
+// is being renamed back to an old name.
+func renamedPaths(previous Human, path string) []string {
+	paths := slices.Clone(previous.PreviousPaths)
+	if previous.Path != "" && previous.Path != path && !slices.Contains(paths, previous.Path) {
+		paths = append(paths, previous.Path)
+	}
+	return slices.DeleteFunc(paths, func(p string) bool { return p == path })
+}
+
+// checkPathAvailable returns ErrHumanAlreadyExists if any other human uses path,
+// either as its current path or as one it redirects from.
+func (d *DAO) checkPathAvailable(tx *firestore.Transaction, humanID, path string) error {
+	collection := d.client.Collection(d.humanCollection)
+	queries := []firestore.Query{
+		collection.Where("urn_path", "==", path).Limit(2),
+		collection.Where("previous_paths", "array-contains", path).Limit(2),
+	}
+	for _, query := range queries {
+		docs, err := tx.Documents(query).GetAll()
+		if err != nil {
+			return fmt.Errorf("unable to check if path (%v) is taken: %w", path, err)
+		}
+		for _, doc := range docs {
+			if doc.Ref.ID != humanID {
+				return fmt.Errorf("%w: %v is used by %v", ErrHumanAlreadyExists, path, doc.Ref.ID)
+			}
+		}
+	}
+
+	return nil
+}
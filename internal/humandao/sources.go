@@ -0,0 +1,127 @@
+package humandao
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSource = errors.New("invalid source")
+
+// Source is a citation for facts about a human.
+type Source struct {
+	URL        string    `firestore:"url"`
+	Title      string    `firestore:"title,omitempty"`
+	Publisher  string    `firestore:"publisher,omitempty"`
+	AccessedAt time.Time `firestore:"accessed_at,omitempty"`
+	// Fields are the firestore names of the fields this source supports (e.g. dob, description).
+	// An empty list means the source supports the entry as a whole.
+	Fields []string `firestore:"fields,omitempty"`
+}
+
+func (s Source) String() string {
+	if len(s.Fields) == 0 {
+		return s.URL
+	}
+	return fmt.Sprintf("%v (%v)", s.URL, strings.Join(s.Fields, ", "))
+}
+
+// Supports reports whether the source backs up the given field.
+func (s Source) Supports(field string) bool {
+	return len(s.Fields) == 0 || slices.Contains(s.Fields, field)
+}
+
+// Unsourced reports whether the human doesn't cite a single source.
+func (h Human) Unsourced() bool {
+	return len(h.Sources) == 0
+}
+
+// Footnotes returns the 1-based numbers of the sources that support field, for rendering
+// footnote markers next to it.
+func (h Human) Footnotes(field string) []int {
+	var footnotes []int
+	for i, source := range h.Sources {
+		if source.Supports(field) {
+			footnotes = append(footnotes, i+1)
+		}
+	}
+	return footnotes
+}
+
+// SourceFields are the fields a source can support: every field on a human that holds a fact
+// about them, rather than bookkeeping.
+var SourceFields = humanFields()
+
+// unsourcedFields are kept by the site itself, so there is nothing to cite for them.
+var unsourcedFields = map[string]struct{}{
+	"urn_path":       {},
+	"previous_paths": {},
+	"featured_image": {},
+	"draft":          {},
+	"ai_generated":   {},
+	"created_at":     {},
+	"created_by":     {},
+	"updated_at":     {},
+	"version":        {},
+	"published_by":   {},
+	"published_at":   {},
+	"views":          {},
+	"similar":        {},
+	"images":         {},
+	"sources":        {},
+	"deleted_at":     {},
+	"deleted_by":     {},
+	"merged_into":    {},
+}
+
+func humanFields() []string {
+	t := reflect.TypeOf(Human{})
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("firestore"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+		if _, ok := unsourcedFields[name]; ok {
+			continue
+		}
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// normalizeSources trims the sources and makes sure every one of them links somewhere real.
+func normalizeSources(sources []Source) ([]Source, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+	normalized := make([]Source, 0, len(sources))
+	for _, source := range sources {
+		source.URL = strings.TrimSpace(source.URL)
+		source.Title = strings.TrimSpace(source.Title)
+		source.Publisher = strings.TrimSpace(source.Publisher)
+		u, err := url.Parse(source.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("%w: %q is not a http(s) url", ErrInvalidSource, source.URL)
+		}
+
+		fields := make([]string, 0, len(source.Fields))
+		for _, field := range source.Fields {
+			field = strings.ToLower(strings.TrimSpace(field))
+			if field == "" || slices.Contains(fields, field) {
+				continue
+			}
+			if !slices.Contains(SourceFields, field) {
+				return nil, fmt.Errorf("%w: unknown field %v for %v", ErrInvalidSource, field, source.URL)
+			}
+			fields = append(fields, field)
+		}
+		source.Fields = fields
+		normalized = append(normalized, source)
+	}
+	return normalized, nil
+}
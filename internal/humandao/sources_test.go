@@ -0,0 +1,67 @@
+package humandao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHuman_Footnotes(t *testing.T) {
+	human := Human{Name: "Foo"}
+	require.True(t, human.Unsourced())
+	require.Empty(t, human.Footnotes("description"))
+
+	human.Sources = []Source{
+		{URL: "https://example.com/profile"},
+		{URL: "https://example.com/birth", Fields: []string{"dob"}},
+		{URL: "https://example.com/bio", Fields: []string{"description", "dob"}},
+	}
+	require.False(t, human.Unsourced())
+	require.Equal(t, []int{1, 3}, human.Footnotes("description"))
+	require.Equal(t, []int{1, 2, 3}, human.Footnotes("dob"))
+	require.Equal(t, []int{1}, human.Footnotes("tags"))
+}
+
+func TestNormalizeSources(t *testing.T) {
+	sources, err := normalizeSources([]Source{{URL: " https://example.com ", Title: " Example ", Fields: []string{"DOB", "dob", " "}}})
+	require.NoError(t, err)
+	require.Equal(t, []Source{{URL: "https://example.com", Title: "Example", Fields: []string{"dob"}}}, sources)
+
+	for _, source := range []Source{
+		{URL: ""},
+		{URL: "example.com"},
+		{URL: "javascript:alert(1)"},
+		{URL: "https://example.com", Fields: []string{"views"}},
+	} {
+		_, err := normalizeSources([]Source{source})
+		require.ErrorIs(t, err, ErrInvalidSource, source.URL)
+	}
+}
+
+func TestMemoryDAO_Sources(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	_, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderMale, Sources: []Source{{URL: "not a url"}}})
+	require.ErrorIs(t, err, ErrInvalidSource)
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderMale})
+	require.NoError(t, err)
+	require.True(t, human.Unsourced())
+
+	sources := []Source{{URL: "https://example.com/foo", Publisher: "Example", Fields: []string{"description"}}}
+	patched, err := dao.PatchHuman(ctx, human.ID, HumanPatch{Sources: &sources})
+	require.NoError(t, err)
+	require.Equal(t, sources, patched.Sources)
+
+	revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{"sources"}, revisions[0].ChangedFields)
+	changes := DiffHumans(human, patched)
+	require.Equal(t, []FieldChange{{Field: "sources", After: "https://example.com/foo (description)"}}, changes)
+
+	patched.Sources = append(patched.Sources, Source{URL: "ftp://example.com"})
+	_, err = dao.UpdateHuman(ctx, patched)
+	require.ErrorIs(t, err, ErrInvalidSource)
+}
@@ -0,0 +1,70 @@
+package humandao
+
+import "context"
+
+// HumanStore is the storage contract for humans. DAO is the Firestore implementation,
+// and MemoryDAO keeps everything in process so the site can run without emulators.
+type HumanStore interface {
+	Human(ctx context.Context, input HumanInput) (Human, error)
+	HumansByID(ctx context.Context, input HumansByIDInput) ([]Human, error)
+	AddHuman(ctx context.Context, input AddHumanInput) (Human, error)
+	UpdateHuman(ctx context.Context, human Human) (Human, error)
+	PatchHuman(ctx context.Context, id string, patch HumanPatch) (Human, error)
+	PatchHumanFunc(ctx context.Context, id string, build func(current Human) (HumanPatch, error)) (Human, error)
+	PatchDeletedHuman(ctx context.Context, id string, patch HumanPatch) (Human, error)
+	ListHumans(ctx context.Context, input ListHumansInput) ([]Human, string, error)
+	CreatedBy(ctx context.Context, input CreatedByInput) ([]Human, string, error)
+	UserDrafts(ctx context.Context, input UserDraftsInput) ([]Human, string, error)
+	Drafts(ctx context.Context, input DraftsInput) ([]Human, string, error)
+	Publish(ctx context.Context, input PublishInput) error
+	Delete(ctx context.Context, input DeleteInput) error
+	Trash(ctx context.Context, input TrashInput) ([]Human, string, error)
+	Undelete(ctx context.Context, input UndeleteInput) (Human, error)
+	Purge(ctx context.Context, input PurgeInput) error
+	Merge(ctx context.Context, input MergeInput) (Human, error)
+	AddRelationship(ctx context.Context, input AddRelationshipInput) (Relationship, error)
+	RemoveRelationship(ctx context.Context, input RemoveRelationshipInput) error
+	Relationships(ctx context.Context, input RelationshipsInput) ([]Relationship, error)
+	View(ctx context.Context, input ViewInput) error
+	Revisions(ctx context.Context, input RevisionsInput) ([]Revision, string, error)
+	Revision(ctx context.Context, input RevisionInput) (Revision, error)
+	DiffRevisions(ctx context.Context, input DiffRevisionsInput) ([]FieldChange, error)
+	RestoreRevision(ctx context.Context, input RestoreRevisionInput) (Human, error)
+	Snapshots(ctx context.Context) SnapshotIterator
+	Tags(ctx context.Context) (Taxonomy, error)
+	SaveTag(ctx context.Context, input SaveTagInput) (Tag, error)
+	RenameTag(ctx context.Context, input RenameTagInput) (Tag, error)
+	MergeTags(ctx context.Context, input MergeTagsInput) (Tag, error)
+}
+
+var (
+	_ HumanStore = (*DAO)(nil)
+	_ HumanStore = (*MemoryDAO)(nil)
+)
+
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeModified
+	ChangeRemoved
+)
+
+// Change describes a single human that was added, modified or removed.
+type Change struct {
+	Kind  ChangeKind
+	Human Human
+}
+
+// Snapshot is a batch of changes delivered by a SnapshotIterator.
+// The first snapshot contains every existing human as ChangeAdded.
+type Snapshot struct {
+	Changes []Change
+}
+
+// SnapshotIterator is a change feed over the humans collection.
+// Next blocks until there are changes, and returns iterator.Done once Stop is called.
+type SnapshotIterator interface {
+	Next() (Snapshot, error)
+	Stop()
+}
@@ -1,69 +1,580 @@
 package humandao
 
-import "sort"
-
-// AllTags is a list of allowed tags for a human.
-var AllTags = []string{
-	"academic",
-	"activist",
-	"actor",
-	"artist",
-	"athlete",
-	"beauty",
-	"chef",
-	"comedian",
-	"content creator",
-	"dancer",
-	"designer",
-	"director",
-	"doctor",
-	"engineer",
-	"entertainer",
-	"entrepreneur",
-	"executive",
-	"fashion",
-	"filmmaker",
-	"fitness",
-	"founder",
-	"influencer",
-	"journalist",
-	"lawyer",
-	"martial artist",
-	"model",
-	"musician",
-	"olympian",
-	"philanthropist",
-	"photographer",
-	"pilot",
-	"playwright",
-	"politician",
-	"producer",
-	"professor",
-	"rapper",
-	"scientist",
-	"screenwriter",
-	"singer",
-	"songwriter",
-	"writer",
-}
-
-var allTagsMap map[string]struct{}
-
-func init() {
-	allTagsMap = make(map[string]struct{}, len(AllTags))
-	for _, t := range AllTags {
-		allTagsMap[t] = struct{}{}
-	}
-}
-
-// IsValidTag returns true if the tag is in the allowed list.
-func IsValidTag(tag string) bool {
-	_, ok := allTagsMap[tag]
-	return ok
-}
-
-// GetTags returns a sorted list of all allowed tags.
-func GetTags() []string {
-	sort.Strings(AllTags)
-	return AllTags
-}
\ No newline at end of file
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/firestore"
+)
+
+var (
+	ErrInvalidTag  = errors.New("invalid tag")
+	ErrTagNotFound = errors.New("tag not found")
+	ErrTagExists   = errors.New("tag already exists")
+)
+
+// Tag is what a human is known for, like "actor" or "rapper". The tags humans can be given are
+// managed by admins, and stored in their own collection.
+type Tag struct {
+	Name string `firestore:"name"`
+	// Parent is a broader tag the tag is a kind of, e.g. "musician" for "rapper".
+	Parent string `firestore:"parent,omitempty"`
+	// Synonyms are other ways the tag is written, which are stored as Name instead.
+	Synonyms    []string  `firestore:"synonyms,omitempty"`
+	Description string    `firestore:"description,omitempty"`
+	UpdatedAt   time.Time `firestore:"updated_at,omitempty"`
+	UpdatedBy   string    `firestore:"updated_by,omitempty"`
+}
+
+// Taxonomy is every tag humans can be given, sorted by name.
+type Taxonomy []Tag
+
+// DefaultTags is the taxonomy until admins change it, when it is stored for the first time.
+var DefaultTags = Taxonomy{
+	{Name: "academic", Description: "Researchers and teachers at universities."},
+	{Name: "activist", Synonyms: []string{"activism"}, Description: "Campaigners for social or political change."},
+	{Name: "actor", Parent: "entertainer", Synonyms: []string{"actress", "voice actor"}, Description: "Performers in film, television, theater or voice work."},
+	{Name: "artist", Synonyms: []string{"illustrator", "animator"}, Description: "Painters, illustrators, animators and other visual artists."},
+	{Name: "athlete", Description: "Professional and amateur sportspeople."},
+	{Name: "beauty", Description: "Makeup artists, skincare experts and beauty brands."},
+	{Name: "chef", Description: "Cooks, restaurateurs and food personalities."},
+	{Name: "comedian", Parent: "entertainer", Synonyms: []string{"comedy"}, Description: "Stand-up, sketch and comedic performers."},
+	{Name: "content creator", Synonyms: []string{"youtuber", "youtube", "podcaster"}, Description: "People who publish videos, podcasts or other content online."},
+	{Name: "dancer", Parent: "entertainer", Description: "Dancers and choreographers."},
+	{Name: "designer", Synonyms: []string{"stylist"}, Description: "Designers of fashion, products, graphics or spaces."},
+	{Name: "director", Parent: "filmmaker", Description: "Directors of film, television or theater."},
+	{Name: "doctor", Synonyms: []string{"physician"}, Description: "Physicians and surgeons."},
+	{Name: "engineer", Description: "Engineers of any discipline."},
+	{Name: "entertainer", Synonyms: []string{"entertainment"}, Description: "People who perform for an audience."},
+	{Name: "entrepreneur", Description: "People who started their own business."},
+	{Name: "executive", Synonyms: []string{"business"}, Description: "Leaders of companies and organizations."},
+	{Name: "fashion", Description: "People who work in the fashion industry."},
+	{Name: "filmmaker", Description: "People who make films."},
+	{Name: "fitness", Description: "Trainers, coaches and fitness personalities."},
+	{Name: "founder", Parent: "entrepreneur", Description: "Founders of companies or organizations."},
+	{Name: "influencer", Synonyms: []string{"social media"}, Description: "People with a large following on social media."},
+	{Name: "journalist", Description: "Reporters, editors and news anchors."},
+	{Name: "lawyer", Description: "Attorneys and judges."},
+	{Name: "lgbtq", Synonyms: []string{"lgbt"}, Description: "Members of the LGBTQ community."},
+	{Name: "martial artist", Parent: "athlete", Description: "Practitioners of martial arts."},
+	{Name: "model", Parent: "fashion", Description: "Fashion and commercial models."},
+	{Name: "musician", Synonyms: []string{"music"}, Description: "People who make music."},
+	{Name: "olympian", Parent: "athlete", Synonyms: []string{"olympics"}, Description: "Athletes who competed in the Olympic Games."},
+	{Name: "philanthropist", Description: "People known for giving to charitable causes."},
+	{Name: "photographer", Parent: "artist", Synonyms: []string{"photography"}, Description: "Photographers of any kind."},
+	{Name: "pilot", Description: "Pilots and astronauts."},
+	{Name: "playwright", Parent: "writer", Description: "Writers of plays."},
+	{Name: "politician", Description: "Elected officials and political candidates."},
+	{Name: "producer", Description: "Producers of film, television or music."},
+	{Name: "professor", Parent: "academic", Description: "Professors at universities."},
+	{Name: "rapper", Parent: "musician", Description: "Hip hop artists."},
+	{Name: "scientist", Description: "Scientists and researchers."},
+	{Name: "screenwriter", Parent: "writer", Description: "Writers of film and television."},
+	{Name: "singer", Parent: "musician", Description: "Vocalists of any genre."},
+	{Name: "songwriter", Parent: "musician", Description: "Writers of songs."},
+	{Name: "technology", Synonyms: []string{"tech", "technologist", "software engineer"}, Description: "People who build or lead technology companies and products."},
+	{Name: "writer", Synonyms: []string{"author"}, Description: "Authors of books, essays and other writing."},
+}
+
+// tagKey is how tags are compared: case and extra spaces don't matter.
+func tagKey(s string) string {
+	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
+}
+
+// Get finds a tag by its name or one of its synonyms.
+func (t Taxonomy) Get(name string) (Tag, bool) {
+	k := tagKey(name)
+	for _, tag := range t {
+		if tag.Name == k || slices.Contains(tag.Synonyms, k) {
+			return tag, true
+		}
+	}
+	return Tag{}, false
+}
+
+// Names returns the name of every tag.
+func (t Taxonomy) Names() []string {
+	names := make([]string, 0, len(t))
+	for _, tag := range t {
+		names = append(names, tag.Name)
+	}
+	return names
+}
+
+// Ancestors returns the tags the tag is a kind of, from its parent up.
+func (t Taxonomy) Ancestors(name string) []Tag {
+	var ancestors []Tag
+	tag, ok := t.Get(name)
+	for ok && tag.Parent != "" && len(ancestors) < len(t) {
+		tag, ok = t.Get(tag.Parent)
+		if ok {
+			ancestors = append(ancestors, tag)
+		}
+	}
+	return ancestors
+}
+
+// Children returns the tags whose parent is the tag.
+func (t Taxonomy) Children(name string) []Tag {
+	parent, ok := t.Get(name)
+	if !ok {
+		return nil
+	}
+	var children []Tag
+	for _, tag := range t {
+		if tag.Parent == parent.Name {
+			children = append(children, tag)
+		}
+	}
+	return children
+}
+
+// Is reports whether the tag is the group, or a kind of it, so "rapper" is "musician". Tags that
+// aren't in the taxonomy are compared as they are written.
+func (t Taxonomy) Is(name, group string) bool {
+	tag, ok := t.Get(name)
+	g, gok := t.Get(group)
+	if !ok || !gok {
+		return tagKey(name) == tagKey(group)
+	}
+	if tag.Name == g.Name {
+		return true
+	}
+	return slices.ContainsFunc(t.Ancestors(tag.Name), func(a Tag) bool { return a.Name == g.Name })
+}
+
+// Clean splits comma separated tags, replaces synonyms with the tag they are of and drops
+// duplicates. The tags that aren't in the taxonomy are kept, lowercased, and also returned as
+// unknown.
+func (t Taxonomy) Clean(tags []string) (cleaned, unknown []string) {
+	for _, value := range tags {
+		for _, part := range strings.Split(value, ",") {
+			name := tagKey(part)
+			if name == "" {
+				continue
+			}
+			if tag, ok := t.Get(name); ok {
+				name = tag.Name
+			} else if !slices.Contains(unknown, name) {
+				unknown = append(unknown, name)
+			}
+			if !slices.Contains(cleaned, name) {
+				cleaned = append(cleaned, name)
+			}
+		}
+	}
+	return cleaned, unknown
+}
+
+// Normalize cleans the tags, and returns an error if any of them isn't in the taxonomy.
+func (t Taxonomy) Normalize(tags []string) ([]string, error) {
+	return t.normalizeKeeping(tags, nil)
+}
+
+// normalizeKeeping is Normalize, but allows the tags a human already has, so tags that were
+// removed from the taxonomy don't stop the human from being edited before they are cleaned up.
+func (t Taxonomy) normalizeKeeping(tags, existing []string) ([]string, error) {
+	if len(tags) == 0 {
+		return tags, nil
+	}
+	cleaned, unknown := t.Clean(tags)
+	for _, name := range unknown {
+		if !slices.ContainsFunc(existing, func(e string) bool { return tagKey(e) == name }) {
+			return nil, fmt.Errorf("%w: %q is not a tag, add it to the tags first", ErrInvalidTag, name)
+		}
+	}
+	return cleaned, nil
+}
+
+// Known cleans the tags and leaves out the ones that aren't in the taxonomy, for tags suggested by
+// xAI or found elsewhere that can't be fixed by hand.
+func (t Taxonomy) Known(tags []string) []string {
+	cleaned, unknown := t.Clean(tags)
+	return slices.DeleteFunc(cleaned, func(name string) bool { return slices.Contains(unknown, name) })
+}
+
+// ByTags keeps the humans with any of the tags, or a kind of them, so "musician" keeps rappers too.
+func (t Taxonomy) ByTags(tags ...string) FilterOpt {
+	return func(f Filterable) Filterable {
+		filtered := make([]Human, 0, len(f))
+		for _, human := range f {
+			if slices.ContainsFunc(human.Tags, func(tag string) bool {
+				return slices.ContainsFunc(tags, func(group string) bool { return t.Is(tag, group) })
+			}) {
+				filtered = append(filtered, human)
+			}
+		}
+		return filtered
+	}
+}
+
+// normalizeTag cleans up the tag and checks that it fits in the taxonomy it is saved to. It takes
+// the place of the tag called replacing, or of the tag with its name if replacing is empty.
+func (t Taxonomy) normalizeTag(tag Tag, replacing string) (Tag, error) {
+	tag.Name = tagKey(tag.Name)
+	tag.Parent = tagKey(tag.Parent)
+	tag.Description = strings.TrimSpace(tag.Description)
+	if tag.Name == "" {
+		return Tag{}, fmt.Errorf("%w: name must be provided", ErrInvalidTag)
+	}
+	if strings.Contains(tag.Name, ",") {
+		return Tag{}, fmt.Errorf("%w: %q can't contain a comma", ErrInvalidTag, tag.Name)
+	}
+
+	if replacing == "" {
+		replacing = tag.Name
+	}
+	others := slices.DeleteFunc(slices.Clone(t), func(other Tag) bool { return other.Name == replacing })
+	var synonyms []string
+	for _, synonym := range tag.Synonyms {
+		synonym = tagKey(synonym)
+		if synonym == "" || synonym == tag.Name || slices.Contains(synonyms, synonym) {
+			continue
+		}
+		synonyms = append(synonyms, synonym)
+	}
+	tag.Synonyms = synonyms
+	for _, name := range append([]string{tag.Name}, tag.Synonyms...) {
+		if other, ok := others.Get(name); ok {
+			return Tag{}, fmt.Errorf("%w: %q is already %v", ErrTagExists, name, other.Name)
+		}
+	}
+
+	if tag.Parent != "" {
+		parent, ok := others.Get(tag.Parent)
+		if !ok {
+			return Tag{}, fmt.Errorf("%w: parent %q is not a tag", ErrInvalidTag, tag.Parent)
+		}
+		tag.Parent = parent.Name
+		// the tag can't be a parent of itself, through any of its parents
+		if slices.ContainsFunc(append(others, tag).Ancestors(parent.Name), func(a Tag) bool { return a.Name == tag.Name }) {
+			return Tag{}, fmt.Errorf("%w: %v can't be a kind of %v, which is a kind of it", ErrInvalidTag, tag.Name, parent.Name)
+		}
+	}
+	return tag, nil
+}
+
+// withTag returns the taxonomy with the tag added, or replacing the one it is named after.
+func (t Taxonomy) withTag(tag Tag) Taxonomy {
+	next := slices.DeleteFunc(slices.Clone(t), func(other Tag) bool { return other.Name == tag.Name })
+	next = append(next, tag)
+	slices.SortFunc(next, func(a, b Tag) int { return strings.Compare(a.Name, b.Name) })
+	return next
+}
+
+// saveTag returns the taxonomy with the tag created, or updated if it already exists.
+func (t Taxonomy) saveTag(tag Tag) (Taxonomy, Tag, error) {
+	tag, err := t.normalizeTag(tag, "")
+	if err != nil {
+		return nil, Tag{}, err
+	}
+	return t.withTag(tag), tag, nil
+}
+
+// mergeTag returns the taxonomy with from merged into into: from and its synonyms become synonyms
+// of into, and the tags that were a kind of from are now a kind of into.
+func (t Taxonomy) mergeTag(from, into string) (Taxonomy, Tag, error) {
+	fromTag, ok := t.Get(from)
+	if !ok {
+		return nil, Tag{}, fmt.Errorf("%w: %v", ErrTagNotFound, from)
+	}
+	intoTag, ok := t.Get(into)
+	if !ok {
+		return nil, Tag{}, fmt.Errorf("%w: %v", ErrTagNotFound, into)
+	}
+	if fromTag.Name == intoTag.Name {
+		if tagKey(from) != fromTag.Name {
+			// from is already a synonym of into, so it was merged before
+			return t, intoTag, nil
+		}
+		return nil, Tag{}, fmt.Errorf("%w: can't merge %v into itself", ErrInvalidTag, fromTag.Name)
+	}
+	// the tags that were a kind of from would become a kind of into, and one of them is a parent of into
+	if t.Is(intoTag.Name, fromTag.Name) && intoTag.Parent != fromTag.Name {
+		return nil, Tag{}, fmt.Errorf("%w: can't merge %v into %v, merge it into %v first", ErrInvalidTag, fromTag.Name, intoTag.Name, intoTag.Parent)
+	}
+
+	next := make(Taxonomy, 0, len(t)-1)
+	for _, tag := range t {
+		if tag.Name == fromTag.Name {
+			continue
+		}
+		if tag.Parent == fromTag.Name {
+			tag.Parent = fromTag.Parent
+			if tag.Name != intoTag.Name {
+				tag.Parent = intoTag.Name
+			}
+		}
+		if tag.Name == intoTag.Name {
+			for _, synonym := range append([]string{fromTag.Name}, fromTag.Synonyms...) {
+				if !slices.Contains(tag.Synonyms, synonym) {
+					tag.Synonyms = append(slices.Clone(tag.Synonyms), synonym)
+				}
+			}
+			if tag.Description == "" {
+				tag.Description = fromTag.Description
+			}
+			intoTag = tag
+		}
+		next = append(next, tag)
+	}
+	return next, intoTag, nil
+}
+
+// renameTag returns the taxonomy with from renamed to, and its old name kept as a synonym.
+func (t Taxonomy) renameTag(from, to string) (Taxonomy, Tag, error) {
+	fromTag, ok := t.Get(from)
+	if !ok {
+		return nil, Tag{}, fmt.Errorf("%w: %v", ErrTagNotFound, from)
+	}
+	if fromTag.Name == tagKey(to) && tagKey(from) != fromTag.Name {
+		// from is already a synonym of to, so it was renamed before
+		return t, fromTag, nil
+	}
+	renamed := fromTag
+	renamed.Name = to
+	renamed.Synonyms = append(slices.Clone(fromTag.Synonyms), fromTag.Name)
+	renamed, err := t.normalizeTag(renamed, fromTag.Name)
+	if err != nil {
+		return nil, Tag{}, err
+	}
+	if renamed.Name == fromTag.Name {
+		return nil, Tag{}, fmt.Errorf("%w: %v is already called that", ErrInvalidTag, fromTag.Name)
+	}
+
+	next := make(Taxonomy, 0, len(t))
+	for _, tag := range t {
+		if tag.Name == fromTag.Name {
+			continue
+		}
+		if tag.Parent == fromTag.Name {
+			tag.Parent = renamed.Name
+		}
+		next = append(next, tag)
+	}
+	return next.withTag(renamed), renamed, nil
+}
+
+// retag returns the tags with from replaced by into, and whether from was one of them.
+func retag(tags []string, from, into string) ([]string, bool) {
+	if !slices.Contains(tags, from) {
+		return tags, false
+	}
+	var retagged []string
+	for _, tag := range tags {
+		if tag == from {
+			tag = into
+		}
+		if !slices.Contains(retagged, tag) {
+			retagged = append(retagged, tag)
+		}
+	}
+	return retagged, true
+}
+
+type SaveTagInput struct {
+	Tag Tag
+}
+
+type RenameTagInput struct {
+	From string
+	To   string
+}
+
+type MergeTagsInput struct {
+	From string
+	Into string
+}
+
+// changeTags applies the change to the taxonomy, and marks the tag it changed as changed by the
+// author of ctx.
+func changeTags(ctx context.Context, taxonomy Taxonomy, change func(Taxonomy) (Taxonomy, Tag, error)) (Taxonomy, Tag, error) {
+	next, tag, err := change(taxonomy)
+	if err != nil {
+		return nil, Tag{}, err
+	}
+	tag.UpdatedAt = time.Now()
+	tag.UpdatedBy = authorFromContext(ctx, "")
+	return next.withTag(tag), tag, nil
+}
+
+// humanPatcher patches humans whether or not they are in the trash, so tags are changed on the
+// humans in it as well.
+type humanPatcher interface {
+	patchHuman(ctx context.Context, id string, build func(current Human) (HumanPatch, error), includeDeleted bool) (Human, error)
+}
+
+// retagSynonyms gives the tag to every human with one of its synonyms instead. The humans are looked
+// up by every synonym, not only by the name the tag had before it was changed, so when it fails
+// partway, changing the tag again retags the humans it missed.
+func retagSynonyms(ctx context.Context, store humanPatcher, tag Tag, tagged func(synonym string) ([]Human, error)) error {
+	for _, synonym := range tag.Synonyms {
+		humans, err := tagged(synonym)
+		if err == nil {
+			err = retagHumans(ctx, store, humans, synonym, tag.Name)
+		}
+		if err != nil {
+			return fmt.Errorf("tag %v was saved, but not every human was retagged, try again to retag the rest: %w", tag.Name, err)
+		}
+	}
+	return nil
+}
+
+// retagHumans replaces from with into on every one of the humans that has it.
+func retagHumans(ctx context.Context, store humanPatcher, humans []Human, from, into string) error {
+	for _, human := range humans {
+		tags, ok := retag(human.Tags, from, into)
+		if !ok {
+			continue
+		}
+		if _, err := store.patchHuman(ctx, human.ID, staticPatch(HumanPatch{Tags: &tags}), true); err != nil {
+			return fmt.Errorf("unable to retag %v (%v): %w", human.Name, human.ID, err)
+		}
+	}
+	return nil
+}
+
+func (d *DAO) tags() *firestore.CollectionRef {
+	return d.client.Collection(d.tagCollection)
+}
+
+// tagID is the document ID of a tag.
+func tagID(name string) string {
+	return Slug(name)
+}
+
+func convertTagDocs(docs []*firestore.DocumentSnapshot) (Taxonomy, error) {
+	if len(docs) == 0 {
+		return slices.Clone(DefaultTags), nil
+	}
+	taxonomy := make(Taxonomy, 0, len(docs))
+	for _, doc := range docs {
+		var tag Tag
+		if err := doc.DataTo(&tag); err != nil {
+			return nil, fmt.Errorf("unable to convert tag %v: %w", doc.Ref.ID, err)
+		}
+		taxonomy = append(taxonomy, tag)
+	}
+	slices.SortFunc(taxonomy, func(a, b Tag) int { return strings.Compare(a.Name, b.Name) })
+	return taxonomy, nil
+}
+
+// Tags returns the taxonomy. It is DefaultTags until it is changed for the first time.
+func (d *DAO) Tags(ctx context.Context) (Taxonomy, error) {
+	docs, err := d.tags().Documents(ctx).GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get tags: %w", err)
+	}
+	return convertTagDocs(docs)
+}
+
+// taxonomyFor returns the taxonomy to check the tags against, without reading it when there are none.
+func (d *DAO) taxonomyFor(ctx context.Context, tags []string) (Taxonomy, error) {
+	if len(tags) == 0 {
+		return nil, nil
+	}
+	return d.Tags(ctx)
+}
+
+// updateTags changes the taxonomy in a transaction. All of it is written, so the default tags are
+// stored the first time it is changed.
+func (d *DAO) updateTags(ctx context.Context, change func(Taxonomy) (Taxonomy, Tag, error)) (Tag, error) {
+	var changed Tag
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs, err := tx.Documents(d.tags()).GetAll()
+		if err != nil {
+			return err
+		}
+		taxonomy, err := convertTagDocs(docs)
+		if err != nil {
+			return err
+		}
+		next, tag, err := changeTags(ctx, taxonomy, change)
+		if err != nil {
+			return err
+		}
+
+		kept := make(map[string]bool, len(next))
+		for _, tag := range next {
+			kept[tagID(tag.Name)] = true
+		}
+		for _, doc := range docs {
+			if !kept[doc.Ref.ID] {
+				if err := tx.Delete(doc.Ref); err != nil {
+					return err
+				}
+			}
+		}
+		for _, tag := range next {
+			if err := tx.Set(d.tags().Doc(tagID(tag.Name)), tag); err != nil {
+				return err
+			}
+		}
+
+		changed = tag
+		return nil
+	})
+	if err != nil {
+		return Tag{}, fmt.Errorf("unable to update tags: %w", err)
+	}
+	return changed, nil
+}
+
+// humansTagged returns a func that gets every human with a tag, including the ones in the trash.
+func (d *DAO) humansTagged(ctx context.Context) func(tag string) ([]Human, error) {
+	return func(tag string) ([]Human, error) {
+		docs, err := d.client.Collection(d.humanCollection).Where("tags", "array-contains", tag).Documents(ctx).GetAll()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get humans tagged %v: %w", tag, err)
+		}
+		humans := make([]Human, 0, len(docs))
+		for _, doc := range docs {
+			human, err := convertHumanDoc(doc)
+			if err != nil {
+				return nil, err
+			}
+			humans = append(humans, human)
+		}
+		return humans, nil
+	}
+}
+
+// SaveTag creates the tag, or updates the tag with its name. Humans given one of its synonyms
+// before it was one are given the tag instead.
+func (d *DAO) SaveTag(ctx context.Context, input SaveTagInput) (Tag, error) {
+	saved, err := d.updateTags(ctx, func(t Taxonomy) (Taxonomy, Tag, error) {
+		return t.saveTag(input.Tag)
+	})
+	if err != nil {
+		return Tag{}, err
+	}
+	return saved, retagSynonyms(ctx, d, saved, d.humansTagged(ctx))
+}
+
+// RenameTag renames the tag on every human that has it. The old name is kept as a synonym, so
+// renaming it again finishes a rename that failed to retag every human.
+func (d *DAO) RenameTag(ctx context.Context, input RenameTagInput) (Tag, error) {
+	renamed, err := d.updateTags(ctx, func(t Taxonomy) (Taxonomy, Tag, error) {
+		return t.renameTag(input.From, input.To)
+	})
+	if err != nil {
+		return Tag{}, err
+	}
+	return renamed, retagSynonyms(ctx, d, renamed, d.humansTagged(ctx))
+}
+
+// MergeTags merges the tag from into the tag into, on every human and in the taxonomy, where from
+// becomes a synonym of into. Merging it again finishes a merge that failed to retag every human.
+func (d *DAO) MergeTags(ctx context.Context, input MergeTagsInput) (Tag, error) {
+	merged, err := d.updateTags(ctx, func(t Taxonomy) (Taxonomy, Tag, error) {
+		return t.mergeTag(input.From, input.Into)
+	})
+	if err != nil {
+		return Tag{}, err
+	}
+	return merged, retagSynonyms(ctx, d, merged, d.humansTagged(ctx))
+}
@@ -0,0 +1,173 @@
+package humandao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefaultTags(t *testing.T) {
+	for i, tag := range DefaultTags {
+		normalized, err := DefaultTags.normalizeTag(tag, "")
+		require.NoError(t, err, tag.Name)
+		require.Equal(t, tag, normalized)
+		if i > 0 {
+			require.Less(t, DefaultTags[i-1].Name, tag.Name)
+		}
+	}
+}
+
+func TestTaxonomy_Clean(t *testing.T) {
+	cleaned, unknown := DefaultTags.Clean([]string{"Actress", "actor, Comedy", " YouTuber ", "ballerina", ""})
+	require.Equal(t, []string{"actor", "comedian", "content creator", "ballerina"}, cleaned)
+	require.Equal(t, []string{"ballerina"}, unknown)
+
+	require.Equal(t, []string{"actor", "comedian", "content creator"}, DefaultTags.Known([]string{"Actress", "actor, Comedy", " YouTuber ", "ballerina"}))
+
+	_, err := DefaultTags.Normalize([]string{"actor", "ballerina"})
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	tags, err := DefaultTags.normalizeKeeping([]string{"Author", "Ballerina"}, []string{"ballerina"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"writer", "ballerina"}, tags)
+}
+
+func TestTaxonomy_Is(t *testing.T) {
+	require.True(t, DefaultTags.Is("rapper", "musician"))
+	require.True(t, DefaultTags.Is("director", "filmmaker"))
+	require.True(t, DefaultTags.Is("music", "musician"))
+	require.False(t, DefaultTags.Is("musician", "rapper"))
+	require.False(t, DefaultTags.Is("actor", "musician"))
+
+	f := Filterable{
+		{Name: "Rapper", Tags: []string{"rapper"}},
+		{Name: "Singer", Tags: []string{"singer", "actor"}},
+		{Name: "Actor", Tags: []string{"actor"}},
+	}
+	require.Equal(t, Filterable{f[0], f[1]}, DefaultTags.ByTags("musician")(f))
+	require.Equal(t, Filterable{f[1], f[2]}, DefaultTags.ByTags("entertainer")(f))
+}
+
+func TestTaxonomy_SaveTag(t *testing.T) {
+	taxonomy, tag, err := DefaultTags.saveTag(Tag{Name: " K-Pop Idol ", Parent: "Singer", Synonyms: []string{"idol", "Idol", "k-pop idol"}})
+	require.NoError(t, err)
+	require.Equal(t, Tag{Name: "k-pop idol", Parent: "singer", Synonyms: []string{"idol"}}, tag)
+	require.True(t, taxonomy.Is("idol", "musician"))
+	require.Len(t, taxonomy, len(DefaultTags)+1)
+
+	// updating a tag keeps its name
+	taxonomy, tag, err = taxonomy.saveTag(Tag{Name: "k-pop idol", Parent: "singer", Description: "Members of K-pop groups."})
+	require.NoError(t, err)
+	require.Equal(t, "Members of K-pop groups.", tag.Description)
+	require.Len(t, taxonomy, len(DefaultTags)+1)
+
+	for name, tag := range map[string]Tag{
+		"no-name":        {Parent: "singer"},
+		"unknown-parent": {Name: "idol", Parent: "k-pop"},
+		"cycle":          {Name: "musician", Parent: "rapper"},
+		"comma":          {Name: "singer, songwriter"},
+	} {
+		_, _, err := taxonomy.saveTag(tag)
+		require.ErrorIs(t, err, ErrInvalidTag, name)
+	}
+	_, _, err = taxonomy.saveTag(Tag{Name: "vocalist", Synonyms: []string{"actress"}})
+	require.ErrorIs(t, err, ErrTagExists)
+}
+
+func TestTaxonomy_MergeAndRename(t *testing.T) {
+	renamedTaxonomy, renamed, err := DefaultTags.renameTag("musician", "music artist")
+	require.NoError(t, err)
+	require.Equal(t, []string{"music", "musician"}, renamed.Synonyms)
+	require.True(t, renamedTaxonomy.Is("rapper", "music artist"))
+	_, ok := renamedTaxonomy.Get("musician")
+	require.True(t, ok)
+
+	_, _, err = DefaultTags.renameTag("actor", "comedian")
+	require.ErrorIs(t, err, ErrTagExists)
+	_, _, err = DefaultTags.renameTag("ballerina", "dancer")
+	require.ErrorIs(t, err, ErrTagNotFound)
+
+	taxonomy, merged, err := DefaultTags.mergeTag("songwriter", "singer")
+	require.NoError(t, err)
+	require.Equal(t, "singer", merged.Name)
+	require.Equal(t, []string{"songwriter"}, merged.Synonyms)
+	require.Len(t, taxonomy, len(DefaultTags)-1)
+
+	// the tags that were a kind of the merged tag are a kind of the tag it was merged into
+	taxonomy, _, err = DefaultTags.mergeTag("entertainer", "actor")
+	require.NoError(t, err)
+	require.True(t, taxonomy.Is("comedian", "actor"))
+	actor, _ := taxonomy.Get("actor")
+	require.Equal(t, "", actor.Parent)
+
+	_, _, err = DefaultTags.mergeTag("singer", "singer")
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	// renaming or merging again doesn't change anything, so a retry can retag the humans left over
+	again, tag, err := taxonomy.mergeTag("entertainer", "actor")
+	require.NoError(t, err)
+	require.Equal(t, taxonomy, again)
+	require.Equal(t, "actor", tag.Name)
+	again, tag, err = renamedTaxonomy.renameTag("musician", "music artist")
+	require.NoError(t, err)
+	require.Equal(t, renamedTaxonomy, again)
+	require.Equal(t, "music artist", tag.Name)
+}
+
+func TestMemoryDAO_Tags(t *testing.T) {
+	ctx := WithAuthor(context.Background(), "admin")
+	dao := NewMemoryDAO(Human{ID: "legacy", Name: "Legacy", Gender: GenderFemale, Tags: []string{"ballerina", "actor"}})
+
+	_, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderMale, Tags: []string{"ballerina"}})
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo", Gender: GenderMale, Tags: []string{"Actress", "author"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"actor", "writer"}, human.Tags)
+
+	// tags the human already has don't stop it from being edited, but new ones have to be known
+	legacy, err := dao.PatchHuman(ctx, "legacy", HumanPatch{Tags: &[]string{"ballerina", "comedy"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ballerina", "comedian"}, legacy.Tags)
+	legacy.Tags = append(legacy.Tags, "juggler")
+	_, err = dao.UpdateHuman(ctx, legacy)
+	require.ErrorIs(t, err, ErrInvalidTag)
+
+	tag, err := dao.SaveTag(ctx, SaveTagInput{Tag: Tag{Name: "dancer", Parent: "entertainer", Synonyms: []string{"ballerina"}}})
+	require.NoError(t, err)
+	require.Equal(t, "admin", tag.UpdatedBy)
+	require.False(t, tag.UpdatedAt.IsZero())
+	legacy, err = dao.Human(ctx, HumanInput{HumanID: "legacy"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"dancer", "comedian"}, legacy.Tags)
+
+	_, err = dao.MergeTags(ctx, MergeTagsInput{From: "actor", Into: "comedian"})
+	require.NoError(t, err)
+	human, err = dao.Human(ctx, HumanInput{HumanID: human.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{"comedian", "writer"}, human.Tags)
+
+	renamed, err := dao.RenameTag(ctx, RenameTagInput{From: "writer", To: "author"})
+	require.NoError(t, err)
+	require.Equal(t, "author", renamed.Name)
+	human, err = dao.Human(ctx, HumanInput{HumanID: human.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{"comedian", "author"}, human.Tags)
+
+	// a human the rename didn't get to is retagged when it is renamed again
+	dao.humans["straggler"] = Human{ID: "straggler", Name: "Straggler", Tags: []string{"writer"}}
+	_, err = dao.RenameTag(ctx, RenameTagInput{From: "writer", To: "author"})
+	require.NoError(t, err)
+	straggler, err := dao.Human(ctx, HumanInput{HumanID: "straggler"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"author"}, straggler.Tags)
+
+	taxonomy, err := dao.Tags(ctx)
+	require.NoError(t, err)
+	_, ok := taxonomy.Get("actor")
+	require.True(t, ok)
+	_, ok = taxonomy.Get("writer")
+	require.True(t, ok)
+	require.Len(t, taxonomy, len(DefaultTags)-1)
+}
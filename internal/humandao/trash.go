@@ -0,0 +1,206 @@
+package humandao
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"cloud.google.com/go/firestore"
+	"google.golang.org/api/iterator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// collectHumans runs query and returns the humans that aren't in the trash, stopping after limit.
+// Older documents don't have a deleted_at field at all, so deleted humans can't be filtered out by
+// Firestore and are skipped here instead. A zero limit returns all of them.
+func collectHumans(ctx context.Context, query firestore.Query, limit int) ([]Human, error) {
+	it := query.Documents(ctx)
+	defer it.Stop()
+
+	var humans []Human
+	for limit <= 0 || len(humans) < limit {
+		doc, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		human, err := convertHumanDoc(doc)
+		if err != nil {
+			return nil, err
+		}
+		if human.Deleted() {
+			continue
+		}
+		humans = append(humans, human)
+	}
+
+	return humans, nil
+}
+
+type TrashInput struct {
+	Limit int
+	// PageToken is the next page token returned by the previous call with the same input.
+	PageToken string
+}
+
+// Trash returns a page of the deleted humans, most recently deleted first, along with the token for
+// the page after it. A zero Limit returns all of them.
+func (d *DAO) Trash(ctx context.Context, input TrashInput) ([]Human, string, error) {
+	query := d.client.Collection(d.humanCollection).
+		Where("deleted_at", ">", time.Time{})
+	humans, next, err := listPage(ctx, query, orderByDeletedAt, firestore.Desc, input.PageToken, input.Limit, collectTrash, func(last Human) string {
+		return newPageToken(orderByDeletedAt, last)
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to get deleted humans: %w", err)
+	}
+
+	return humans, next, nil
+}
+
+// collectTrash runs query, which only returns humans in the trash, stopping after limit.
+func collectTrash(ctx context.Context, query firestore.Query, limit int) ([]Human, error) {
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	docs, err := query.Documents(ctx).GetAll()
+	if err != nil {
+		return nil, err
+	}
+	return convertHumansDocs(docs)
+}
+
+type UndeleteInput struct {
+	HumanID string
+	UserID  string
+}
+
+// Undelete takes a human back out of the trash.
+func (d *DAO) Undelete(ctx context.Context, input UndeleteInput) (Human, error) {
+	var restored Human
+	ref := d.client.Collection(d.humanCollection).Doc(input.HumanID)
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		previous, err := convertHumanDoc(doc)
+		if err != nil {
+			return err
+		}
+		if !previous.Deleted() {
+			restored = previous
+			return nil
+		}
+		if previous.MergedInto != "" {
+			return fmt.Errorf("%w: %v", ErrHumanMerged, previous.MergedInto)
+		}
+		if err := d.checkPathAvailable(tx, input.HumanID, previous.Path); err != nil {
+			return err
+		}
+
+		err = tx.Update(ref, []firestore.Update{
+			{Path: "deleted_at", Value: firestore.Delete},
+			{Path: "deleted_by", Value: firestore.Delete},
+			{Path: "version", Value: firestore.Increment(1)},
+		})
+		if err != nil {
+			return err
+		}
+
+		next := cloneHuman(previous)
+		next.DeletedAt = time.Time{}
+		next.DeletedBy = ""
+		next.Version++
+		revision := newRevision(ctx, RevisionActionUndelete, input.UserID, previous, next)
+		if err := tx.Create(d.revisions(input.HumanID).Doc(revision.ID), revision); err != nil {
+			return err
+		}
+
+		restored = next
+		return nil
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return Human{}, fmt.Errorf("%w: %v", ErrHumanNotFound, input.HumanID)
+		}
+		return Human{}, fmt.Errorf("unable to undelete human: %v: %w", input.HumanID, err)
+	}
+
+	return restored, nil
+}
+
+type PurgeInput struct {
+	HumanID string
+}
+
+// Purge permanently removes a deleted human along with its revisions and relationships. Only humans
+// in the trash can be purged. Images are stored outside of Firestore and have to be removed by the caller.
+func (d *DAO) Purge(ctx context.Context, input PurgeInput) error {
+	ref := d.client.Collection(d.humanCollection).Doc(input.HumanID)
+	err := d.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(ref)
+		if err != nil {
+			return err
+		}
+		human, err := convertHumanDoc(doc)
+		if err != nil {
+			return err
+		}
+		if !human.Deleted() {
+			return fmt.Errorf("%w: %v", ErrHumanNotDeleted, input.HumanID)
+		}
+		return tx.Delete(ref)
+	})
+	missing := status.Code(err) == codes.NotFound
+	if err != nil && !missing {
+		return fmt.Errorf("unable to purge human: %v: %w", input.HumanID, err)
+	}
+
+	// A human can have more revisions than a transaction can write, so they are deleted once the
+	// human is gone. Purging again cleans up after a purge that failed part way through.
+	deleted, err := d.deleteAll(ctx,
+		d.revisions(input.HumanID).Select(),
+		d.relationships().Where("humans", "array-contains", input.HumanID).Select(),
+	)
+	if err != nil {
+		return fmt.Errorf("unable to purge human: %v: %w", input.HumanID, err)
+	}
+	if missing && deleted == 0 {
+		return fmt.Errorf("%w: %v", ErrHumanNotFound, input.HumanID)
+	}
+
+	return nil
+}
+
+// deleteAll deletes every document the queries return, and returns how many there were.
+func (d *DAO) deleteAll(ctx context.Context, queries ...firestore.Query) (int, error) {
+	bw := d.client.BulkWriter(ctx)
+	defer bw.End()
+
+	var jobs []*firestore.BulkWriterJob
+	for _, query := range queries {
+		docs, err := query.Documents(ctx).GetAll()
+		if err != nil {
+			return 0, err
+		}
+		for _, doc := range docs {
+			job, err := bw.Delete(doc.Ref)
+			if err != nil {
+				return 0, err
+			}
+			jobs = append(jobs, job)
+		}
+	}
+
+	bw.End()
+	for _, job := range jobs {
+		if _, err := job.Results(); err != nil {
+			return 0, err
+		}
+	}
+	return len(jobs), nil
+}
@@ -0,0 +1,201 @@
+package humandao
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tj/assert"
+)
+
+func TestMemoryDAO_Trash(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	dao := NewMemoryDAO(
+		Human{ID: "a", Name: "A", CreatedAt: now.Add(-2 * time.Hour)},
+		Human{ID: "b", Name: "B", CreatedAt: now.Add(-1 * time.Hour)},
+	)
+
+	require.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: "a", UserID: "admin"}))
+	// deleting twice is a no-op
+	require.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: "a", UserID: "someone-else"}))
+
+	humans, _, err := dao.ListHumans(ctx, ListHumansInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b"}, ids(humans))
+
+	_, err = dao.Human(ctx, HumanInput{Path: "a"})
+	require.ErrorIs(t, err, ErrHumanNotFound)
+	deleted, err := dao.Human(ctx, HumanInput{HumanID: "a", IncludeDeleted: true})
+	require.NoError(t, err)
+	require.Equal(t, "admin", deleted.DeletedBy)
+
+	trash, _, err := dao.Trash(ctx, TrashInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, ids(trash))
+
+	// the slug still belongs to the deleted human, so it can be restored
+	_, err = dao.AddHuman(ctx, AddHumanInput{Name: "A", Gender: GenderMale})
+	require.ErrorIs(t, err, ErrHumanAlreadyExists)
+
+	require.ErrorIs(t, dao.Purge(ctx, PurgeInput{HumanID: "b"}), ErrHumanNotDeleted)
+
+	restored, err := dao.Undelete(ctx, UndeleteInput{HumanID: "a", UserID: "admin"})
+	require.NoError(t, err)
+	require.False(t, restored.Deleted())
+	require.Empty(t, restored.DeletedBy)
+
+	revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: "a"})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	require.Equal(t, RevisionActionUndelete, revisions[0].Action)
+	require.Equal(t, RevisionActionDelete, revisions[1].Action)
+	require.Equal(t, []string{"deleted_at", "deleted_by"}, revisions[1].ChangedFields)
+
+	humans, _, err = dao.ListHumans(ctx, ListHumansInput{})
+	require.NoError(t, err)
+	require.Equal(t, []string{"b", "a"}, ids(humans))
+
+	require.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: "a"}))
+	require.NoError(t, dao.Purge(ctx, PurgeInput{HumanID: "a"}))
+	_, err = dao.Human(ctx, HumanInput{HumanID: "a", IncludeDeleted: true})
+	require.ErrorIs(t, err, ErrHumanNotFound)
+	revisions, _, err = dao.Revisions(ctx, RevisionsInput{HumanID: "a"})
+	require.NoError(t, err)
+	require.Empty(t, revisions)
+}
+
+func TestMemoryDAO_PublishInTrash(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO(Human{ID: "a", Name: "A", Draft: true})
+
+	require.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: "a"}))
+	require.ErrorIs(t, dao.Publish(ctx, PublishInput{HumanID: "a", UserID: "admin"}), ErrHumanNotFound)
+
+	trashed, err := dao.Human(ctx, HumanInput{HumanID: "a", IncludeDeleted: true})
+	require.NoError(t, err)
+	require.True(t, trashed.Draft)
+	require.Empty(t, trashed.PublishedBy)
+}
+
+func TestMemoryDAO_TrashPages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	dao := NewMemoryDAO(
+		Human{ID: "a", Name: "A", DeletedAt: now.Add(-3 * time.Hour)},
+		Human{ID: "b", Name: "B", DeletedAt: now.Add(-2 * time.Hour)},
+		Human{ID: "c", Name: "C", DeletedAt: now.Add(-1 * time.Hour)},
+		Human{ID: "d", Name: "D", CreatedAt: now},
+		Human{ID: "e", Name: "E", CreatedAt: now},
+	)
+
+	trash, next, err := dao.Trash(ctx, TrashInput{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"c", "b"}, ids(trash))
+	trash, next, err = dao.Trash(ctx, TrashInput{Limit: 2, PageToken: next})
+	require.NoError(t, err)
+	require.Equal(t, []string{"a"}, ids(trash))
+	require.Empty(t, next)
+
+	// a token only works for the list it was handed out for
+	_, next, err = dao.ListHumans(ctx, ListHumansInput{Limit: 1})
+	require.NoError(t, err)
+	_, _, err = dao.Trash(ctx, TrashInput{PageToken: next})
+	require.ErrorIs(t, err, ErrInvalidPageToken)
+}
+
+func TestMemoryDAO_UndeletePathTaken(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO(
+		Human{ID: "a", Name: "A", Path: "a", DeletedAt: time.Now(), DeletedBy: "admin"},
+		Human{ID: "b", Name: "B", Path: "b", PreviousPaths: []string{"a"}},
+	)
+
+	_, err := dao.Undelete(ctx, UndeleteInput{HumanID: "a", UserID: "admin"})
+	require.ErrorIs(t, err, ErrHumanAlreadyExists)
+
+	deleted, err := dao.Human(ctx, HumanInput{HumanID: "a", IncludeDeleted: true})
+	require.NoError(t, err)
+	require.True(t, deleted.Deleted())
+}
+
+func TestDAO_Trash(t *testing.T) {
+	WithDAO(t, func(ctx context.Context, dao *DAO) {
+		human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderFemale})
+		assert.NoError(t, err)
+		other, err := dao.AddHuman(ctx, AddHumanInput{Name: "Other", Gender: GenderFemale})
+		assert.NoError(t, err)
+
+		assert.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: human.ID, UserID: "admin"}))
+
+		humans, _, err := dao.ListHumans(ctx, ListHumansInput{Limit: 10})
+		assert.NoError(t, err)
+		assert.Len(t, humans, 1)
+		assert.Equal(t, other.ID, humans[0].ID)
+
+		trash, _, err := dao.Trash(ctx, TrashInput{})
+		assert.NoError(t, err)
+		assert.Len(t, trash, 1)
+		assert.Equal(t, human.ID, trash[0].ID)
+		assert.Equal(t, "admin", trash[0].DeletedBy)
+
+		// the slug still belongs to the deleted human, so it can be restored
+		_, err = dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderFemale})
+		assert.True(t, errors.Is(err, ErrHumanAlreadyExists))
+
+		err = dao.Purge(ctx, PurgeInput{HumanID: other.ID})
+		assert.True(t, errors.Is(err, ErrHumanNotDeleted))
+
+		restored, err := dao.Undelete(ctx, UndeleteInput{HumanID: human.ID, UserID: "admin"})
+		assert.NoError(t, err)
+		assert.False(t, restored.Deleted())
+		got, err := dao.Human(ctx, HumanInput{HumanID: human.ID})
+		assert.NoError(t, err)
+		assert.False(t, got.Deleted())
+
+		assert.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: human.ID}))
+		assert.NoError(t, dao.Purge(ctx, PurgeInput{HumanID: human.ID}))
+		_, err = dao.Human(ctx, HumanInput{HumanID: human.ID, IncludeDeleted: true})
+		assert.True(t, errors.Is(err, ErrHumanNotFound))
+		revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID})
+		assert.NoError(t, err)
+		assert.Empty(t, revisions)
+	})
+}
+
+func TestDAO_PurgeManyRevisions(t *testing.T) {
+	WithDAO(t, func(ctx context.Context, dao *DAO) {
+		human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Foo Bar", Gender: GenderFemale})
+		assert.NoError(t, err)
+		other, err := dao.AddHuman(ctx, AddHumanInput{Name: "Other", Gender: GenderFemale})
+		assert.NoError(t, err)
+		_, err = dao.AddRelationship(ctx, AddRelationshipInput{Type: RelationshipCollaborator, From: human.ID, To: other.ID})
+		assert.NoError(t, err)
+
+		// more revisions than a transaction can delete
+		bw := dao.client.BulkWriter(ctx)
+		for i := range 600 {
+			revision := newRevision(ctx, RevisionActionUpdate, "admin", human, human)
+			revision.Snapshot.Description = strconv.Itoa(i)
+			_, err := bw.Create(dao.revisions(human.ID).Doc(revision.ID), revision)
+			assert.NoError(t, err)
+		}
+		bw.End()
+
+		assert.NoError(t, dao.Delete(ctx, DeleteInput{HumanID: human.ID}))
+		assert.NoError(t, dao.Purge(ctx, PurgeInput{HumanID: human.ID}))
+
+		revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID})
+		assert.NoError(t, err)
+		assert.Empty(t, revisions)
+		relationships, err := dao.Relationships(ctx, RelationshipsInput{HumanID: other.ID})
+		assert.NoError(t, err)
+		assert.Empty(t, relationships)
+
+		err = dao.Purge(ctx, PurgeInput{HumanID: human.ID})
+		assert.True(t, errors.Is(err, ErrHumanNotFound))
+	})
+}
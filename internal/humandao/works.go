@@ -0,0 +1,121 @@
+package humandao
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+var ErrInvalidWork = errors.New("invalid work")
+
+type WorkType string
+
+const (
+	WorkFilm       WorkType = "film"
+	WorkTelevision WorkType = "tv"
+	// WorkStage is a play, musical or stand-up special performed live.
+	WorkStage WorkType = "stage"
+	WorkAlbum WorkType = "album"
+	WorkSong  WorkType = "song"
+	WorkBook  WorkType = "book"
+	WorkGame  WorkType = "game"
+	WorkOther WorkType = "other"
+)
+
+// WorkTypes lists every type, in the order they are offered in forms.
+var WorkTypes = []WorkType{WorkFilm, WorkTelevision, WorkStage, WorkAlbum, WorkSong, WorkBook, WorkGame, WorkOther}
+
+// Work is something a human made or took part in, like a film they acted in or a book they wrote.
+type Work struct {
+	Title string   `firestore:"title"`
+	Type  WorkType `firestore:"type"`
+	// Year is when the work came out. Zero means it isn't known.
+	Year int `firestore:"year,omitempty"`
+	// Credit is what the human did on the work, e.g. "Director", "Author" or the character they played.
+	Credit string `firestore:"credit,omitempty"`
+	// URL links to more about the work, e.g. its IMDb or Goodreads page.
+	URL string `firestore:"url,omitempty"`
+}
+
+func (w Work) String() string {
+	s := w.Title
+	if w.Year != 0 {
+		s = fmt.Sprintf("%v (%d)", s, w.Year)
+	}
+	if w.Credit != "" {
+		s = fmt.Sprintf("%v as %v", s, w.Credit)
+	}
+	return fmt.Sprintf("%v: %v", w.Type, s)
+}
+
+// sameWork reports whether a and b are the same credit, ignoring the link.
+func sameWork(a, b Work) bool {
+	return strings.EqualFold(a.Title, b.Title) && a.Type == b.Type && a.Year == b.Year && strings.EqualFold(a.Credit, b.Credit)
+}
+
+// compareWorks orders works oldest first, with works of an unknown year last, then by title.
+func compareWorks(a, b Work) int {
+	if (a.Year == 0) != (b.Year == 0) {
+		if a.Year == 0 {
+			return 1
+		}
+		return -1
+	}
+	if c := cmp.Compare(a.Year, b.Year); c != 0 {
+		return c
+	}
+	return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+}
+
+// normalizeWorks trims the works, checks them, drops duplicates and puts them in order.
+func normalizeWorks(works []Work) ([]Work, error) {
+	if len(works) == 0 {
+		return nil, nil
+	}
+	// a work can be announced a few years before it comes out
+	latestYear := time.Now().Year() + 5
+	normalized := make([]Work, 0, len(works))
+	for _, work := range works {
+		work.Title = strings.TrimSpace(work.Title)
+		work.Type = WorkType(strings.ToLower(strings.TrimSpace(string(work.Type))))
+		work.Credit = strings.TrimSpace(work.Credit)
+		work.URL = strings.TrimSpace(work.URL)
+		if work.Title == "" {
+			return nil, fmt.Errorf("%w: title must be provided", ErrInvalidWork)
+		}
+		if !slices.Contains(WorkTypes, work.Type) {
+			return nil, fmt.Errorf("%w: unknown type %q for %v", ErrInvalidWork, work.Type, work.Title)
+		}
+		if work.Year != 0 && (work.Year < 1000 || work.Year > latestYear) {
+			return nil, fmt.Errorf("%w: year %d of %v", ErrInvalidWork, work.Year, work.Title)
+		}
+		if work.URL != "" {
+			u, err := url.Parse(work.URL)
+			if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				return nil, fmt.Errorf("%w: %q is not a http(s) url", ErrInvalidWork, work.URL)
+			}
+		}
+		if slices.ContainsFunc(normalized, func(w Work) bool { return sameWork(w, work) }) {
+			continue
+		}
+		normalized = append(normalized, work)
+	}
+	slices.SortStableFunc(normalized, compareWorks)
+	return normalized, nil
+}
+
+// mergeWorks returns the works of both humans, without the credits they share.
+func mergeWorks(winner, loser []Work) []Work {
+	merged := slices.Clone(winner)
+	for _, work := range loser {
+		if !slices.ContainsFunc(merged, func(w Work) bool { return sameWork(w, work) }) {
+			merged = append(merged, work)
+		}
+	}
+	slices.SortStableFunc(merged, compareWorks)
+	return merged
+}
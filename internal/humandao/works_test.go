@@ -0,0 +1,56 @@
+package humandao
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeWorks(t *testing.T) {
+	works, err := normalizeWorks([]Work{
+		{Title: " Beef ", Type: "TV", Year: 2023, Credit: "Danny Cho"},
+		{Title: "Untitled Project", Type: WorkFilm},
+		{Title: "Minari", Type: WorkFilm, Year: 2020, Credit: "Jacob Yi", URL: "https://www.imdb.com/title/tt10633456/"},
+		{Title: "beef", Type: WorkTelevision, Year: 2023, Credit: "danny cho"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, []Work{
+		{Title: "Minari", Type: WorkFilm, Year: 2020, Credit: "Jacob Yi", URL: "https://www.imdb.com/title/tt10633456/"},
+		{Title: "Beef", Type: WorkTelevision, Year: 2023, Credit: "Danny Cho"},
+		{Title: "Untitled Project", Type: WorkFilm},
+	}, works)
+
+	for name, work := range map[string]Work{
+		"no-title":     {Type: WorkFilm},
+		"unknown-type": {Title: "Minari", Type: "podcast"},
+		"year":         {Title: "Minari", Type: WorkFilm, Year: 20},
+		"url":          {Title: "Minari", Type: WorkFilm, URL: "imdb.com/minari"},
+	} {
+		_, err := normalizeWorks([]Work{work})
+		require.ErrorIs(t, err, ErrInvalidWork, name)
+	}
+}
+
+func TestMemoryDAO_Works(t *testing.T) {
+	ctx := context.Background()
+	dao := NewMemoryDAO()
+
+	_, err := dao.AddHuman(ctx, AddHumanInput{Name: "Steven Yeun", Gender: GenderMale, Works: []Work{{Title: "Minari"}}})
+	require.ErrorIs(t, err, ErrInvalidWork)
+
+	human, err := dao.AddHuman(ctx, AddHumanInput{Name: "Steven Yeun", Gender: GenderMale, Works: []Work{{Title: "Minari", Type: WorkFilm, Year: 2020}}})
+	require.NoError(t, err)
+
+	works := append(human.Works, Work{Title: "Nope", Type: WorkFilm, Year: 2022, Credit: "Ricky 'Jupe' Park"})
+	patched, err := dao.PatchHuman(ctx, human.ID, HumanPatch{Works: &works})
+	require.NoError(t, err)
+	require.Equal(t, works, patched.Works)
+
+	revisions, _, err := dao.Revisions(ctx, RevisionsInput{HumanID: human.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{"works"}, revisions[0].ChangedFields)
+
+	merged := MergeHumans(patched, Human{Name: "Steven Yeun", Works: []Work{{Title: "Beef", Type: WorkTelevision, Year: 2023}, works[0]}}, nil)
+	require.Equal(t, []string{"Minari", "Nope", "Beef"}, []string{merged.Works[0].Title, merged.Works[1].Title, merged.Works[2].Title})
+}
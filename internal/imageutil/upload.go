@@ -3,11 +3,15 @@ package imageutil
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	_ "image/gif"
 	"image/jpeg"
 	_ "image/png"
 	"net/url"
+	"slices"
+	"strings"
+	"time"
 
 	_ "golang.org/x/image/webp"
 
@@ -15,15 +19,17 @@ import (
 	"github.com/disintegration/imaging"
 	"github.com/raymonstah/asianamericanswiki/functions/api"
 	"github.com/raymonstah/asianamericanswiki/internal/humandao"
+	"github.com/segmentio/ksuid"
+	"google.golang.org/api/iterator"
 )
 
 type Uploader struct {
 	storageClient *storage.Client
-	humanDAO      *humandao.DAO
+	humanDAO      humandao.HumanStore
 	storageURL    string
 }
 
-func NewUploader(storageClient *storage.Client, humanDAO *humandao.DAO, storageURL string) *Uploader {
+func NewUploader(storageClient *storage.Client, humanDAO humandao.HumanStore, storageURL string) *Uploader {
 	return &Uploader{
 		storageClient: storageClient,
 		humanDAO:      humanDAO,
@@ -31,16 +37,74 @@ func NewUploader(storageClient *storage.Client, humanDAO *humandao.DAO, storageU
 	}
 }
 
-func (u *Uploader) UploadHumanImages(ctx context.Context, human humandao.Human, rawImage []byte) (humandao.Human, error) {
+// humanImageObjects are the objects UploadHumanImage writes for an image of a human: the image
+// and a thumbnail of it.
+func humanImageObjects(humanID, imageID string) (string, string) {
+	return fmt.Sprintf("%s/%s/original.webp", humanID, imageID), fmt.Sprintf("%s/%s/thumbnail.webp", humanID, imageID)
+}
+
+// UsesImagesOf reports whether the human shows any of the images uploaded for the human with the
+// ID, as the winner of a merge does when it took the images of the loser.
+func UsesImagesOf(human humandao.Human, humanID string) bool {
+	prefix := humanID + "/"
+	urls := []string{human.FeaturedImage, human.Images.Featured, human.Images.Thumbnail}
+	for _, image := range human.Images.Gallery {
+		if strings.HasPrefix(image.Object, prefix) || strings.HasPrefix(image.ThumbnailObject, prefix) {
+			return true
+		}
+		urls = append(urls, image.URL, image.ThumbnailURL)
+	}
+	// the emulator serves objects with their name escaped, see objectURL
+	return slices.ContainsFunc(urls, func(u string) bool {
+		return strings.Contains(u, "/"+prefix) || strings.Contains(u, "/"+url.PathEscape(prefix))
+	})
+}
+
+// objectURL is where an object of the images bucket is served from.
+func (u *Uploader) objectURL(objectID string) string {
+	if u.storageURL == "https://storage.googleapis.com" {
+		return fmt.Sprintf("%v/%v/%s", u.storageURL, api.ImagesStorageBucket, objectID)
+	}
+	// Emulator URL format
+	return fmt.Sprintf("%v/v0/b/%v/o/%s?alt=media", u.storageURL, api.ImagesStorageBucket, url.PathEscape(objectID))
+}
+
+func (u *Uploader) upload(ctx context.Context, objectID, contentType string, raw []byte) error {
+	writer := u.storageClient.Bucket(api.ImagesStorageBucket).Object(objectID).NewWriter(ctx)
+	writer.ContentType = contentType
+	if _, err := writer.Write(raw); err != nil {
+		return fmt.Errorf("unable to upload %v: %w", objectID, err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("unable to upload %v: %w", objectID, err)
+	}
+	return nil
+}
+
+type UploadHumanImageInput struct {
+	Human    humandao.Human
+	RawImage []byte
+	// Image is what is known about the image, like who took it and its license.
+	Image humandao.Image
+	// Featured makes the image the one shown wherever the human is. The first image of a human
+	// is featured either way.
+	Featured bool
+}
+
+// UploadHumanImage uploads the image and a thumbnail of it, and adds it to the gallery of the human.
+// The images already uploaded are kept. The patch is attributed to the author on ctx, or to
+// "image-upload" if there is none.
+func (u *Uploader) UploadHumanImage(ctx context.Context, input UploadHumanImageInput) (humandao.Human, error) {
+	ctx = humandao.WithDefaultAuthor(ctx, "image-upload")
+	human := input.Human
+
 	// Generate thumbnail
-	src, err := imaging.Decode(bytes.NewReader(rawImage))
+	src, err := imaging.Decode(bytes.NewReader(input.RawImage))
 	if err != nil {
-		return human, fmt.Errorf("unable to decode image for thumbnail: %w", err)
+		return human, fmt.Errorf("%w: unable to decode image for thumbnail: %v", humandao.ErrInvalidImage, err)
 	}
-
 	thumb := imaging.Thumbnail(src, 256, 256, imaging.Lanczos)
 	thumb = imaging.Sharpen(thumb, 0.5)
-
 	var thumbBuf bytes.Buffer
 	// Even though the object ID might end in .webp, imaging.Encode or jpeg.Encode determines the format.
 	// For now, let's keep it consistent and actually use the format the extension suggests if possible,
@@ -48,45 +112,100 @@ func (u *Uploader) UploadHumanImages(ctx context.Context, human humandao.Human,
 	if err := jpeg.Encode(&thumbBuf, thumb, &jpeg.Options{Quality: 95}); err != nil {
 		return human, fmt.Errorf("unable to encode thumbnail to jpeg: %w", err)
 	}
-	thumbRaw := thumbBuf.Bytes()
+
+	image := input.Image
+	image.ID = ksuid.New().String()
+	image.Object, image.ThumbnailObject = humanImageObjects(human.ID, image.ID)
+	image.URL, image.ThumbnailURL = u.objectURL(image.Object), u.objectURL(image.ThumbnailObject)
+	image.Width, image.Height = src.Bounds().Dx(), src.Bounds().Dy()
+	image.UploadedAt = time.Now()
+	image.UploadedBy = humandao.AuthorFromContext(ctx)
 
 	// Upload to GCS
-	objectID := fmt.Sprintf("%s/original.webp", human.ID)
-	obj := u.storageClient.Bucket(api.ImagesStorageBucket).Object(objectID)
-	writer := obj.NewWriter(ctx)
-	writer.ContentType = "image/webp"
-	if _, err := writer.Write(rawImage); err != nil {
-		return human, fmt.Errorf("unable to upload original image: %w", err)
+	if err := u.upload(ctx, image.Object, "image/webp", input.RawImage); err != nil {
+		return human, err
 	}
-	if err := writer.Close(); err != nil {
+	if err := u.upload(ctx, image.ThumbnailObject, "image/jpeg", thumbBuf.Bytes()); err != nil {
 		return human, err
 	}
 
-	thumbObjectID := fmt.Sprintf("%s/thumbnail.webp", human.ID)
-	thumbObj := u.storageClient.Bucket(api.ImagesStorageBucket).Object(thumbObjectID)
-	thumbWriter := thumbObj.NewWriter(ctx)
-	thumbWriter.ContentType = "image/jpeg"
-	if _, err := thumbWriter.Write(thumbRaw); err != nil {
-		return human, fmt.Errorf("unable to upload thumbnail: %w", err)
-	}
-	if err := thumbWriter.Close(); err != nil {
-		return human, err
+	// the gallery is changed as it is stored, so images added or removed since human was read are kept
+	patched, err := u.humanDAO.PatchHumanFunc(ctx, human.ID, func(current humandao.Human) (humandao.HumanPatch, error) {
+		images := current.Images
+		if len(images.Gallery) == 0 && images.Featured != "" {
+			// the image featured before there was a gallery is kept in it, without any details
+			images = images.WithImage(humandao.Image{ID: ksuid.New().String(), URL: images.Featured, ThumbnailURL: images.Thumbnail}, true)
+		}
+		images = images.WithImage(image, input.Featured)
+		// If it was already AI generated, keep it true.
+		aiGenerated := current.AIGenerated || (images.FeaturedID == image.ID && image.AIGenerated)
+		return humandao.HumanPatch{Images: &images, AIGenerated: &aiGenerated}, nil
+	})
+	if err != nil {
+		return human, fmt.Errorf("unable to update human with image URLs: %w", err)
 	}
 
-	if u.storageURL == "https://storage.googleapis.com" {
-		human.Images.Featured = fmt.Sprintf("%v/%v/%s", u.storageURL, api.ImagesStorageBucket, objectID)
-		human.Images.Thumbnail = fmt.Sprintf("%v/%v/%s", u.storageURL, api.ImagesStorageBucket, thumbObjectID)
-	} else {
-		// Emulator URL format
-		human.Images.Featured = fmt.Sprintf("%v/v0/b/%v/o/%s?alt=media", u.storageURL, api.ImagesStorageBucket, url.PathEscape(objectID))
-		human.Images.Thumbnail = fmt.Sprintf("%v/v0/b/%v/o/%s?alt=media", u.storageURL, api.ImagesStorageBucket, url.PathEscape(thumbObjectID))
+	return patched, nil
+}
+
+// FeatureHumanImage makes the image of the gallery with the ID the one shown wherever the human is.
+// The gallery is read as it is stored, so human only needs to have its ID.
+func (u *Uploader) FeatureHumanImage(ctx context.Context, human humandao.Human, imageID string) (humandao.Human, error) {
+	patched, err := u.humanDAO.PatchHumanFunc(ctx, human.ID, func(current humandao.Human) (humandao.HumanPatch, error) {
+		images, err := current.Images.Feature(imageID)
+		if err != nil {
+			return humandao.HumanPatch{}, err
+		}
+		return humandao.HumanPatch{Images: &images}, nil
+	})
+	if err != nil {
+		return human, fmt.Errorf("unable to feature image %v: %w", imageID, err)
 	}
+	return patched, nil
+}
 
-	// If it was already AI generated, keep it true.
-	// The caller can set it to true if they know it's AI generated.
-	if err := u.humanDAO.UpdateHuman(ctx, human); err != nil {
-		return human, fmt.Errorf("unable to update human with image URLs: %w", err)
+// DeleteHumanImage removes the image with the ID from the gallery of the human, and then from storage.
+// Like FeatureHumanImage, it changes the gallery as it is stored rather than the one on human.
+func (u *Uploader) DeleteHumanImage(ctx context.Context, human humandao.Human, imageID string) (humandao.Human, error) {
+	var image humandao.Image
+	patched, err := u.humanDAO.PatchHumanFunc(ctx, human.ID, func(current humandao.Human) (humandao.HumanPatch, error) {
+		images, removed, err := current.Images.Without(imageID)
+		if err != nil {
+			return humandao.HumanPatch{}, err
+		}
+		image = removed
+		return humandao.HumanPatch{Images: &images}, nil
+	})
+	if err != nil {
+		return human, fmt.Errorf("unable to remove image %v: %w", imageID, err)
+	}
+	for _, objectID := range []string{image.Object, image.ThumbnailObject} {
+		if objectID == "" {
+			continue
+		}
+		err := u.storageClient.Bucket(api.ImagesStorageBucket).Object(objectID).Delete(ctx)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return patched, fmt.Errorf("unable to delete image %v: %w", objectID, err)
+		}
 	}
+	return patched, nil
+}
 
-	return human, nil
+// DeleteHumanImages removes every image uploaded for a human.
+func (u *Uploader) DeleteHumanImages(ctx context.Context, humanID string) error {
+	bucket := u.storageClient.Bucket(api.ImagesStorageBucket)
+	it := bucket.Objects(ctx, &storage.Query{Prefix: humanID + "/"})
+	for {
+		attrs, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("unable to list images of %v: %w", humanID, err)
+		}
+		err = bucket.Object(attrs.Name).Delete(ctx)
+		if err != nil && !errors.Is(err, storage.ErrObjectNotExist) {
+			return fmt.Errorf("unable to delete image %v: %w", attrs.Name, err)
+		}
+	}
 }
@@ -2,6 +2,7 @@ package imageutil
 
 import (
 	"context"
+	"errors"
 	"image"
 	"image/color"
 	"image/draw"
@@ -61,7 +62,11 @@ func TestUploader_UploadHumanImages(t *testing.T) {
 	rawImage := buf.Bytes()
 
 	// Upload
-	updatedHuman, err := uploader.UploadHumanImages(ctx, human, rawImage)
+	updatedHuman, err := uploader.UploadHumanImage(ctx, UploadHumanImageInput{
+		Human:    human,
+		RawImage: rawImage,
+		Image:    humandao.Image{Author: "Jane Doe", License: "cc by 4.0", SourceURL: "https://example.com/photo"},
+	})
 	assert.NoError(t, err)
 
 	// Verify URLs
@@ -71,23 +76,101 @@ func TestUploader_UploadHumanImages(t *testing.T) {
 	assert.Contains(t, updatedHuman.Images.Thumbnail, human.ID)
 	assert.Contains(t, updatedHuman.Images.Featured, "127.0.0.1:9199")
 	assert.Contains(t, updatedHuman.Images.Thumbnail, "127.0.0.1:9199")
+	first := updatedHuman.Images.FeaturedImage()
+	assert.Equal(t, updatedHuman.Images.FeaturedID, first.ID)
+	assert.Equal(t, 100, first.Width)
+	assert.Equal(t, 100, first.Height)
+	assert.Equal(t, "Photo by Jane Doe, CC BY 4.0", first.Attribution())
 
 	// Verify storage objects exist
 	bucket := storageClient.Bucket(api.ImagesStorageBucket)
 	
 	// Check original
-	_, err = bucket.Object(human.ID + "/original.webp").Attrs(ctx)
+	_, err = bucket.Object(first.Object).Attrs(ctx)
 	assert.NoError(t, err)
 
 	// Check thumbnail
-	_, err = bucket.Object(human.ID + "/thumbnail.webp").Attrs(ctx)
+	_, err = bucket.Object(first.ThumbnailObject).Attrs(ctx)
+	assert.NoError(t, err)
+
+	// A second image is added to the gallery, without replacing the first
+	updatedHuman, err = uploader.UploadHumanImage(ctx, UploadHumanImageInput{Human: updatedHuman, RawImage: rawImage, Featured: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(updatedHuman.Images.Gallery))
+	assert.NotEqual(t, first.ID, updatedHuman.Images.FeaturedID)
+	_, err = bucket.Object(first.Object).Attrs(ctx)
+	assert.NoError(t, err)
+
+	updatedHuman, err = uploader.FeatureHumanImage(ctx, updatedHuman, first.ID)
 	assert.NoError(t, err)
+	assert.Equal(t, first.URL, updatedHuman.Images.Featured)
 
 	// Verify Firestore record updated
 	gotHuman, err := dao.Human(ctx, humandao.HumanInput{HumanID: human.ID})
 	assert.NoError(t, err)
 	assert.Equal(t, updatedHuman.Images.Featured, gotHuman.Images.Featured)
 	assert.Equal(t, updatedHuman.Images.Thumbnail, gotHuman.Images.Thumbnail)
+	assert.Equal(t, 2, len(gotHuman.Images.Gallery))
+
+	updatedHuman, err = uploader.DeleteHumanImage(ctx, updatedHuman, first.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(updatedHuman.Images.Gallery))
+	assert.Equal(t, updatedHuman.Images.Gallery[0].URL, updatedHuman.Images.Featured)
+	_, err = bucket.Object(first.Object).Attrs(ctx)
+	assert.Equal(t, storage.ErrObjectNotExist, err)
+
+	// Delete, twice to make sure missing objects are skipped
+	second := updatedHuman.Images.Gallery[0]
+	assert.NoError(t, uploader.DeleteHumanImages(ctx, human.ID))
+	assert.NoError(t, uploader.DeleteHumanImages(ctx, human.ID))
+	_, err = bucket.Object(second.Object).Attrs(ctx)
+	assert.Equal(t, storage.ErrObjectNotExist, err)
+	_, err = bucket.Object(second.ThumbnailObject).Attrs(ctx)
+	assert.Equal(t, storage.ErrObjectNotExist, err)
+}
+
+func TestUsesImagesOf(t *testing.T) {
+	uploader := NewUploader(nil, nil, "https://storage.googleapis.com")
+	emulator := NewUploader(nil, nil, "http://127.0.0.1:9199")
+	object, thumbnail := humanImageObjects("loser", "one")
+
+	for name, test := range map[string]struct {
+		human    humandao.Human
+		expected bool
+	}{
+		"none":           {humandao.Human{}, false},
+		"own images":     {humandao.Human{Images: humandao.Images{Featured: uploader.objectURL("winner/one/original.webp")}}, false},
+		"gallery object": {humandao.Human{Images: humandao.Images{Gallery: []humandao.Image{{ID: "one", Object: object, ThumbnailObject: thumbnail}}}}, true},
+		"featured":       {humandao.Human{Images: humandao.Images{Featured: uploader.objectURL(object)}}, true},
+		"emulator":       {humandao.Human{Images: humandao.Images{Thumbnail: emulator.objectURL(thumbnail)}}, true},
+		"legacy":         {humandao.Human{FeaturedImage: uploader.objectURL("loser/original.webp")}, true},
+		"similar id":     {humandao.Human{Images: humandao.Images{Featured: uploader.objectURL("loser2/one/original.webp")}}, false},
+	} {
+		assert.Equal(t, test.expected, UsesImagesOf(test.human, "loser"), name)
+	}
+}
+
+func TestUploader_StaleHuman(t *testing.T) {
+	ctx := context.Background()
+	images := humandao.Images{}.
+		WithImage(humandao.Image{ID: "one", URL: "https://example.com/one.webp"}, true).
+		WithImage(humandao.Image{ID: "two", URL: "https://example.com/two.webp"}, false)
+	dao := humandao.NewMemoryDAO(humandao.Human{ID: "ali", Name: "Ali Wong", Images: images})
+	uploader := NewUploader(nil, dao, "https://storage.googleapis.com")
+
+	stale, err := dao.Human(ctx, humandao.HumanInput{HumanID: "ali"})
+	assert.NoError(t, err)
+	_, err = uploader.DeleteHumanImage(ctx, stale, "one")
+	assert.NoError(t, err)
+
+	// featuring with the copy read before the delete doesn't bring the deleted image back
+	featured, err := uploader.FeatureHumanImage(ctx, stale, "two")
+	assert.NoError(t, err)
+	assert.Equal(t, "two", featured.Images.FeaturedID)
+	assert.Equal(t, 1, len(featured.Images.Gallery))
+
+	_, err = uploader.FeatureHumanImage(ctx, stale, "one")
+	assert.True(t, errors.Is(err, humandao.ErrImageNotFound))
 }
 
 func TestDecodeFormatsRegistered(t *testing.T) {
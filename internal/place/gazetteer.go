@@ -0,0 +1,262 @@
+package place
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// gazetteer.csv lists the cities places are matched against, with the most likely city first
+// when a name is shared (e.g. Richmond, Virginia before Richmond, British Columbia).
+//
+//go:embed gazetteer.csv
+var gazetteerCSV string
+
+type city struct {
+	Place
+	aliases []string
+}
+
+var cities = sync.OnceValue(func() []city {
+	records, err := csv.NewReader(strings.NewReader(gazetteerCSV)).ReadAll()
+	if err != nil {
+		panic(fmt.Sprintf("unable to read gazetteer: %v", err))
+	}
+	var cities []city
+	for _, record := range records[1:] {
+		lat, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			panic(fmt.Sprintf("unable to read latitude of %v: %v", record[0], err))
+		}
+		lng, err := strconv.ParseFloat(record[4], 64)
+		if err != nil {
+			panic(fmt.Sprintf("unable to read longitude of %v: %v", record[0], err))
+		}
+		c := city{Place: Place{City: record[0], Region: record[1], Country: record[2], Lat: lat, Lng: lng}}
+		if record[5] != "" {
+			c.aliases = strings.Split(record[5], "|")
+		}
+		cities = append(cities, c)
+	}
+	return cities
+})
+
+type country struct {
+	name    string
+	aliases []string
+}
+
+var countries = []country{
+	{"United States", []string{"US", "USA", "U.S.", "U.S.A.", "United States of America", "America"}},
+	{"Canada", nil},
+	{"United Kingdom", []string{"UK", "U.K.", "Great Britain", "Britain"}},
+	{"France", nil},
+	{"Germany", nil},
+	{"Australia", nil},
+	{"New Zealand", nil},
+	{"Mexico", nil},
+	{"Brazil", nil},
+	{"Peru", nil},
+	{"China", []string{"PRC", "People's Republic of China", "Mainland China"}},
+	{"Hong Kong", []string{"Hong Kong SAR"}},
+	{"Macau", []string{"Macao"}},
+	{"Taiwan", []string{"Republic of China", "ROC"}},
+	{"Japan", nil},
+	{"South Korea", []string{"Korea", "Republic of Korea", "ROK"}},
+	{"North Korea", []string{"DPRK"}},
+	{"Mongolia", nil},
+	{"Philippines", []string{"The Philippines"}},
+	{"Vietnam", []string{"Viet Nam"}},
+	{"Thailand", nil},
+	{"Cambodia", nil},
+	{"Laos", nil},
+	{"Myanmar", []string{"Burma"}},
+	{"Malaysia", nil},
+	{"Singapore", nil},
+	{"Brunei", nil},
+	{"Indonesia", nil},
+	{"India", nil},
+	{"Pakistan", nil},
+	{"Bangladesh", nil},
+	{"Sri Lanka", nil},
+	{"Nepal", nil},
+	{"Bhutan", nil},
+	{"Maldives", nil},
+	{"Afghanistan", nil},
+}
+
+type region struct {
+	name    string
+	abbr    string
+	country string
+}
+
+var regions = []region{
+	{"Alabama", "AL", "United States"}, {"Alaska", "AK", "United States"}, {"Arizona", "AZ", "United States"},
+	{"Arkansas", "AR", "United States"}, {"California", "CA", "United States"}, {"Colorado", "CO", "United States"},
+	{"Connecticut", "CT", "United States"}, {"Delaware", "DE", "United States"}, {"District of Columbia", "DC", "United States"},
+	{"Florida", "FL", "United States"}, {"Georgia", "GA", "United States"}, {"Hawaii", "HI", "United States"},
+	{"Idaho", "ID", "United States"}, {"Illinois", "IL", "United States"}, {"Indiana", "IN", "United States"},
+	{"Iowa", "IA", "United States"}, {"Kansas", "KS", "United States"}, {"Kentucky", "KY", "United States"},
+	{"Louisiana", "LA", "United States"}, {"Maine", "ME", "United States"}, {"Maryland", "MD", "United States"},
+	{"Massachusetts", "MA", "United States"}, {"Michigan", "MI", "United States"}, {"Minnesota", "MN", "United States"},
+	{"Mississippi", "MS", "United States"}, {"Missouri", "MO", "United States"}, {"Montana", "MT", "United States"},
+	{"Nebraska", "NE", "United States"}, {"Nevada", "NV", "United States"}, {"New Hampshire", "NH", "United States"},
+	{"New Jersey", "NJ", "United States"}, {"New Mexico", "NM", "United States"}, {"New York", "NY", "United States"},
+	{"North Carolina", "NC", "United States"}, {"North Dakota", "ND", "United States"}, {"Ohio", "OH", "United States"},
+	{"Oklahoma", "OK", "United States"}, {"Oregon", "OR", "United States"}, {"Pennsylvania", "PA", "United States"},
+	{"Rhode Island", "RI", "United States"}, {"South Carolina", "SC", "United States"}, {"South Dakota", "SD", "United States"},
+	{"Tennessee", "TN", "United States"}, {"Texas", "TX", "United States"}, {"Utah", "UT", "United States"},
+	{"Vermont", "VT", "United States"}, {"Virginia", "VA", "United States"}, {"Washington", "WA", "United States"},
+	{"West Virginia", "WV", "United States"}, {"Wisconsin", "WI", "United States"}, {"Wyoming", "WY", "United States"},
+	{"Alberta", "AB", "Canada"}, {"British Columbia", "BC", "Canada"}, {"Manitoba", "MB", "Canada"},
+	{"New Brunswick", "NB", "Canada"}, {"Newfoundland and Labrador", "NL", "Canada"}, {"Nova Scotia", "NS", "Canada"},
+	{"Ontario", "ON", "Canada"}, {"Prince Edward Island", "PE", "Canada"}, {"Quebec", "QC", "Canada"},
+	{"Saskatchewan", "SK", "Canada"},
+	{"England", "", "United Kingdom"}, {"Scotland", "", "United Kingdom"}, {"Wales", "", "United Kingdom"},
+	{"New South Wales", "NSW", "Australia"}, {"Victoria", "VIC", "Australia"}, {"Queensland", "QLD", "Australia"},
+	{"Western Australia", "WA", "Australia"},
+	{"Okinawa", "", "Japan"}, {"Penang", "", "Malaysia"}, {"Bali", "", "Indonesia"},
+}
+
+// key is how names are compared: case, dots and extra spaces don't matter.
+func key(s string) string {
+	s = strings.ReplaceAll(strings.ToLower(s), ".", "")
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func lookupCountry(name string) (string, bool) {
+	k := key(name)
+	for _, c := range countries {
+		if key(c.name) == k {
+			return c.name, true
+		}
+		for _, alias := range c.aliases {
+			if key(alias) == k {
+				return c.name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// lookupRegion finds a region by its name or abbreviation, in the country if it is known.
+func lookupRegion(name, inCountry string) (region, bool) {
+	k := key(name)
+	for _, r := range regions {
+		if inCountry != "" && r.country != inCountry {
+			continue
+		}
+		if key(r.name) == k || (r.abbr != "" && key(r.abbr) == k) {
+			return r, true
+		}
+	}
+	return region{}, false
+}
+
+// lookupCity finds a city by its name or one of its aliases, in the region and country if they are known.
+func lookupCity(name, inRegion, inCountry string) (Place, bool) {
+	k := key(name)
+	for _, c := range cities() {
+		if inRegion != "" && c.Region != inRegion {
+			continue
+		}
+		if inCountry != "" && c.Country != inCountry {
+			continue
+		}
+		if key(c.City) == k {
+			return c.Place, true
+		}
+		for _, alias := range c.aliases {
+			if key(alias) == k {
+				return c.Place, true
+			}
+		}
+	}
+	return Place{}, false
+}
+
+// Parse reads a place written out in full, like "Los Angeles, CA" or "Seoul, South Korea", and
+// fills in what the gazetteer knows about it. Places it doesn't know are kept as written, without
+// coordinates.
+func Parse(s string) Place {
+	var parts []string
+	for _, part := range strings.Split(s, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	switch len(parts) {
+	case 0:
+		return Place{}
+	case 1:
+		if p, ok := lookupCity(parts[0], "", ""); ok {
+			return p
+		}
+		if r, ok := lookupRegion(parts[0], ""); ok {
+			return Place{Region: r.name, Country: r.country}
+		}
+		if c, ok := lookupCountry(parts[0]); ok {
+			return Place{Country: c}
+		}
+		return Place{City: parts[0]}
+	}
+
+	var p Place
+	if c, ok := lookupCountry(parts[len(parts)-1]); ok {
+		p.Country = c
+		parts = parts[:len(parts)-1]
+	}
+	if len(parts) > 1 || (len(parts) == 1 && p.Country != "") {
+		if _, ok := lookupRegion(parts[len(parts)-1], p.Country); ok {
+			p.Region = parts[len(parts)-1]
+			parts = parts[:len(parts)-1]
+		}
+	}
+	p.City = strings.Join(parts, ", ")
+	return Resolve(p)
+}
+
+// ParseAll parses every place, leaving out the empty ones.
+func ParseAll(places []string) []Place {
+	var parsed []Place
+	for _, s := range places {
+		if p := Parse(s); !p.IsZero() {
+			parsed = append(parsed, p)
+		}
+	}
+	return parsed
+}
+
+// Resolve puts the region and country of the place in the form the gazetteer uses, and fills in
+// anything missing about a city it knows, including its coordinates.
+func Resolve(p Place) Place {
+	p.City = strings.TrimSpace(p.City)
+	p.Region = strings.TrimSpace(p.Region)
+	p.Country = strings.TrimSpace(p.Country)
+	if c, ok := lookupCountry(p.Country); ok {
+		p.Country = c
+	}
+	if p.Region != "" {
+		if r, ok := lookupRegion(p.Region, p.Country); ok {
+			p.Region = r.name
+			if p.Country == "" {
+				p.Country = r.country
+			}
+		}
+	}
+	if p.City == "" {
+		return p
+	}
+	c, ok := lookupCity(p.City, p.Region, p.Country)
+	if !ok {
+		return p
+	}
+	if p.HasCoordinates() {
+		c.Lat, c.Lng = p.Lat, p.Lng
+	}
+	return c
+}
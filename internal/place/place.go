@@ -0,0 +1,34 @@
+package place
+
+import (
+	"strings"
+)
+
+// Place is somewhere a human was born or is based, like a city. Any part of it can be missing,
+// e.g. when only the country someone was born in is known.
+type Place struct {
+	City    string `firestore:"city,omitempty"`
+	Region  string `firestore:"region,omitempty"` // state, province or prefecture
+	Country string `firestore:"country,omitempty"`
+	// Lat and Lng are where the place is on the map. Both are zero if that isn't known.
+	Lat float64 `firestore:"lat,omitempty"`
+	Lng float64 `firestore:"lng,omitempty"`
+}
+
+func (p Place) String() string {
+	var parts []string
+	for _, part := range []string{p.City, p.Region, p.Country} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+func (p Place) IsZero() bool {
+	return p.City == "" && p.Region == "" && p.Country == ""
+}
+
+func (p Place) HasCoordinates() bool {
+	return p.Lat != 0 || p.Lng != 0
+}
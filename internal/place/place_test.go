@@ -0,0 +1,52 @@
+package place
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	losAngeles := Place{City: "Los Angeles", Region: "California", Country: "United States", Lat: 34.05, Lng: -118.24}
+	tests := map[string]Place{
+		"Los Angeles, CA":              losAngeles,
+		"los angeles, California, USA": losAngeles,
+		"LA":                           losAngeles,
+		"NYC":                          {City: "New York City", Region: "New York", Country: "United States", Lat: 40.71, Lng: -74.01},
+		"Washington, D.C.":             {City: "Washington", Region: "District of Columbia", Country: "United States", Lat: 38.91, Lng: -77.04},
+		"Richmond, BC":                 {City: "Richmond", Region: "British Columbia", Country: "Canada", Lat: 49.17, Lng: -123.14},
+		"Richmond":                     {City: "Richmond", Region: "Virginia", Country: "United States", Lat: 37.54, Lng: -77.44},
+		"Saigon, Vietnam":              {City: "Ho Chi Minh City", Country: "Vietnam", Lat: 10.82, Lng: 106.63},
+		"Seoul, Korea":                 {City: "Seoul", Country: "South Korea", Lat: 37.57, Lng: 126.98},
+		"Taiwan":                       {Country: "Taiwan"},
+		"Texas":                        {Region: "Texas", Country: "United States"},
+		"Springfield, IL":              {City: "Springfield", Region: "Illinois", Country: "United States"},
+		"Hyderabad, Sindh, Pakistan":   {City: "Hyderabad, Sindh", Country: "Pakistan"},
+		"  ":                           {},
+	}
+	for input, want := range tests {
+		require.Equal(t, want, Parse(input), input)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	require.Equal(t,
+		Place{City: "Toronto", Region: "Ontario", Country: "Canada", Lat: 43.65, Lng: -79.38},
+		Resolve(Place{City: " toronto "}),
+	)
+	// coordinates that were set by hand are kept
+	require.Equal(t,
+		Place{City: "Queens", Region: "New York", Country: "United States", Lat: 40.76, Lng: -73.83},
+		Resolve(Place{City: "Flushing", Region: "NY", Lat: 40.76, Lng: -73.83}),
+	)
+	require.Equal(t,
+		Place{City: "Portland", Region: "Maine", Country: "United States"},
+		Resolve(Place{City: "Portland", Region: "ME"}),
+	)
+}
+
+func TestPlace_String(t *testing.T) {
+	require.Equal(t, "Honolulu, Hawaii, United States", Parse("Honolulu").String())
+	require.Equal(t, "Japan", Place{Country: "Japan"}.String())
+	require.True(t, Place{}.IsZero())
+}
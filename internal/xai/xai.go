@@ -154,17 +154,29 @@ type GeneratedHumanResponse struct {
 	Ethnicity   []string `json:"ethnicity"`
 	FullAsian   bool     `json:"full_asian"`
 	Description string   `json:"description"`
+	BirthPlace  string   `json:"birth_place"`
 	Location    []string `json:"location"`
 	Website     string   `json:"website"`
 	Twitter     string   `json:"twitter"`
 	Tags        []string `json:"tags"`
+	// Sources are what the facts above were taken from.
+	Sources []GeneratedSource `json:"sources"`
+}
+
+// GeneratedSource is a citation for a generated human. Fields are the keys of
+// GeneratedHumanResponse it backs up, named the way humandao.Source names them.
+type GeneratedSource struct {
+	URL       string   `json:"url"`
+	Title     string   `json:"title"`
+	Publisher string   `json:"publisher"`
+	Fields    []string `json:"fields"`
 }
 
 var humanPrompt = `
 Your task is to write a few sentences about %v.
 Here are some tags to help you identify this person: "%v".
 Your tone should neutral, like a biographer or how a Wikipedia article is written.
-Focus on providing factual information based on reliable sources. You do not need to site your sources at the end.
+Focus on providing factual information based on reliable sources, and cite every source you used in the "sources" key.
 Try to limit your response to two to four paragraphs. In your paragraphs you should include:
 1. What is their ethnicity and background? Clearly identify if they are of full Asian descent.
 2. Where are they from?
@@ -182,12 +194,18 @@ Along with the "description" key, Provide a JSON response with the following key
 * gender: the gender of the person, one of ["male", "female", "nonbinary"]
 * dob: the date of birth of the person in the format "YYYY-MM-DD". If you know only the year, use "YYYY". If you know only the year and month, use "YYYY-MM".
 * dod: the date of death of the person, if they died.
-* ethnicity: an array containing the ethnicity of the person. Provide multiple if they are mixed. Examples include: ["Chinese", "Korean", "Vietnamese"].
+* ethnicity: an array containing the ethnicity of the person. Provide multiple if they are mixed. Examples include: ["Chinese", "Korean", "Vietnamese"]. Use the most specific one that is known, such as "Hmong", "Punjabi" or "Okinawan", or a region like "Southeast Asian" when only that is known.
 * full_asian: a boolean indicating if the person is of full Asian descent (both parents).
-* location: an array of cities where the person was born, lived or is based out of.
+* birth_place: the city where the person was born, written as "City, State or Province, Country" (e.g. "Honolulu, Hawaii, United States", or "Seoul, South Korea").
+* location: an array of cities where the person lives or is based out of, each written like birth_place.
 * tags: an array of relevant tags to help identify the person, such as "actor", "activist", "politician", etc.
 * website: the website of the person, if they have one.
 * twitter: the twitter handle of the person, if they have one, in the format of "https://twitter.com/{handle}"
+* sources: an array of the sources your answer is based on, such as news articles, interviews or official biographies. Each source is an object with:
+  * url: the full URL of the source, starting with "https://". Only include URLs you are confident exist.
+  * title: the title of the page or article.
+  * publisher: who published it, such as "The New York Times".
+  * fields: which facts the source backs up, any of ["dob", "dod", "ethnicity", "description", "birth_place", "based_in", "tags"]. Leave it empty if the source backs up the whole answer.
 
 Your output should follow the following JSON template between the triple dashes:
 ---
@@ -199,14 +217,16 @@ Your output should follow the following JSON template between the triple dashes:
 	"ethnicity": [],
 	"full_asian": true,
 	"description": "",
+	"birth_place": "",
 	"location": [],
 	"website": "",
 	"twitter": "",
-	"tags": []
+	"tags": [],
+	"sources": [{"url": "", "title": "", "publisher": "", "fields": []}]
 }
 ---
 
-If any keys are missing, use an empty string instead.
+If any keys are missing, use an empty string instead. If you have no sources, use an empty array.
 Do not make up answers. If the person is too ambiguous, because there are multiple people with the same name,
 or because you don't know anything about this person, respond with the text: "error: ", and tell me why.
 